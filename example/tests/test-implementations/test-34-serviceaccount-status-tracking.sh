@@ -4,7 +4,7 @@
 # Self-contained under the first-owner-wins ownership gate (issue #43, PR #45):
 # provisions its OWN ConfigMap resolving to a DEDICATED namespace. Reusing the
 # baseline ConfigMap left this CR with zero owned namespaces, so
-# status.processedServiceAccounts stayed empty and the test failed for the
+# the ServiceAccount status stayed empty and the test failed for the
 # wrong reason (test harness collision, not an operator bug).
 # Source common functions
 if [ -z "$SCRIPT_DIR" ]; then
@@ -51,23 +51,25 @@ if ! wait_for_sa "$TEST_NS" "$SA_NAME" 90; then
     exit 1
 fi
 
-# status.processedServiceAccounts entries have the form "<namespace>/<sa-name>"
+# The PermissionBinderReport of the namespace lists "<namespace>/<sa-name>"
+# entries; the PermissionBinder status only counts them
 EXPECTED_ENTRY="${TEST_NS}/${SA_NAME}"
+REPORT_NAME="${PB_NAME}.${TEST_NS}"
 status_has_entry() {
-    kubectl get permissionbinder "$PB_NAME" -n "$NAMESPACE" -o json 2>/dev/null \
+    kubectl get permissionbinderreport "$REPORT_NAME" -n "$NAMESPACE" -o json 2>/dev/null \
         | jq -e --arg e "$EXPECTED_ENTRY" \
-            '.status.processedServiceAccounts // [] | index($e) != null' >/dev/null
+            '.status.serviceAccounts // [] | index($e) != null' >/dev/null
 }
 
 if wait_for_cmd 60 status_has_entry; then
-    SA_COUNT=$(kubectl get permissionbinder "$PB_NAME" -n "$NAMESPACE" -o json 2>/dev/null \
-        | jq '.status.processedServiceAccounts // [] | length')
+    SA_COUNT=$(kubectl get permissionbinder "$PB_NAME" -n "$NAMESPACE" \
+        -o jsonpath='{.status.reports.serviceAccounts}' 2>/dev/null)
     info_log "Processed ServiceAccounts tracked: $SA_COUNT"
     pass_test "ServiceAccount status tracking works"
 else
-    CURRENT=$(kubectl get permissionbinder "$PB_NAME" -n "$NAMESPACE" \
-        -o jsonpath='{.status.processedServiceAccounts}' 2>/dev/null)
-    fail_test "status.processedServiceAccounts missing entry $EXPECTED_ENTRY (current: ${CURRENT:-<empty>})"
+    CURRENT=$(kubectl get permissionbinderreport "$REPORT_NAME" -n "$NAMESPACE" \
+        -o jsonpath='{.status.serviceAccounts}' 2>/dev/null)
+    fail_test "PermissionBinderReport $REPORT_NAME missing entry $EXPECTED_ENTRY (current: ${CURRENT:-<empty>})"
     exit 1
 fi
 
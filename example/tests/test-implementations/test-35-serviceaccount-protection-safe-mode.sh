@@ -83,15 +83,15 @@ kubectl_retry kubectl annotate configmap "$CM_NAME" -n "$NAMESPACE" \
     test-reconcile="$(date +%s)" --overwrite >/dev/null 2>&1
 
 # Wait until the mapping removal reconciled: the Processed condition observes
-# the new generation AND processedServiceAccounts drains
+# the new generation AND the ServiceAccount count in status.reports drains
 mapping_removal_reconciled() {
     kubectl get permissionbinder "$PB_NAME" -n "$NAMESPACE" -o json 2>/dev/null | jq -e '
         (.metadata.generation as $g
          | .status.conditions // [] | any(.type == "Processed" and .observedGeneration == $g))
-        and ((.status.processedServiceAccounts // []) | length == 0)' >/dev/null
+        and ((.status.reports.serviceAccounts // 0) == 0)' >/dev/null
 }
 if ! wait_for_cmd 60 mapping_removal_reconciled; then
-    fail_test "Mapping removal not reconciled: status.processedServiceAccounts did not drain within $(e2e_max_wait 60)s"
+    fail_test "Mapping removal not reconciled: status.reports.serviceAccounts did not drain within $(e2e_max_wait 60)s"
     exit 1
 fi
 
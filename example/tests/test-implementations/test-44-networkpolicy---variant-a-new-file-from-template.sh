@@ -94,7 +94,7 @@ WAITED=0
 POLL_INTERVAL=2  # Faster polling: 2 seconds instead of 5
 NAMESPACES_FOUND=""
 while [ $WAITED -lt $MAX_WAIT ]; do
-    NAMESPACES_FOUND=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath='{.status.networkPolicies[*].namespace}' 2>/dev/null || echo "")
+    NAMESPACES_FOUND=$(kubectl get permissionbinderreports -n $NAMESPACE -o jsonpath='{.items[?(@.spec.permissionBinder=="test-permissionbinder-networkpolicy")].status.networkPolicy.namespace}' 2>/dev/null || echo "")
     if [ -n "$NAMESPACES_FOUND" ] && [ "$NAMESPACES_FOUND" != "" ]; then
         break
     fi
@@ -132,7 +132,7 @@ verify_pr_for_namespace() {
     
     # If PR number not found, check if PR state indicates it was merged (may need to get PR from GitHub)
     if [ -z "$pr_number" ] || [ "$pr_number" == "" ]; then
-        local pr_state=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath="{.status.networkPolicies[?(@.namespace==\"$namespace\")].state}" 2>/dev/null || echo "")
+        local pr_state=$(kubectl get permissionbinderreport "test-permissionbinder-networkpolicy.$namespace" -n $NAMESPACE -o jsonpath="{.status.networkPolicy.state}" 2>/dev/null || echo "")
         if [ "$pr_state" == "pr-merged" ] || [ "$pr_state" == "pr-pending" ]; then
             info_log "PR state found: $pr_state, but PR number missing. Checking GitHub for recent PRs..."
             if command -v gh &> /dev/null; then
@@ -234,7 +234,7 @@ verify_pr_for_namespace() {
     fi
     
     # Verify PR state in PermissionBinder status
-    local namespace_state=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath="{.status.networkPolicies[?(@.namespace==\"$namespace\")].state}" 2>/dev/null || echo "")
+    local namespace_state=$(kubectl get permissionbinderreport "test-permissionbinder-networkpolicy.$namespace" -n $NAMESPACE -o jsonpath="{.status.networkPolicy.state}" 2>/dev/null || echo "")
     if [ -n "$namespace_state" ]; then
         case "$namespace_state" in
             "pr-created"|"pr-pending"|"pr-merged")
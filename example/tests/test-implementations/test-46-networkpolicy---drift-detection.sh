@@ -41,7 +41,7 @@ fi
 GITHUB_REPO="lukasz-bielinski/tests-network-policies"
 
 # Get all namespaces with NetworkPolicy status
-ALL_NAMESPACES=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath='{.status.networkPolicies[*].namespace}' 2>/dev/null || echo "")
+ALL_NAMESPACES=$(kubectl get permissionbinderreports -n $NAMESPACE -o jsonpath='{.items[?(@.spec.permissionBinder=="test-permissionbinder-networkpolicy")].status.networkPolicy.namespace}' 2>/dev/null || echo "")
 
 if [ -n "$ALL_NAMESPACES" ] && [ "$ALL_NAMESPACES" != "" ]; then
     info_log "Found namespaces with NetworkPolicy status: $ALL_NAMESPACES"
@@ -31,7 +31,7 @@ info_log "Waiting for reconciliation (10s)"
 sleep 10
 
 # Verify kube-system is excluded from NetworkPolicy processing
-ALL_NAMESPACES=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath='{.status.networkPolicies[*].namespace}' 2>/dev/null || echo "")
+ALL_NAMESPACES=$(kubectl get permissionbinderreports -n $NAMESPACE -o jsonpath='{.items[?(@.spec.permissionBinder=="test-permissionbinder-networkpolicy")].status.networkPolicy.namespace}' 2>/dev/null || echo "")
 EXCLUDED_FOUND=false
 for ns in $ALL_NAMESPACES; do
     if [ "$ns" == "kube-system" ]; then
@@ -56,7 +56,7 @@ TEST_NAMESPACES=("test-app" "test-app-2")  # kube-system is excluded, so no PR s
 info_log "Verifying PRs for test namespaces (excluding kube-system)..."
 for test_ns in "${TEST_NAMESPACES[@]}"; do
     # Check if namespace has PR status (may or may not have PR, depending on previous tests)
-    NS_STATE=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath="{.status.networkPolicies[?(@.namespace==\"$test_ns\")].state}" 2>/dev/null || echo "")
+    NS_STATE=$(kubectl get permissionbinderreport "test-permissionbinder-networkpolicy.$test_ns" -n $NAMESPACE -o jsonpath="{.status.networkPolicy.state}" 2>/dev/null || echo "")
     if [ -n "$NS_STATE" ]; then
         info_log "Namespace $test_ns has state: $NS_STATE"
     fi
@@ -14,7 +14,7 @@ echo "--------------------------------------------"
 
 # This test verifies that stale PR detection runs
 # In real scenario, would wait for stalePRThreshold
-ALL_STATES=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath='{.status.networkPolicies[*].state}' 2>/dev/null || echo "")
+ALL_STATES=$(kubectl get permissionbinderreports -n $NAMESPACE -o jsonpath='{.items[?(@.spec.permissionBinder=="test-permissionbinder-networkpolicy")].status.networkPolicy.state}' 2>/dev/null || echo "")
 STALE_FOUND=false
 for state in $ALL_STATES; do
     if [ "$state" == "pr-stale" ]; then
@@ -25,7 +25,7 @@ done
 
 if [ "$STALE_FOUND" == "true" ]; then
     # Verify CreatedAt timestamp exists for stale PR
-    STALE_CREATED_AT=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath='{.status.networkPolicies[?(@.state=="pr-stale")].createdAt}' 2>/dev/null || echo "")
+    STALE_CREATED_AT=$(kubectl get permissionbinderreports -n $NAMESPACE -o jsonpath='{.items[?(@.status.networkPolicy.state=="pr-stale")].status.networkPolicy.createdAt}' 2>/dev/null || echo "")
     if [ -n "$STALE_CREATED_AT" ] && [ "$STALE_CREATED_AT" != "" ]; then
         pass_test "Stale PR detected with CreatedAt timestamp: $STALE_CREATED_AT"
     else
@@ -42,13 +42,13 @@ fi
 GITHUB_REPO="lukasz-bielinski/tests-network-policies"
 
 # Get all namespaces with NetworkPolicy status
-ALL_NAMESPACES=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath='{.status.networkPolicies[*].namespace}' 2>/dev/null || echo "")
+ALL_NAMESPACES=$(kubectl get permissionbinderreports -n $NAMESPACE -o jsonpath='{.items[?(@.spec.permissionBinder=="test-permissionbinder-networkpolicy")].status.networkPolicy.namespace}' 2>/dev/null || echo "")
 
 if [ -n "$ALL_NAMESPACES" ] && [ "$ALL_NAMESPACES" != "" ]; then
     info_log "Found namespaces with NetworkPolicy status: $ALL_NAMESPACES"
     info_log "Verifying PR states for all namespaces..."
     for ns in $ALL_NAMESPACES; do
-        NS_STATE=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath="{.status.networkPolicies[?(@.namespace==\"$ns\")].state}" 2>/dev/null || echo "")
+        NS_STATE=$(kubectl get permissionbinderreport "test-permissionbinder-networkpolicy.$ns" -n $NAMESPACE -o jsonpath="{.status.networkPolicy.state}" 2>/dev/null || echo "")
         info_log "  Namespace $ns: state=$NS_STATE"
     done
 else
@@ -91,7 +91,7 @@ WAITED=0
 POLL_INTERVAL=2
 NAMESPACES_FOUND=""
 while [ $WAITED -lt $MAX_WAIT ]; do
-    NAMESPACES_FOUND=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath='{.status.networkPolicies[*].namespace}' 2>/dev/null || echo "")
+    NAMESPACES_FOUND=$(kubectl get permissionbinderreports -n $NAMESPACE -o jsonpath='{.items[?(@.spec.permissionBinder=="test-permissionbinder-networkpolicy")].status.networkPolicy.namespace}' 2>/dev/null || echo "")
     if [ -n "$NAMESPACES_FOUND" ] && [ "$NAMESPACES_FOUND" != "" ]; then
         break
     fi
@@ -129,7 +129,7 @@ else
 fi
 
 # Verify operator continues processing other namespaces
-NAMESPACES_PROCESSED=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath='{.status.networkPolicies[*].namespace}' 2>/dev/null | wc -w || echo "0")
+NAMESPACES_PROCESSED=$(kubectl get permissionbinderreports -n $NAMESPACE -o jsonpath='{.items[?(@.spec.permissionBinder=="test-permissionbinder-networkpolicy")].status.networkPolicy.namespace}' 2>/dev/null | wc -w || echo "0")
 if [ "$NAMESPACES_PROCESSED" -gt 0 ]; then
     pass_test "Operator continues processing namespaces (graceful degradation)"
 else
@@ -146,7 +146,7 @@ verify_pr_for_namespace() {
     
     # If PR number not found, check if PR state indicates it was merged (may need to get PR from GitHub)
     if [ -z "$pr_number" ] || [ "$pr_number" == "" ]; then
-        local pr_state=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath="{.status.networkPolicies[?(@.namespace==\"$namespace\")].state}" 2>/dev/null || echo "")
+        local pr_state=$(kubectl get permissionbinderreport "test-permissionbinder-networkpolicy.$namespace" -n $NAMESPACE -o jsonpath="{.status.networkPolicy.state}" 2>/dev/null || echo "")
         if [ "$pr_state" == "pr-merged" ] || [ "$pr_state" == "pr-pending" ]; then
             info_log "PR state found: $pr_state, but PR number missing. Checking GitHub for recent PRs..."
             if command -v gh &> /dev/null; then
@@ -231,7 +231,7 @@ verify_pr_for_namespace() {
     fi
     
     # Verify PR state in PermissionBinder status
-    local namespace_state=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath="{.status.networkPolicies[?(@.namespace==\"$namespace\")].state}" 2>/dev/null || echo "")
+    local namespace_state=$(kubectl get permissionbinderreport "test-permissionbinder-networkpolicy.$namespace" -n $NAMESPACE -o jsonpath="{.status.networkPolicy.state}" 2>/dev/null || echo "")
     if [ -n "$namespace_state" ]; then
         case "$namespace_state" in
             "pr-created"|"pr-pending"|"pr-merged")
@@ -258,7 +258,7 @@ fi
 # (operator may skip them if they already have status from previous tests)
 info_log "Checking status of other namespaces (may be skipped if already processed)..."
 for ns in "test-app" "test-app-2"; do
-    NS_STATE=$(kubectl get permissionbinder test-permissionbinder-networkpolicy -n $NAMESPACE -o jsonpath="{.status.networkPolicies[?(@.namespace==\"$ns\")].state}" 2>/dev/null || echo "")
+    NS_STATE=$(kubectl get permissionbinderreport "test-permissionbinder-networkpolicy.$ns" -n $NAMESPACE -o jsonpath="{.status.networkPolicy.state}" 2>/dev/null || echo "")
     if [ -n "$NS_STATE" ]; then
         info_log "Namespace $ns has state: $NS_STATE (already processed - operator optimization)"
     else
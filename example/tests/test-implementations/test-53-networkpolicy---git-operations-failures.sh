@@ -103,7 +103,7 @@ sleep 20
 # 4. Verify operator recorded Git failure
 # ----------------------------------------------------------------------------
 # Check PermissionBinder status for error message
-ERROR_MESSAGE=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$TEST_NAMESPACE'")].errorMessage}' 2>/dev/null || echo "")
+ERROR_MESSAGE=$(kubectl get permissionbinderreport "$BINDER_NAME.$TEST_NAMESPACE" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.errorMessage}' 2>/dev/null || echo "")
 if [ -n "$ERROR_MESSAGE" ]; then
     pass_test "PermissionBinder status contains error message: $ERROR_MESSAGE"
 else
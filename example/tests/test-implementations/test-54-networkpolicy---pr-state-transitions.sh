@@ -107,7 +107,7 @@ if [ -z "$PR_NUMBER" ]; then
     exit 1
 fi
 
-INITIAL_STATE=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$TEST_NAMESPACE'")].state}' 2>/dev/null || echo "")
+INITIAL_STATE=$(kubectl get permissionbinderreport "$BINDER_NAME.$TEST_NAMESPACE" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.state}' 2>/dev/null || echo "")
 pass_test "PR created (number: $PR_NUMBER, initial state: ${INITIAL_STATE:-unknown})"
 
 # ----------------------------------------------------------------------------
@@ -124,7 +124,7 @@ info_log "Waiting for operator to detect merged state (up to 120s)"
 if wait_for_pr_state "$BINDER_NAME" "$TEST_NAMESPACE" "pr-merged" 120; then
     pass_test "PermissionBinder status transitioned to pr-merged"
 else
-    CURRENT_STATE=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$TEST_NAMESPACE'")].state}' 2>/dev/null || echo "")
+    CURRENT_STATE=$(kubectl get permissionbinderreport "$BINDER_NAME.$TEST_NAMESPACE" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.state}' 2>/dev/null || echo "")
     fail_test "Expected pr-merged state, current state: ${CURRENT_STATE:-unknown}"
 fi
 
@@ -175,7 +175,7 @@ NEW_PR=""
 MAX_WAIT=180
 WAITED=0
 while [ $WAITED -lt $MAX_WAIT ]; do
-    NEW_PR=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$TEST_NAMESPACE'")].prNumber}' 2>/dev/null || echo "")
+    NEW_PR=$(kubectl get permissionbinderreport "$BINDER_NAME.$TEST_NAMESPACE" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.prNumber}' 2>/dev/null || echo "")
     if [ -n "$NEW_PR" ] && [ "$NEW_PR" != "$INITIAL_PR" ]; then
         break
     fi
@@ -96,8 +96,8 @@ sleep 20
 # ----------------------------------------------------------------------------
 # 4. Validate failure captured in status/logs/metrics
 # ----------------------------------------------------------------------------
-STATUS_STATE=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$TEST_NAMESPACE'")].state}' 2>/dev/null || echo "")
-STATUS_ERROR=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$TEST_NAMESPACE'")].errorMessage}' 2>/dev/null || echo "")
+STATUS_STATE=$(kubectl get permissionbinderreport "$BINDER_NAME.$TEST_NAMESPACE" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.state}' 2>/dev/null || echo "")
+STATUS_ERROR=$(kubectl get permissionbinderreport "$BINDER_NAME.$TEST_NAMESPACE" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.errorMessage}' 2>/dev/null || echo "")
 
 if [ -n "$STATUS_ERROR" ]; then
     pass_test "Status error message captured: $STATUS_ERROR"
@@ -65,7 +65,7 @@ sleep 10
 # ----------------------------------------------------------------------------
 # 3. Validate no NetworkPolicy activity
 # ----------------------------------------------------------------------------
-STATUS_RAW=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies}' 2>/dev/null || echo "")
+STATUS_RAW=$(kubectl get permissionbinderreports -n "$NAMESPACE" -o jsonpath='{.items[?(@.spec.permissionBinder=="'"$BINDER_NAME"'")].status.networkPolicy}' 2>/dev/null || echo "")
 if [ -z "$STATUS_RAW" ] || [ "$STATUS_RAW" == "[]" ]; then
     pass_test "No NetworkPolicy status entries created (as expected)"
 else
@@ -126,7 +126,7 @@ EOF
 info_log "Waiting for removal reconciliation (up to 180s)"
 REMOVAL_STATE=""
 for i in {1..36}; do
-    REMOVAL_STATE=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$NAMESPACE_B'")].state}' 2>/dev/null || echo "")
+    REMOVAL_STATE=$(kubectl get permissionbinderreport "$BINDER_NAME.$NAMESPACE_B" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.state}' 2>/dev/null || echo "")
     if [ "$REMOVAL_STATE" == "pr-removal" ] || [ "$REMOVAL_STATE" == "removed" ]; then
         break
     fi
@@ -139,14 +139,14 @@ else
     fail_test "Namespace $NAMESPACE_B did not transition to removal state (current: ${REMOVAL_STATE:-none})"
 fi
 
-REMOVAL_PR=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$NAMESPACE_B'")].prNumber}' 2>/dev/null || echo "")
+REMOVAL_PR=$(kubectl get permissionbinderreport "$BINDER_NAME.$NAMESPACE_B" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.prNumber}' 2>/dev/null || echo "")
 if [ -n "$REMOVAL_PR" ]; then
     pass_test "Removal PR recorded for $NAMESPACE_B (PR: $REMOVAL_PR)"
 else
     info_log "⚠️  Removal PR number not recorded (may not have been created if namespace skipped)"
 fi
 
-REMOVED_AT=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$NAMESPACE_B'")].removedAt}' 2>/dev/null || echo "")
+REMOVED_AT=$(kubectl get permissionbinderreport "$BINDER_NAME.$NAMESPACE_B" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.removedAt}' 2>/dev/null || echo "")
 if [ -n "$REMOVED_AT" ]; then
     pass_test "RemovedAt timestamp populated: $REMOVED_AT"
 else
@@ -154,7 +154,7 @@ else
 fi
 
 # Verify remaining namespace still present
-STATE_A=$(kubectl get permissionbinder "$BINDER_NAME" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicies[?(@.namespace=="'$NAMESPACE_A'")].state}' 2>/dev/null || echo "")
+STATE_A=$(kubectl get permissionbinderreport "$BINDER_NAME.$NAMESPACE_A" -n "$NAMESPACE" -o jsonpath='{.status.networkPolicy.state}' 2>/dev/null || echo "")
 if [ -n "$STATE_A" ]; then
     pass_test "Namespace $NAMESPACE_A still tracked (state: $STATE_A)"
 else
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BindingLedgerSpec defines the desired state of BindingLedger
+type BindingLedgerSpec struct {
+	// PermissionBinder is the PermissionBinder whose resources this ledger owns
+	// +kubebuilder:validation:Required
+	PermissionBinder PermissionBinderReference `json:"permissionBinder"`
+}
+
+// BindingLedgerStatus defines the observed state of BindingLedger
+type BindingLedgerStatus struct {
+	// RoleBindings is the number of RoleBindings the ledger owns
+	RoleBindings int `json:"roleBindings"`
+
+	// ServiceAccounts is the number of ServiceAccounts the ledger owns
+	ServiceAccounts int `json:"serviceAccounts"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="PermissionBinder",type=string,JSONPath=`.spec.permissionBinder.name`
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.permissionBinder.namespace`
+// +kubebuilder:printcolumn:name="RoleBindings",type=integer,JSONPath=`.status.roleBindings`
+// +kubebuilder:printcolumn:name="ServiceAccounts",type=integer,JSONPath=`.status.serviceAccounts`
+
+// BindingLedger is a cluster-scoped owner of the resources a PermissionBinder
+// manages outside its own namespace (spec.ownerReferences), so that garbage
+// collection and tools such as kubectl tree see them. The operator creates and
+// deletes ledgers; namespaced owners cannot own objects in other namespaces.
+type BindingLedger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BindingLedgerSpec   `json:"spec,omitempty"`
+	Status BindingLedgerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BindingLedgerList contains a list of BindingLedger
+type BindingLedgerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BindingLedger `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BindingLedger{}, &BindingLedgerList{})
+}
@@ -34,6 +34,279 @@ type LdapSecretReference struct {
 	Namespace string `json:"namespace"`
 }
 
+// PermissionBinderReference identifies a PermissionBinder
+type PermissionBinderReference struct {
+	// Name of the PermissionBinder
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace of the PermissionBinder (default: the namespace of the referencing PermissionBinder)
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// DeletionProtectionSpec halts a reconciliation that would delete too many resources
+// at once (e.g. after a truncated whitelist or a role mapping change)
+type DeletionProtectionSpec struct {
+	// MaxDeletions is the number of RoleBindings, ServiceAccounts, token Secrets, LDAP
+	// groups (ldapGroupRetirement policy Delete) and NetworkPolicy removal Pull
+	// Requests one reconciliation may delete without acknowledgement (default 10)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDeletions *int32 `json:"maxDeletions,omitempty"`
+
+	// MaxDeletionPercent is the share (in percent) of the RoleBindings, ServiceAccounts,
+	// tracked LDAP groups and NetworkPolicy namespaces owned by this PermissionBinder
+	// one reconciliation may delete without acknowledgement (default 25)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxDeletionPercent *int32 `json:"maxDeletionPercent,omitempty"`
+
+	// MinDeletionsForPercent is the number of deletions up to which maxDeletionPercent
+	// does not apply, so that a PermissionBinder owning only a few resources can
+	// still delete one of them (default 3)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinDeletionsForPercent *int32 `json:"minDeletionsForPercent,omitempty"`
+}
+
+// OwnershipTransferSpec hands over namespaces to another PermissionBinder
+// The namespace objects and the whitelist RoleBindings this PermissionBinder owns
+// in them are re-stamped for the receiving PermissionBinder; afterwards whitelist
+// entries for these namespaces are no longer processed by this PermissionBinder.
+type OwnershipTransferSpec struct {
+	// Namespaces to hand over
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+
+	// To is the receiving PermissionBinder
+	// +kubebuilder:validation:Required
+	To PermissionBinderReference `json:"to"`
+}
+
+// LdapGroupMembersSpec configures declarative LDAP group membership management
+// Members are declared per group under the key "<group CN>.members", one member
+// per line (full user DN or sAMAccountName, "#" comments allowed). Groups without
+// such a key are not managed; an empty value removes all members.
+type LdapGroupMembersSpec struct {
+	// Enabled turns on membership management (requires ldapSecretRef)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ConfigMapName is a companion ConfigMap in configMapNamespace declaring the members
+	// Default: members are read from the whitelist ConfigMap
+	// +kubebuilder:validation:Optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// MaxRemovalsPerReconcile caps member removals per reconciliation across all groups
+	// Removals above the cap are deferred to the next reconciliation; 0 disables removals
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=10
+	MaxRemovalsPerReconcile *int32 `json:"maxRemovalsPerReconcile,omitempty"`
+}
+
+// LdapSchemaSpec selects the directory schema used for LDAP groups
+type LdapSchemaSpec struct {
+	// Profile is the directory schema profile
+	// ActiveDirectory: objectClass group with sAMAccountName, DN members
+	// GroupOfNames: OpenLDAP/389-DS groupOfNames, DN members (placeholderMember always kept as member)
+	// PosixGroup: posixGroup with an allocated gidNumber, memberUid members
+	// Custom: objectClasses and attributes templates below
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ActiveDirectory;GroupOfNames;PosixGroup;Custom
+	// +kubebuilder:default="ActiveDirectory"
+	Profile string `json:"profile,omitempty"`
+
+	// ObjectClasses of created groups (profile Custom)
+	// +kubebuilder:validation:Optional
+	ObjectClasses []string `json:"objectClasses,omitempty"`
+
+	// Attributes of created groups (profile Custom), as Go templates with one value per line
+	// Available fields: .GroupName, .Description, .ClusterName, .BindDN, .PlaceholderMember, .GidNumber
+	// Example: {"cn": "{{ .GroupName }}", "description": "{{ .Description }}"}
+	// Keep "description" so retirement can recognize operator-created groups
+	// +kubebuilder:validation:Optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// MemberAttribute overrides the group attribute holding members
+	// Default per profile: member (ActiveDirectory, GroupOfNames, Custom) or memberUid (PosixGroup)
+	// +kubebuilder:validation:Optional
+	MemberAttribute string `json:"memberAttribute,omitempty"`
+
+	// UserNameAttribute overrides the user attribute used to resolve non-DN members
+	// Default per profile: sAMAccountName (ActiveDirectory) or uid (others)
+	// +kubebuilder:validation:Optional
+	UserNameAttribute string `json:"userNameAttribute,omitempty"`
+
+	// GidNumberMin is the lowest gidNumber allocated for new groups (PosixGroup, or Custom using .GidNumber)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10000
+	GidNumberMin *int64 `json:"gidNumberMin,omitempty"`
+
+	// GidNumberMax is the highest gidNumber allocated for new groups
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=59999
+	GidNumberMax *int64 `json:"gidNumberMax,omitempty"`
+
+	// GidSearchBaseDN is the subtree searched for gidNumbers in use when allocating
+	// a new one; set it when posixGroups live outside the domain of the group DN
+	// Default: the DC=... suffix of the group DN
+	// +kubebuilder:validation:Optional
+	GidSearchBaseDN string `json:"gidSearchBaseDn,omitempty"`
+
+	// PlaceholderMember is the DN kept as member of every GroupOfNames group, as
+	// groupOfNames requires at least one member
+	// Default: the bind DN with the simple bind method; required with sasl_external
+	// and gssapi, whose bind identity is not a DN
+	// +kubebuilder:validation:Optional
+	PlaceholderMember string `json:"placeholderMember,omitempty"`
+}
+
+// LdapGroupRetirementSpec configures what happens to operator-created LDAP groups
+// whose whitelist entry was removed. Only groups whose description carries the
+// operator's creation marker for this cluster are ever touched.
+type LdapGroupRetirementSpec struct {
+	// Policy is the retirement action for groups of removed whitelist entries
+	// None: leave the group as-is (only tracked)
+	// Move: move the group into retiredOu
+	// Describe: append a retirement note to the group description
+	// Delete: append a retirement note and delete the group after gracePeriod
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=None;Move;Describe;Delete
+	// +kubebuilder:default="None"
+	Policy string `json:"policy,omitempty"`
+
+	// RetiredOU is the container retired groups are moved to (policy Move)
+	// Example: "OU=Retired,OU=Kubernetes,DC=example,DC=com"
+	// +kubebuilder:validation:Optional
+	RetiredOU string `json:"retiredOu,omitempty"`
+
+	// GracePeriod is how long a removed group is kept before deletion (policy Delete)
+	// Re-adding the whitelist entry within the grace period cancels the deletion
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="168h"
+	GracePeriod string `json:"gracePeriod,omitempty"`
+}
+
+// LdapGroupVerificationSpec configures the lookup of whitelist groups in LDAP
+// before their RoleBindings are created, so that typos in the whitelist do not
+// produce RoleBindings to groups nobody is a member of
+type LdapGroupVerificationSpec struct {
+	// Enabled turns on the verification (requires ldapSecretRef)
+	Enabled bool `json:"enabled"`
+
+	// MissingGroupPolicy decides what happens to entries whose group does not exist
+	// Skip: no RoleBinding is created for the entry
+	// Warn: the RoleBinding is created and the entry is reported as missing
+	// Create: the group is created in LDAP (the createLdapGroups behaviour)
+	// Defaults to Create when createLdapGroups is set, otherwise to Warn
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Skip;Warn;Create
+	MissingGroupPolicy string `json:"missingGroupPolicy,omitempty"`
+
+	// RecheckInterval is how often missing groups are looked up again while the
+	// whitelist is unchanged (e.g. a group created in AD after the entry was added)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="10m"
+	RecheckInterval string `json:"recheckInterval,omitempty"`
+}
+
+// LdapRateLimitSpec throttles LDAP operations and caches confirmed group existence
+type LdapRateLimitSpec struct {
+	// OperationsPerSecond is the sustained rate of LDAP operations (searches and
+	// writes) per LDAP Secret, shared by all PermissionBinders using the Secret.
+	// When they configure different values, the lowest applies.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=20
+	OperationsPerSecond *int32 `json:"operationsPerSecond,omitempty"`
+
+	// Burst is the number of LDAP operations allowed above the sustained rate
+	// (the lowest of the PermissionBinders sharing the Secret applies)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=40
+	Burst *int32 `json:"burst,omitempty"`
+
+	// ExistenceCacheTTL is how long a group confirmed to exist is not looked up
+	// again by createLdapGroups ("0s" disables the cache). Entries confirmed while
+	// processing the current whitelist version are skipped regardless of the TTL.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="30m"
+	ExistenceCacheTTL string `json:"existenceCacheTtl,omitempty"`
+}
+
+// WhitelistSourceSpec selects where the whitelist entries come from
+type WhitelistSourceSpec struct {
+	// Type is the whitelist source
+	// ConfigMap: whitelist.txt of configMapName (default)
+	// LdapSearch: the DNs returned by ldapSearch (via ldapSecretRef)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ConfigMap;LdapSearch
+	// +kubebuilder:default="ConfigMap"
+	Type string `json:"type,omitempty"`
+
+	// LdapSearch configures the LDAP search of the LdapSearch source
+	// +kubebuilder:validation:Optional
+	LdapSearch *LdapWhitelistSearchSpec `json:"ldapSearch,omitempty"`
+}
+
+// LdapWhitelistSearchSpec configures the LDAP search that discovers whitelist groups
+type LdapWhitelistSearchSpec struct {
+	// BaseDN is the search base
+	// Example: "OU=Kubernetes,DC=example,DC=com"
+	// +kubebuilder:validation:Required
+	BaseDN string `json:"baseDn"`
+
+	// Filter is the LDAP search filter selecting the permission groups
+	// Example: "(&(objectClass=group)(cn=COMPANY-K8S-*))"
+	// +kubebuilder:validation:Required
+	Filter string `json:"filter"`
+
+	// Scope is the search scope below baseDn
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Subtree;OneLevel
+	// +kubebuilder:default="Subtree"
+	Scope string `json:"scope,omitempty"`
+
+	// PageSize is the page size of the paged search
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=500
+	PageSize *int32 `json:"pageSize,omitempty"`
+
+	// Interval is how often the search runs; results are cached in between
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="15m"
+	Interval string `json:"interval,omitempty"`
+}
+
+// WhitelistSnapshotSpec configures the last-known-good whitelist snapshots. The
+// parsed whitelist of every successful reconciliation is kept in a managed
+// ConfigMap in the PermissionBinder's namespace; the annotation
+// permission-binder.io/rollback-to rolls back to one of them, and the latest is
+// used while the source ConfigMap is missing.
+type WhitelistSnapshotSpec struct {
+	// Enabled turns on the snapshots
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// History is the number of snapshots kept. Older snapshots are dropped earlier
+	// when the snapshot ConfigMap would exceed 900 KiB.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +kubebuilder:default=5
+	History *int32 `json:"history,omitempty"`
+}
+
 // ServiceAccountRoleRef defines the role reference for a ServiceAccount
 type ServiceAccountRoleRef struct {
 	// Kind of the role (ClusterRole or Role)
@@ -46,6 +319,113 @@ type ServiceAccountRoleRef struct {
 	Name string `json:"name"`
 }
 
+// ServiceAccountConfig defines a structured ServiceAccount entry
+// Supersedes serviceAccountMapping entries with the same name
+type ServiceAccountConfig struct {
+	// Name of the ServiceAccount entry (the {name} in serviceAccountNamingPattern)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Roles bound to the ServiceAccount in its namespace
+	// A single role is bound by sa-{namespace}-{name}; with several roles each is bound
+	// by sa-{namespace}-{name}-{kind}-{hash}, hash of the entry name and the role
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Roles []ServiceAccountRoleRef `json:"roles"`
+
+	// Annotations added to the ServiceAccount (e.g. IRSA or Workload Identity)
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels added to the ServiceAccount
+	// Labels managed by the operator (app.kubernetes.io/*) cannot be overridden
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// AutomountServiceAccountToken sets automountServiceAccountToken on the ServiceAccount
+	// Left unset when not specified
+	// +kubebuilder:validation:Optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+
+	// ImagePullSecrets are Secret names added to the ServiceAccount imagePullSecrets
+	// Secrets added by the platform (e.g. OpenShift dockercfg) are preserved
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Token configures an operator-managed token Secret for the ServiceAccount
+	// (e.g. for external CI systems)
+	// +kubebuilder:validation:Optional
+	Token *ServiceAccountTokenSpec `json:"token,omitempty"`
+}
+
+// ServiceAccountTokenSpec configures an operator-managed token Secret
+type ServiceAccountTokenSpec struct {
+	// Mode selects how the token is provided
+	//   - LongLived: a kubernetes.io/service-account-token Secret populated by Kubernetes (no expiry)
+	//   - TokenRequest: a token requested via the TokenRequest API and refreshed by the
+	//     operator after 80% of its lifetime (Opaque Secret with key "token")
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=LongLived;TokenRequest
+	// +kubebuilder:default=LongLived
+	Mode string `json:"mode,omitempty"`
+
+	// SecretName is the name of the token Secret in the ServiceAccount namespace
+	// Default: {serviceAccountName}-token
+	// +kubebuilder:validation:Optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Audiences of the requested token (TokenRequest only)
+	// Default: the API server audiences
+	// +kubebuilder:validation:Optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// ExpirationSeconds is the requested token lifetime (TokenRequest only)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=600
+	// +kubebuilder:default=3600
+	ExpirationSeconds int64 `json:"expirationSeconds,omitempty"`
+}
+
+// ServiceAccountOverride customizes the ServiceAccount mapping for the namespaces it selects
+// A namespace is selected when it matches any of namespaces, namespacePatterns or prefixes
+type ServiceAccountOverride struct {
+	// Namespaces is a list of explicit namespace names selected by this override
+	// +kubebuilder:validation:Optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespacePatterns are regex patterns selecting namespaces
+	// Example: ["^prod-.*", ".*-production$"]
+	// An invalid pattern selects nothing and is reported in the ServiceAccountsReconciled
+	// condition; ServiceAccounts are not pruned until it is fixed.
+	// +kubebuilder:validation:Optional
+	NamespacePatterns []string `json:"namespacePatterns,omitempty"`
+
+	// Prefixes selects namespaces derived from whitelist entries matching one of these prefixes
+	// Example: ["MT-K8S-DEV"]
+	// +kubebuilder:validation:Optional
+	Prefixes []string `json:"prefixes,omitempty"`
+
+	// ServiceAccountMapping is merged over the global serviceAccountMapping
+	// Adds extra ServiceAccounts or replaces the role of existing ones
+	// Example: "deploy: admin" binds deploy to ClusterRole "admin" in selected namespaces
+	// +kubebuilder:validation:Optional
+	ServiceAccountMapping map[string]string `json:"serviceAccountMapping,omitempty"`
+
+	// ServiceAccounts are structured entries merged over the global serviceAccounts
+	// +kubebuilder:validation:Optional
+	ServiceAccounts []ServiceAccountConfig `json:"serviceAccounts,omitempty"`
+
+	// Exclude lists ServiceAccount names (mapping keys) not created in selected namespaces
+	// +kubebuilder:validation:Optional
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Disabled creates no ServiceAccounts at all in selected namespaces (e.g. sandboxes)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Disabled bool `json:"disabled,omitempty"`
+}
+
 // PermissionBinderSpec defines the desired state of PermissionBinder
 type PermissionBinderSpec struct {
 	// RoleMapping defines mapping of role names to existing ClusterRoles
@@ -63,6 +443,8 @@ type PermissionBinderSpec struct {
 	ExcludeList []string `json:"excludeList,omitempty"`
 
 	// ConfigMapName is the name of the ConfigMap to watch for changes
+	// With whitelistSource.type LdapSearch its whitelist.txt is ignored and the
+	// ConfigMap is optional (it may still declare LDAP group members)
 	// +kubebuilder:validation:Required
 	ConfigMapName string `json:"configMapName"`
 
@@ -70,6 +452,55 @@ type PermissionBinderSpec struct {
 	// +kubebuilder:validation:Required
 	ConfigMapNamespace string `json:"configMapNamespace"`
 
+	// WhitelistSource selects where whitelist entries come from (default: the ConfigMap)
+	// +kubebuilder:validation:Optional
+	WhitelistSource *WhitelistSourceSpec `json:"whitelistSource,omitempty"`
+
+	// WhitelistSnapshots keeps the last-known-good whitelists for rollback and as a
+	// fallback when the source ConfigMap is missing (disabled when unset)
+	// +kubebuilder:validation:Optional
+	WhitelistSnapshots *WhitelistSnapshotSpec `json:"whitelistSnapshots,omitempty"`
+
+	// ClusterName identifies this cluster in LDAP group descriptions, NetworkPolicy
+	// Git paths and branch names, and metrics labels
+	// Takes precedence over the operator's --cluster-name flag (CLUSTER_NAME env),
+	// networkPolicy.gitRepository.clusterName and the detected cluster name
+	// (OpenShift Infrastructure object, cluster-info ConfigMap)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Transfers hands over namespaces managed by this PermissionBinder to other
+	// PermissionBinders (ownership handover without deleting this CR)
+	// +kubebuilder:validation:Optional
+	Transfers []OwnershipTransferSpec `json:"transfers,omitempty"`
+
+	// OwnerReferences also records ownership in ownerReferences, for garbage collection
+	// and tools such as kubectl tree: RoleBindings and ServiceAccounts in this
+	// namespace are owned by the PermissionBinder, those in other namespaces by its
+	// cluster-scoped BindingLedger. Namespaces never get ownerReferences. The
+	// references are removed before the PermissionBinder is deleted (SAFE MODE).
+	// Not safe with foreground deletion: a foreground deletion of the PermissionBinder
+	// or the BindingLedger lets garbage collection delete the owned resources before
+	// the operator has released them - delete with the background or orphan
+	// propagation policy (config/admission rejects foreground deletion).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OwnerReferences bool `json:"ownerReferences,omitempty"`
+
+	// Paused stops this PermissionBinder from applying changes: reconciliation still
+	// computes the whitelist and reports the changes it withholds in
+	// status.withheldChanges, but creates, updates and deletes nothing
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Paused bool `json:"paused,omitempty"`
+
+	// DeletionProtection halts reconciliation before deletions exceeding its thresholds
+	// until they are acknowledged with the permission-binder.io/approve-deletions
+	// annotation (disabled when unset)
+	// +kubebuilder:validation:Optional
+	DeletionProtection *DeletionProtectionSpec `json:"deletionProtection,omitempty"`
+
 	// CreateLdapGroups enables automatic LDAP group creation for namespaces
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
@@ -86,6 +517,30 @@ type PermissionBinderSpec struct {
 	// +kubebuilder:default=true
 	LdapTlsVerify *bool `json:"ldapTlsVerify,omitempty"`
 
+	// LdapSchema selects the directory schema profile used to create and manage groups
+	// Default: ActiveDirectory
+	// +kubebuilder:validation:Optional
+	LdapSchema *LdapSchemaSpec `json:"ldapSchema,omitempty"`
+
+	// LdapGroupMembers enables declarative membership management for LDAP groups
+	// of the whitelist (member attribute reconciled via LDAP Modify)
+	// +kubebuilder:validation:Optional
+	LdapGroupMembers *LdapGroupMembersSpec `json:"ldapGroupMembers,omitempty"`
+
+	// LdapGroupRetirement configures the lifecycle of operator-created LDAP groups
+	// whose whitelist entry was removed (requires createLdapGroups)
+	// +kubebuilder:validation:Optional
+	LdapGroupRetirement *LdapGroupRetirementSpec `json:"ldapGroupRetirement,omitempty"`
+
+	// LdapGroupVerification looks up every whitelist group in LDAP before binding it
+	// +kubebuilder:validation:Optional
+	LdapGroupVerification *LdapGroupVerificationSpec `json:"ldapGroupVerification,omitempty"`
+
+	// LdapRateLimit throttles LDAP operations (default 20/s, burst 40) and sets how
+	// long confirmed group existence is cached by createLdapGroups (default 30m)
+	// +kubebuilder:validation:Optional
+	LdapRateLimit *LdapRateLimitSpec `json:"ldapRateLimit,omitempty"`
+
 	// ServiceAccountMapping defines mapping of service account names to roles
 	// Creates ServiceAccounts with pattern defined by serviceAccountNamingPattern
 	// Example: "deploy: edit" creates SA with ClusterRole "edit"
@@ -105,6 +560,31 @@ type PermissionBinderSpec struct {
 	// +kubebuilder:default="{namespace}-sa-{name}"
 	ServiceAccountNamingPattern string `json:"serviceAccountNamingPattern,omitempty"`
 
+	// ServiceAccounts defines structured ServiceAccount entries
+	// Supports Role and ClusterRole references, multiple roles per ServiceAccount,
+	// annotations, labels, automountServiceAccountToken and imagePullSecrets
+	// An entry replaces the serviceAccountMapping entry with the same name
+	// +kubebuilder:validation:Optional
+	ServiceAccounts []ServiceAccountConfig `json:"serviceAccounts,omitempty"`
+
+	// ServiceAccountPruneMode defines what happens to ServiceAccounts (and their
+	// sa-{namespace}-{name} RoleBindings) owned by this PermissionBinder that are
+	// no longer in the desired set - because the key was removed from
+	// serviceAccountMapping or the namespace left the whitelist
+	//   - Orphan: keep the resources and mark them with orphaned-at/orphaned-by
+	//     annotations (SAFE MODE, default)
+	//   - Delete: delete the RoleBinding and the ServiceAccount
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Orphan;Delete
+	// +kubebuilder:default=Orphan
+	ServiceAccountPruneMode string `json:"serviceAccountPruneMode,omitempty"`
+
+	// ServiceAccountOverrides customize serviceAccountMapping for selected namespaces
+	// Overrides are evaluated in order and the first matching override wins
+	// Namespaces without a matching override use serviceAccountMapping unchanged
+	// +kubebuilder:validation:Optional
+	ServiceAccountOverrides []ServiceAccountOverride `json:"serviceAccountOverrides,omitempty"`
+
 	// NetworkPolicy configuration for GitOps-based Network Policy management
 	// +kubebuilder:validation:Optional
 	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
@@ -191,8 +671,10 @@ type GitRepositorySpec struct {
 
 	// ClusterName is the cluster name used in Git repository paths
 	// Example: "DEV-cluster" -> networkpolicies/DEV-cluster/...
-	// +kubebuilder:validation:Required
-	ClusterName string `json:"clusterName"`
+	// Deprecated in favor of spec.clusterName; used when neither spec.clusterName
+	// nor the operator's cluster name is set
+	// +kubebuilder:validation:Optional
+	ClusterName string `json:"clusterName,omitempty"`
 
 	// CredentialsSecretRef references a Secret containing Git credentials
 	// Required keys: token (and optionally username, email)
@@ -264,25 +746,453 @@ type BatchProcessingSpec struct {
 	SleepBetweenBatches string `json:"sleepBetweenBatches,omitempty"`
 }
 
+// ServiceAccountTokenStatus tracks an operator-managed ServiceAccount token Secret
+type ServiceAccountTokenStatus struct {
+	// Namespace of the ServiceAccount and the token Secret
+	Namespace string `json:"namespace"`
+
+	// ServiceAccount is the name of the ServiceAccount
+	ServiceAccount string `json:"serviceAccount"`
+
+	// SecretName is the name of the token Secret
+	SecretName string `json:"secretName"`
+
+	// Mode is LongLived or TokenRequest
+	Mode string `json:"mode"`
+
+	// LastRotationTime is when the current token was issued
+	// +kubebuilder:validation:Optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// ExpirationTime is when the current token expires (TokenRequest only)
+	// +kubebuilder:validation:Optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// NextRotationTime is when the operator refreshes the token (TokenRequest only)
+	// +kubebuilder:validation:Optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+}
+
+// LdapGroupStatus tracks an LDAP group created by the operator
+type LdapGroupStatus struct {
+	// DN is the distinguished name of the group as listed in the whitelist
+	DN string `json:"dn"`
+
+	// State is Active, Retired or PendingDeletion
+	State string `json:"state"`
+
+	// RemovedAt is when the whitelist entry of the group was removed
+	// +kubebuilder:validation:Optional
+	RemovedAt *metav1.Time `json:"removedAt,omitempty"`
+
+	// RetiredDN is the DN of the group after it was moved to the retired OU
+	// +kubebuilder:validation:Optional
+	RetiredDN string `json:"retiredDn,omitempty"`
+
+	// Namespace is the target namespace of the whitelist entry of the group, which
+	// selects the PermissionBinderReport that tracks it
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// WhitelistSourceStatus reports the last LDAP search of the LdapSearch whitelist source
+type WhitelistSourceStatus struct {
+	// Type is the whitelist source type
+	Type string `json:"type"`
+
+	// Entries is the number of DNs returned by the last successful search
+	Entries int `json:"entries"`
+
+	// Added is the number of DNs that appeared with the last search
+	// +kubebuilder:validation:Optional
+	Added int `json:"added,omitempty"`
+
+	// Removed is the number of DNs that disappeared with the last search
+	// +kubebuilder:validation:Optional
+	Removed int `json:"removed,omitempty"`
+
+	// LastSearchTime is when the search last succeeded
+	// +kubebuilder:validation:Optional
+	LastSearchTime *metav1.Time `json:"lastSearchTime,omitempty"`
+
+	// Error is set when the last search failed; the cached result is used meanwhile
+	// +kubebuilder:validation:Optional
+	Error string `json:"error,omitempty"`
+}
+
+// WhitelistSnapshotStatus reports the last-known-good whitelist snapshots
+type WhitelistSnapshotStatus struct {
+	// ConfigMap is the snapshot ConfigMap in the PermissionBinder's namespace
+	ConfigMap string `json:"configMap"`
+
+	// Current is the hash of the snapshot of the whitelist last applied
+	// +kubebuilder:validation:Optional
+	Current string `json:"current,omitempty"`
+
+	// Versions is the number of snapshots kept
+	Versions int `json:"versions"`
+
+	// Source is where the applied whitelist came from
+	// Source: the whitelist source (ConfigMap or LDAP search)
+	// Rollback: the snapshot named by permission-binder.io/rollback-to
+	// Fallback: the latest snapshot, because the source ConfigMap is missing
+	// +kubebuilder:validation:Optional
+	Source string `json:"source,omitempty"`
+}
+
+// ClusterIdentityStatus reports the resolved cluster name
+type ClusterIdentityStatus struct {
+	// Name is the cluster name used for LDAP group descriptions, Git paths and metrics
+	Name string `json:"name"`
+
+	// Source is where the name came from: spec, operator, gitRepository,
+	// infrastructure, cluster-info or default
+	Source string `json:"source"`
+
+	// Conflicts lists the configured sources that disagree with Name, as "source=value"
+	// +kubebuilder:validation:Optional
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// OwnerReferencesStatus reports the ownerReferences set for spec.ownerReferences
+type OwnerReferencesStatus struct {
+	// Ledger is the name of the BindingLedger owning the resources in other namespaces
+	Ledger string `json:"ledger"`
+
+	// OwnedByPermissionBinder is the number of resources owned by the PermissionBinder
+	OwnedByPermissionBinder int `json:"ownedByPermissionBinder"`
+
+	// OwnedByLedger is the number of resources owned by the BindingLedger
+	OwnedByLedger int `json:"ownedByLedger"`
+}
+
+// OwnershipTransferStatus reports a handover of namespaces between PermissionBinders
+type OwnershipTransferStatus struct {
+	// From is the handing-over PermissionBinder ("namespace/name"), set in incomingTransfers
+	// +kubebuilder:validation:Optional
+	From string `json:"from,omitempty"`
+
+	// To is the receiving PermissionBinder ("namespace/name"), set in transfers
+	// +kubebuilder:validation:Optional
+	To string `json:"to,omitempty"`
+
+	// Namespaces handed over
+	Namespaces []string `json:"namespaces"`
+
+	// State is Pending (not handed over yet) or Completed
+	// +kubebuilder:validation:Enum=Pending;Completed
+	State string `json:"state"`
+
+	// RoleBindings is the number of RoleBindings re-stamped for the receiver
+	// +kubebuilder:validation:Optional
+	RoleBindings int `json:"roleBindings,omitempty"`
+
+	// ServiceAccounts is the number of ServiceAccounts re-stamped for the receiver,
+	// together with their RoleBindings and token Secrets
+	// +kubebuilder:validation:Optional
+	ServiceAccounts int `json:"serviceAccounts,omitempty"`
+
+	// Message explains why a transfer is pending
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// TransferredAt is when the handover completed
+	// +kubebuilder:validation:Optional
+	TransferredAt *metav1.Time `json:"transferredAt,omitempty"`
+}
+
+// WhitelistEntryResult reports what the last reconciliation did with one whitelist line
+type WhitelistEntryResult struct {
+	// Line is the 1-based line number in whitelist.txt
+	Line int `json:"line"`
+
+	// CN of the entry (empty when the DN has none)
+	// +kubebuilder:validation:Optional
+	CN string `json:"cn,omitempty"`
+
+	// Namespace parsed from the CN
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Role parsed from the CN
+	// +kubebuilder:validation:Optional
+	Role string `json:"role,omitempty"`
+
+	// Outcome of the entry
+	// +kubebuilder:validation:Enum=Bound;Excluded;Invalid;LdapGroupMissing;Transferred;OwnershipConflict;Failed
+	Outcome string `json:"outcome"`
+
+	// Reason explains the outcome, e.g. the RoleBinding or the parse error
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// WhitelistEntriesSummary counts the whitelist entries of the last reconciliation per outcome
+type WhitelistEntriesSummary struct {
+	// Total is the number of whitelist entries (non-empty, non-comment lines)
+	Total int `json:"total"`
+
+	// Bound entries have their RoleBinding
+	Bound int `json:"bound"`
+
+	// Excluded entries match excludeList
+	Excluded int `json:"excluded"`
+
+	// Invalid entries are not a valid DN or permission string
+	Invalid int `json:"invalid"`
+
+	// LdapGroupMissing entries were skipped because their LDAP group does not exist
+	LdapGroupMissing int `json:"ldapGroupMissing"`
+
+	// Transferred entries belong to namespaces handed over to another PermissionBinder
+	Transferred int `json:"transferred"`
+
+	// OwnershipConflict entries have a RoleBinding claimed by another PermissionBinder
+	OwnershipConflict int `json:"ownershipConflict"`
+
+	// Failed entries could not be applied (namespace or RoleBinding write failed)
+	Failed int `json:"failed"`
+}
+
+// OwnershipConflict reports a resource this PermissionBinder refused to take over
+// because another PermissionBinder claims it (or, for token Secrets, because it
+// is not managed by this PermissionBinder)
+type OwnershipConflict struct {
+	// Kind of the resource: Namespace, RoleBinding or Secret
+	Kind string `json:"kind"`
+
+	// Namespace of the resource (empty for namespaces)
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the resource
+	Name string `json:"name"`
+
+	// ClaimedBy is the claiming PermissionBinder ("namespace/name", or the name for
+	// resources annotated before ownership became namespace-aware); empty for a
+	// Secret not managed by any PermissionBinder
+	// +kubebuilder:validation:Optional
+	ClaimedBy string `json:"claimedBy,omitempty"`
+}
+
+// BlockedDeletionsStatus reports the deletions halted by spec.deletionProtection
+type BlockedDeletionsStatus struct {
+	// Hash identifies the planned deletions; set the permission-binder.io/approve-deletions
+	// annotation to this value to let them proceed
+	Hash string `json:"hash"`
+
+	// Count is the number of planned deletions
+	Count int `json:"count"`
+
+	// Percent is the share of the resources owned by this PermissionBinder (see
+	// deletionProtection.maxDeletionPercent) the deletions amount to
+	Percent int `json:"percent"`
+
+	// Deletions lists the planned deletions (bounded), e.g. "RoleBinding payments/payments-admin",
+	// "LdapGroup CN=...", "NetworkPolicyRemoval payments"
+	// +kubebuilder:validation:Optional
+	Deletions []string `json:"deletions,omitempty"`
+}
+
+// LdapSyncStatus reports the last directory sync of a PermissionBinder
+type LdapSyncStatus struct {
+	// ObservedGeneration is the PermissionBinder generation of the last sync
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// WhitelistVersion is the whitelist version the last successful sync processed
+	// +kubebuilder:validation:Optional
+	WhitelistVersion string `json:"whitelistVersion,omitempty"`
+
+	// LastSyncTime is when the directory was last synced
+	// +kubebuilder:validation:Optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// MissingGroupsHash identifies the set of missing whitelist groups; the RBAC
+	// reconciliation runs again when it changes (missingGroupPolicy Skip)
+	// +kubebuilder:validation:Optional
+	MissingGroupsHash string `json:"missingGroupsHash,omitempty"`
+
+	// TotalGroups is the number of whitelist groups of the last sync
+	TotalGroups int `json:"totalGroups"`
+
+	// Groups is the per-group result of the last sync, missing and failed groups
+	// first (bounded; see totalGroups)
+	// +kubebuilder:validation:Optional
+	Groups []LdapGroupSyncStatus `json:"groups,omitempty"`
+}
+
+// LdapGroupSyncStatus is the directory state of one whitelist group
+type LdapGroupSyncStatus struct {
+	// DN is the whitelist entry
+	DN string `json:"dn"`
+
+	// State is present, created, missing, unverified or error
+	State string `json:"state"`
+
+	// Message explains missing, unverified and error states
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// LdapGroupVerificationStatus summarizes the last LDAP lookup of the whitelist groups
+type LdapGroupVerificationStatus struct {
+	// Present is the number of whitelist groups found in LDAP
+	Present int `json:"present"`
+
+	// Missing is the number of whitelist groups not found in LDAP
+	Missing int `json:"missing"`
+
+	// Created is the number of missing groups created by the operator (policy Create)
+	Created int `json:"created"`
+
+	// MissingGroups lists the DNs of the missing groups (at most 50)
+	// +kubebuilder:validation:Optional
+	MissingGroups []string `json:"missingGroups,omitempty"`
+
+	// Error is set when LDAP could not be queried; entries are then bound unverified
+	// +kubebuilder:validation:Optional
+	Error string `json:"error,omitempty"`
+
+	// VerifiedAt is when the whitelist groups were last looked up
+	// +kubebuilder:validation:Optional
+	VerifiedAt *metav1.Time `json:"verifiedAt,omitempty"`
+}
+
 // PermissionBinderStatus defines the observed state of PermissionBinder
 type PermissionBinderStatus struct {
-	// ProcessedRoleBindings contains the list of successfully created RoleBindings
+	// ProcessedRoleBindings contains the list of successfully created RoleBindings.
+	// Deprecated: no longer written, the RoleBindings are listed in the
+	// PermissionBinderReports (see reports).
 	ProcessedRoleBindings []string `json:"processedRoleBindings,omitempty"`
 
-	// ProcessedServiceAccounts contains the list of successfully created ServiceAccounts
+	// ProcessedServiceAccounts contains the list of successfully created ServiceAccounts.
+	// Deprecated: no longer written, the ServiceAccounts are listed in the
+	// PermissionBinderReports (see reports).
 	ProcessedServiceAccounts []string `json:"processedServiceAccounts,omitempty"`
 
+	// Reports summarizes the PermissionBinderReports that hold the per-namespace
+	// RoleBindings, ServiceAccounts and NetworkPolicy state
+	// +kubebuilder:validation:Optional
+	Reports *ReportsSummary `json:"reports,omitempty"`
+
+	// Entries counts the whitelist entries of the last reconciliation per outcome
+	// +kubebuilder:validation:Optional
+	Entries *WhitelistEntriesSummary `json:"entries,omitempty"`
+
+	// EntryResults reports the outcome of each whitelist line, entries that are not
+	// bound first (bounded; see entries for the totals)
+	// +kubebuilder:validation:Optional
+	EntryResults []WhitelistEntryResult `json:"entryResults,omitempty"`
+
+	// PrunedServiceAccounts is the number of ServiceAccounts deleted during the last
+	// reconciliation because they left the desired set (serviceAccountPruneMode=Delete)
+	// +kubebuilder:validation:Optional
+	PrunedServiceAccounts int `json:"prunedServiceAccounts,omitempty"`
+
+	// OrphanedServiceAccounts is the number of ServiceAccounts marked as orphaned during
+	// the last reconciliation because they left the desired set (serviceAccountPruneMode=Orphan)
+	// +kubebuilder:validation:Optional
+	OrphanedServiceAccounts int `json:"orphanedServiceAccounts,omitempty"`
+
+	// ServiceAccountTokens tracks the operator-managed ServiceAccount token Secrets.
+	// Deprecated: no longer written, migrated to the PermissionBinderReports (see reports).
+	// +kubebuilder:validation:Optional
+	ServiceAccountTokens []ServiceAccountTokenStatus `json:"serviceAccountTokens,omitempty"`
+
 	// LastProcessedConfigMapVersion tracks the last processed ConfigMap version
+	// (with the LdapSearch whitelist source: a hash of the search result)
 	LastProcessedConfigMapVersion string `json:"lastProcessedConfigMapVersion,omitempty"`
 
+	// WhitelistSource reports the LdapSearch whitelist source
+	// +kubebuilder:validation:Optional
+	WhitelistSource *WhitelistSourceStatus `json:"whitelistSource,omitempty"`
+
+	// WhitelistSnapshot reports the last-known-good whitelist snapshots
+	// +kubebuilder:validation:Optional
+	WhitelistSnapshot *WhitelistSnapshotStatus `json:"whitelistSnapshot,omitempty"`
+
+	// ClusterIdentity reports the cluster name in use and where it came from
+	// +kubebuilder:validation:Optional
+	ClusterIdentity *ClusterIdentityStatus `json:"clusterIdentity,omitempty"`
+
+	// Transfers reports the handovers of spec.transfers to other PermissionBinders
+	// +kubebuilder:validation:Optional
+	Transfers []OwnershipTransferStatus `json:"transfers,omitempty"`
+
+	// IncomingTransfers reports handovers from other PermissionBinders to this one
+	// +kubebuilder:validation:Optional
+	IncomingTransfers []OwnershipTransferStatus `json:"incomingTransfers,omitempty"`
+
+	// OwnerReferences reports the ownerReferences of spec.ownerReferences
+	// +kubebuilder:validation:Optional
+	OwnerReferences *OwnerReferencesStatus `json:"ownerReferences,omitempty"`
+
+	// OwnershipConflicts lists the resources of whitelist entries that are claimed by
+	// other PermissionBinders (bounded; see the OwnershipConflict condition for the total)
+	// +kubebuilder:validation:Optional
+	OwnershipConflicts []OwnershipConflict `json:"ownershipConflicts,omitempty"`
+
+	// WithheldChanges lists the changes computed but not applied while reconciliation is
+	// frozen or paused (bounded; see the Frozen condition for the total)
+	// +kubebuilder:validation:Optional
+	WithheldChanges []string `json:"withheldChanges,omitempty"`
+
+	// BreakGlassRoleBindings lists the RoleBindings ("namespace/name") of this
+	// PermissionBinder left alone because of the break-glass annotation
+	// +kubebuilder:validation:Optional
+	BreakGlassRoleBindings []string `json:"breakGlassRoleBindings,omitempty"`
+
+	// BlockedDeletions reports the deletions halted by spec.deletionProtection (see the
+	// Blocked condition)
+	// +kubebuilder:validation:Optional
+	BlockedDeletions *BlockedDeletionsStatus `json:"blockedDeletions,omitempty"`
+
+	// LastProcessedLdapMembersVersion tracks the last processed version of the
+	// companion LDAP members ConfigMap (ldapGroupMembers.configMapName)
+	// +kubebuilder:validation:Optional
+	LastProcessedLdapMembersVersion string `json:"lastProcessedLdapMembersVersion,omitempty"`
+
+	// PendingLdapMemberRemovals is the number of LDAP member removals deferred by
+	// ldapGroupMembers.maxRemovalsPerReconcile during the last reconciliation
+	// +kubebuilder:validation:Optional
+	PendingLdapMemberRemovals int `json:"pendingLdapMemberRemovals,omitempty"`
+
+	// LdapGroups tracks the LDAP groups created by the operator and their lifecycle state.
+	// Deprecated: no longer written, migrated to the PermissionBinderReports (see reports).
+	// +kubebuilder:validation:Optional
+	LdapGroups []LdapGroupStatus `json:"ldapGroups,omitempty"`
+
+	// LdapGroupVerification is the result of the last LDAP lookup of the whitelist
+	// groups (ldapGroupVerification)
+	// +kubebuilder:validation:Optional
+	LdapGroupVerification *LdapGroupVerificationStatus `json:"ldapGroupVerification,omitempty"`
+
+	// LdapSync reports the directory work of the LDAP controller (group verification,
+	// creation, lifecycle and members), which runs independently of RBAC reconciliation
+	// +kubebuilder:validation:Optional
+	LdapSync *LdapSyncStatus `json:"ldapSync,omitempty"`
+
+	// LastProcessedLdapMissingGroupsHash is the ldapSync.missingGroupsHash the RoleBindings
+	// were last reconciled with (missingGroupPolicy Skip)
+	// +kubebuilder:validation:Optional
+	LastProcessedLdapMissingGroupsHash string `json:"lastProcessedLdapMissingGroupsHash,omitempty"`
+
 	// LastProcessedRoleMappingHash tracks the hash of the last processed role mapping
 	// This is used to detect when role mapping changes and trigger reconciliation
 	LastProcessedRoleMappingHash string `json:"lastProcessedRoleMappingHash,omitempty"`
 
-	// Conditions represent the latest available observations of the PermissionBinder's state
+	// ObservedGeneration is the metadata.generation the status was last computed for
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the PermissionBinder's state:
+	// Ready, Degraded, WhitelistParsed, RBACReconciled, ServiceAccountsReconciled, LdapSynced,
+	// NetworkPoliciesSynced, WhitelistSnapshotRecorded and the safety conditions (Frozen, Blocked, BreakGlass, OwnershipConflict)
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
-	// NetworkPolicies contains the status of Network Policy management for each namespace
+	// NetworkPolicies contains the status of Network Policy management for each namespace.
+	// Deprecated: no longer written, migrated to the PermissionBinderReports (see reports).
 	// +kubebuilder:validation:Optional
 	NetworkPolicies []NetworkPolicyStatus `json:"networkPolicies,omitempty"`
 
@@ -291,6 +1201,30 @@ type PermissionBinderStatus struct {
 	LastNetworkPolicyReconciliation *metav1.Time `json:"lastNetworkPolicyReconciliation,omitempty"`
 }
 
+// ReportsSummary summarizes the PermissionBinderReports of a PermissionBinder
+type ReportsSummary struct {
+	// Reports is the number of PermissionBinderReports, one per target namespace
+	Reports int `json:"reports"`
+
+	// RoleBindings is the number of RoleBindings listed in the reports
+	RoleBindings int `json:"roleBindings"`
+
+	// ServiceAccounts is the number of ServiceAccounts listed in the reports
+	ServiceAccounts int `json:"serviceAccounts"`
+
+	// NetworkPolicies counts the namespaces with Network Policy management by state
+	// +kubebuilder:validation:Optional
+	NetworkPolicies map[string]int `json:"networkPolicies,omitempty"`
+
+	// ServiceAccountTokens is the number of token Secrets tracked in the reports
+	// +kubebuilder:validation:Optional
+	ServiceAccountTokens int `json:"serviceAccountTokens,omitempty"`
+
+	// LdapGroups counts the LDAP groups tracked in the reports by lifecycle state
+	// +kubebuilder:validation:Optional
+	LdapGroups map[string]int `json:"ldapGroups,omitempty"`
+}
+
 // NetworkPolicyStatus tracks the status of Network Policy management for a namespace
 type NetworkPolicyStatus struct {
 	// Namespace is the namespace name
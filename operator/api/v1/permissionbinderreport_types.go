@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PermissionBinderReportSpec defines what a PermissionBinderReport reports on
+type PermissionBinderReportSpec struct {
+	// PermissionBinder is the name of the PermissionBinder in the namespace of the report
+	// +kubebuilder:validation:Required
+	PermissionBinder string `json:"permissionBinder"`
+
+	// TargetNamespace is the namespace whose resources the report lists
+	// +kubebuilder:validation:Required
+	TargetNamespace string `json:"targetNamespace"`
+}
+
+// PermissionBinderReportStatus defines the observed state of PermissionBinderReport
+type PermissionBinderReportStatus struct {
+	// RoleBindings lists the RoleBindings created for the whitelist in the target
+	// namespace ("namespace/name")
+	// +kubebuilder:validation:Optional
+	RoleBindings []string `json:"roleBindings,omitempty"`
+
+	// ServiceAccounts lists the ServiceAccounts created in the target namespace
+	// ("namespace/name")
+	// +kubebuilder:validation:Optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// NetworkPolicy is the state of Network Policy management for the target namespace
+	// +kubebuilder:validation:Optional
+	NetworkPolicy *NetworkPolicyStatus `json:"networkPolicy,omitempty"`
+
+	// ServiceAccountTokens tracks the operator-managed token Secrets in the target
+	// namespace
+	// +kubebuilder:validation:Optional
+	ServiceAccountTokens []ServiceAccountTokenStatus `json:"serviceAccountTokens,omitempty"`
+
+	// LdapGroups tracks the LDAP groups created by the operator for whitelist entries
+	// of the target namespace and their lifecycle state
+	// +kubebuilder:validation:Optional
+	LdapGroups []LdapGroupStatus `json:"ldapGroups,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="PermissionBinder",type=string,JSONPath=`.spec.permissionBinder`
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetNamespace`
+// +kubebuilder:printcolumn:name="NetworkPolicy",type=string,JSONPath=`.status.networkPolicy.state`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PermissionBinderReport holds the per-item state of one PermissionBinder for one
+// target namespace, so that the PermissionBinder status only carries summaries and
+// stays small with large whitelists. The operator creates, updates and deletes the
+// reports in the namespace of their PermissionBinder, which owns them.
+type PermissionBinderReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PermissionBinderReportSpec   `json:"spec,omitempty"`
+	Status PermissionBinderReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PermissionBinderReportList contains a list of PermissionBinderReport
+type PermissionBinderReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PermissionBinderReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PermissionBinderReport{}, &PermissionBinderReportList{})
+}
@@ -56,301 +56,1310 @@ func (in *BatchProcessingSpec) DeepCopy() *BatchProcessingSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitRepositorySpec) DeepCopyInto(out *GitRepositorySpec) {
+func (in *BindingLedger) DeepCopyInto(out *BindingLedger) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(LdapSecretReference)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingLedger.
+func (in *BindingLedger) DeepCopy() *BindingLedger {
+	if in == nil {
+		return nil
 	}
-	if in.GitTlsVerify != nil {
-		in, out := &in.GitTlsVerify, &out.GitTlsVerify
-		*out = new(bool)
-		**out = **in
+	out := new(BindingLedger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BindingLedger) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepositorySpec.
-func (in *GitRepositorySpec) DeepCopy() *GitRepositorySpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindingLedgerList) DeepCopyInto(out *BindingLedgerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BindingLedger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingLedgerList.
+func (in *BindingLedgerList) DeepCopy() *BindingLedgerList {
 	if in == nil {
 		return nil
 	}
-	out := new(GitRepositorySpec)
+	out := new(BindingLedgerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BindingLedgerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LdapSecretReference) DeepCopyInto(out *LdapSecretReference) {
+func (in *BindingLedgerSpec) DeepCopyInto(out *BindingLedgerSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapSecretReference.
-func (in *LdapSecretReference) DeepCopy() *LdapSecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingLedgerSpec.
+func (in *BindingLedgerSpec) DeepCopy() *BindingLedgerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LdapSecretReference)
+	out := new(BindingLedgerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamespaceExcludeList) DeepCopyInto(out *NamespaceExcludeList) {
+func (in *BindingLedgerStatus) DeepCopyInto(out *BindingLedgerStatus) {
 	*out = *in
-	if in.Patterns != nil {
-		in, out := &in.Patterns, &out.Patterns
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingLedgerStatus.
+func (in *BindingLedgerStatus) DeepCopy() *BindingLedgerStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Explicit != nil {
-		in, out := &in.Explicit, &out.Explicit
+	out := new(BindingLedgerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockedDeletionsStatus) DeepCopyInto(out *BlockedDeletionsStatus) {
+	*out = *in
+	if in.Deletions != nil {
+		in, out := &in.Deletions, &out.Deletions
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceExcludeList.
-func (in *NamespaceExcludeList) DeepCopy() *NamespaceExcludeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockedDeletionsStatus.
+func (in *BlockedDeletionsStatus) DeepCopy() *BlockedDeletionsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NamespaceExcludeList)
+	out := new(BlockedDeletionsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+func (in *ClusterIdentityStatus) DeepCopyInto(out *ClusterIdentityStatus) {
 	*out = *in
-	if in.GitRepository != nil {
-		in, out := &in.GitRepository, &out.GitRepository
-		*out = new(GitRepositorySpec)
-		(*in).DeepCopyInto(*out)
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ExcludeNamespaces != nil {
-		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
-		*out = new(NamespaceExcludeList)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIdentityStatus.
+func (in *ClusterIdentityStatus) DeepCopy() *ClusterIdentityStatus {
+	if in == nil {
+		return nil
 	}
-	if in.ExcludeBackupForNamespaces != nil {
-		in, out := &in.ExcludeBackupForNamespaces, &out.ExcludeBackupForNamespaces
-		*out = new(NamespaceExcludeList)
-		(*in).DeepCopyInto(*out)
+	out := new(ClusterIdentityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionProtectionSpec) DeepCopyInto(out *DeletionProtectionSpec) {
+	*out = *in
+	if in.MaxDeletions != nil {
+		in, out := &in.MaxDeletions, &out.MaxDeletions
+		*out = new(int32)
+		**out = **in
 	}
-	if in.AutoMerge != nil {
-		in, out := &in.AutoMerge, &out.AutoMerge
-		*out = new(AutoMergeSpec)
+	if in.MaxDeletionPercent != nil {
+		in, out := &in.MaxDeletionPercent, &out.MaxDeletionPercent
+		*out = new(int32)
 		**out = **in
 	}
-	if in.BatchProcessing != nil {
-		in, out := &in.BatchProcessing, &out.BatchProcessing
-		*out = new(BatchProcessingSpec)
+	if in.MinDeletionsForPercent != nil {
+		in, out := &in.MinDeletionsForPercent, &out.MinDeletionsForPercent
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
-func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionProtectionSpec.
+func (in *DeletionProtectionSpec) DeepCopy() *DeletionProtectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicySpec)
+	out := new(DeletionProtectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicyStatus) DeepCopyInto(out *NetworkPolicyStatus) {
+func (in *GitRepositorySpec) DeepCopyInto(out *GitRepositorySpec) {
 	*out = *in
-	if in.PRNumber != nil {
-		in, out := &in.PRNumber, &out.PRNumber
-		*out = new(int)
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(LdapSecretReference)
 		**out = **in
 	}
-	if in.LastTemplateCheckTime != nil {
-		in, out := &in.LastTemplateCheckTime, &out.LastTemplateCheckTime
-		*out = (*in).DeepCopy()
+	if in.GitTlsVerify != nil {
+		in, out := &in.GitTlsVerify, &out.GitTlsVerify
+		*out = new(bool)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStatus.
-func (in *NetworkPolicyStatus) DeepCopy() *NetworkPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepositorySpec.
+func (in *GitRepositorySpec) DeepCopy() *GitRepositorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicyStatus)
+	out := new(GitRepositorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PermissionBinder) DeepCopyInto(out *PermissionBinder) {
+func (in *LdapGroupMembersSpec) DeepCopyInto(out *LdapGroupMembersSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.MaxRemovalsPerReconcile != nil {
+		in, out := &in.MaxRemovalsPerReconcile, &out.MaxRemovalsPerReconcile
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinder.
-func (in *PermissionBinder) DeepCopy() *PermissionBinder {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapGroupMembersSpec.
+func (in *LdapGroupMembersSpec) DeepCopy() *LdapGroupMembersSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PermissionBinder)
+	out := new(LdapGroupMembersSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PermissionBinder) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapGroupRetirementSpec) DeepCopyInto(out *LdapGroupRetirementSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapGroupRetirementSpec.
+func (in *LdapGroupRetirementSpec) DeepCopy() *LdapGroupRetirementSpec {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(LdapGroupRetirementSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PermissionBinderList) DeepCopyInto(out *PermissionBinderList) {
+func (in *LdapGroupStatus) DeepCopyInto(out *LdapGroupStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PermissionBinder, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.RemovedAt != nil {
+		in, out := &in.RemovedAt, &out.RemovedAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderList.
-func (in *PermissionBinderList) DeepCopy() *PermissionBinderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapGroupStatus.
+func (in *LdapGroupStatus) DeepCopy() *LdapGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PermissionBinderList)
+	out := new(LdapGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PermissionBinderList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapGroupSyncStatus) DeepCopyInto(out *LdapGroupSyncStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapGroupSyncStatus.
+func (in *LdapGroupSyncStatus) DeepCopy() *LdapGroupSyncStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(LdapGroupSyncStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PermissionBinderSpec) DeepCopyInto(out *PermissionBinderSpec) {
+func (in *LdapGroupVerificationSpec) DeepCopyInto(out *LdapGroupVerificationSpec) {
 	*out = *in
-	if in.RoleMapping != nil {
-		in, out := &in.RoleMapping, &out.RoleMapping
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Prefixes != nil {
-		in, out := &in.Prefixes, &out.Prefixes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ExcludeList != nil {
-		in, out := &in.ExcludeList, &out.ExcludeList
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.LdapSecretRef != nil {
-		in, out := &in.LdapSecretRef, &out.LdapSecretRef
-		*out = new(LdapSecretReference)
-		**out = **in
-	}
-	if in.LdapTlsVerify != nil {
-		in, out := &in.LdapTlsVerify, &out.LdapTlsVerify
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ServiceAccountMapping != nil {
-		in, out := &in.ServiceAccountMapping, &out.ServiceAccountMapping
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.NetworkPolicy != nil {
-		in, out := &in.NetworkPolicy, &out.NetworkPolicy
-		*out = new(NetworkPolicySpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderSpec.
-func (in *PermissionBinderSpec) DeepCopy() *PermissionBinderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapGroupVerificationSpec.
+func (in *LdapGroupVerificationSpec) DeepCopy() *LdapGroupVerificationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PermissionBinderSpec)
+	out := new(LdapGroupVerificationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PermissionBinderStatus) DeepCopyInto(out *PermissionBinderStatus) {
+func (in *LdapGroupVerificationStatus) DeepCopyInto(out *LdapGroupVerificationStatus) {
 	*out = *in
-	if in.ProcessedRoleBindings != nil {
-		in, out := &in.ProcessedRoleBindings, &out.ProcessedRoleBindings
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ProcessedServiceAccounts != nil {
-		in, out := &in.ProcessedServiceAccounts, &out.ProcessedServiceAccounts
+	if in.MissingGroups != nil {
+		in, out := &in.MissingGroups, &out.MissingGroups
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.NetworkPolicies != nil {
-		in, out := &in.NetworkPolicies, &out.NetworkPolicies
-		*out = make([]NetworkPolicyStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LastNetworkPolicyReconciliation != nil {
-		in, out := &in.LastNetworkPolicyReconciliation, &out.LastNetworkPolicyReconciliation
+	if in.VerifiedAt != nil {
+		in, out := &in.VerifiedAt, &out.VerifiedAt
 		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderStatus.
-func (in *PermissionBinderStatus) DeepCopy() *PermissionBinderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapGroupVerificationStatus.
+func (in *LdapGroupVerificationStatus) DeepCopy() *LdapGroupVerificationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PermissionBinderStatus)
+	out := new(LdapGroupVerificationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceAccountRoleRef) DeepCopyInto(out *ServiceAccountRoleRef) {
+func (in *LdapRateLimitSpec) DeepCopyInto(out *LdapRateLimitSpec) {
 	*out = *in
+	if in.OperationsPerSecond != nil {
+		in, out := &in.OperationsPerSecond, &out.OperationsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountRoleRef.
-func (in *ServiceAccountRoleRef) DeepCopy() *ServiceAccountRoleRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapRateLimitSpec.
+func (in *LdapRateLimitSpec) DeepCopy() *LdapRateLimitSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceAccountRoleRef)
+	out := new(LdapRateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapSchemaSpec) DeepCopyInto(out *LdapSchemaSpec) {
+	*out = *in
+	if in.ObjectClasses != nil {
+		in, out := &in.ObjectClasses, &out.ObjectClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.GidNumberMin != nil {
+		in, out := &in.GidNumberMin, &out.GidNumberMin
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GidNumberMax != nil {
+		in, out := &in.GidNumberMax, &out.GidNumberMax
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapSchemaSpec.
+func (in *LdapSchemaSpec) DeepCopy() *LdapSchemaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapSchemaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapSecretReference) DeepCopyInto(out *LdapSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapSecretReference.
+func (in *LdapSecretReference) DeepCopy() *LdapSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapSyncStatus) DeepCopyInto(out *LdapSyncStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]LdapGroupSyncStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapSyncStatus.
+func (in *LdapSyncStatus) DeepCopy() *LdapSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapWhitelistSearchSpec) DeepCopyInto(out *LdapWhitelistSearchSpec) {
+	*out = *in
+	if in.PageSize != nil {
+		in, out := &in.PageSize, &out.PageSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapWhitelistSearchSpec.
+func (in *LdapWhitelistSearchSpec) DeepCopy() *LdapWhitelistSearchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapWhitelistSearchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceExcludeList) DeepCopyInto(out *NamespaceExcludeList) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Explicit != nil {
+		in, out := &in.Explicit, &out.Explicit
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceExcludeList.
+func (in *NamespaceExcludeList) DeepCopy() *NamespaceExcludeList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceExcludeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	if in.GitRepository != nil {
+		in, out := &in.GitRepository, &out.GitRepository
+		*out = new(GitRepositorySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = new(NamespaceExcludeList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeBackupForNamespaces != nil {
+		in, out := &in.ExcludeBackupForNamespaces, &out.ExcludeBackupForNamespaces
+		*out = new(NamespaceExcludeList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoMerge != nil {
+		in, out := &in.AutoMerge, &out.AutoMerge
+		*out = new(AutoMergeSpec)
+		**out = **in
+	}
+	if in.BatchProcessing != nil {
+		in, out := &in.BatchProcessing, &out.BatchProcessing
+		*out = new(BatchProcessingSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyStatus) DeepCopyInto(out *NetworkPolicyStatus) {
+	*out = *in
+	if in.PRNumber != nil {
+		in, out := &in.PRNumber, &out.PRNumber
+		*out = new(int)
+		**out = **in
+	}
+	if in.LastTemplateCheckTime != nil {
+		in, out := &in.LastTemplateCheckTime, &out.LastTemplateCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStatus.
+func (in *NetworkPolicyStatus) DeepCopy() *NetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnerReferencesStatus) DeepCopyInto(out *OwnerReferencesStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerReferencesStatus.
+func (in *OwnerReferencesStatus) DeepCopy() *OwnerReferencesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerReferencesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnershipConflict) DeepCopyInto(out *OwnershipConflict) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnershipConflict.
+func (in *OwnershipConflict) DeepCopy() *OwnershipConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnershipConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnershipTransferSpec) DeepCopyInto(out *OwnershipTransferSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnershipTransferSpec.
+func (in *OwnershipTransferSpec) DeepCopy() *OwnershipTransferSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnershipTransferSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnershipTransferStatus) DeepCopyInto(out *OwnershipTransferStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TransferredAt != nil {
+		in, out := &in.TransferredAt, &out.TransferredAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnershipTransferStatus.
+func (in *OwnershipTransferStatus) DeepCopy() *OwnershipTransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnershipTransferStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinder) DeepCopyInto(out *PermissionBinder) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinder.
+func (in *PermissionBinder) DeepCopy() *PermissionBinder {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PermissionBinder) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinderList) DeepCopyInto(out *PermissionBinderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PermissionBinder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderList.
+func (in *PermissionBinderList) DeepCopy() *PermissionBinderList {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PermissionBinderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinderReference) DeepCopyInto(out *PermissionBinderReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderReference.
+func (in *PermissionBinderReference) DeepCopy() *PermissionBinderReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinderReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinderReport) DeepCopyInto(out *PermissionBinderReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderReport.
+func (in *PermissionBinderReport) DeepCopy() *PermissionBinderReport {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinderReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PermissionBinderReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinderReportList) DeepCopyInto(out *PermissionBinderReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PermissionBinderReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderReportList.
+func (in *PermissionBinderReportList) DeepCopy() *PermissionBinderReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinderReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PermissionBinderReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinderReportSpec) DeepCopyInto(out *PermissionBinderReportSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderReportSpec.
+func (in *PermissionBinderReportSpec) DeepCopy() *PermissionBinderReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinderReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinderReportStatus) DeepCopyInto(out *PermissionBinderReportStatus) {
+	*out = *in
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountTokens != nil {
+		in, out := &in.ServiceAccountTokens, &out.ServiceAccountTokens
+		*out = make([]ServiceAccountTokenStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LdapGroups != nil {
+		in, out := &in.LdapGroups, &out.LdapGroups
+		*out = make([]LdapGroupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderReportStatus.
+func (in *PermissionBinderReportStatus) DeepCopy() *PermissionBinderReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinderReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinderSpec) DeepCopyInto(out *PermissionBinderSpec) {
+	*out = *in
+	if in.RoleMapping != nil {
+		in, out := &in.RoleMapping, &out.RoleMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeList != nil {
+		in, out := &in.ExcludeList, &out.ExcludeList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WhitelistSource != nil {
+		in, out := &in.WhitelistSource, &out.WhitelistSource
+		*out = new(WhitelistSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WhitelistSnapshots != nil {
+		in, out := &in.WhitelistSnapshots, &out.WhitelistSnapshots
+		*out = new(WhitelistSnapshotSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transfers != nil {
+		in, out := &in.Transfers, &out.Transfers
+		*out = make([]OwnershipTransferSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(DeletionProtectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LdapSecretRef != nil {
+		in, out := &in.LdapSecretRef, &out.LdapSecretRef
+		*out = new(LdapSecretReference)
+		**out = **in
+	}
+	if in.LdapTlsVerify != nil {
+		in, out := &in.LdapTlsVerify, &out.LdapTlsVerify
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LdapSchema != nil {
+		in, out := &in.LdapSchema, &out.LdapSchema
+		*out = new(LdapSchemaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LdapGroupMembers != nil {
+		in, out := &in.LdapGroupMembers, &out.LdapGroupMembers
+		*out = new(LdapGroupMembersSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LdapGroupRetirement != nil {
+		in, out := &in.LdapGroupRetirement, &out.LdapGroupRetirement
+		*out = new(LdapGroupRetirementSpec)
+		**out = **in
+	}
+	if in.LdapGroupVerification != nil {
+		in, out := &in.LdapGroupVerification, &out.LdapGroupVerification
+		*out = new(LdapGroupVerificationSpec)
+		**out = **in
+	}
+	if in.LdapRateLimit != nil {
+		in, out := &in.LdapRateLimit, &out.LdapRateLimit
+		*out = new(LdapRateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountMapping != nil {
+		in, out := &in.ServiceAccountMapping, &out.ServiceAccountMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccountOverrides != nil {
+		in, out := &in.ServiceAccountOverrides, &out.ServiceAccountOverrides
+		*out = make([]ServiceAccountOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderSpec.
+func (in *PermissionBinderSpec) DeepCopy() *PermissionBinderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionBinderStatus) DeepCopyInto(out *PermissionBinderStatus) {
+	*out = *in
+	if in.ProcessedRoleBindings != nil {
+		in, out := &in.ProcessedRoleBindings, &out.ProcessedRoleBindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProcessedServiceAccounts != nil {
+		in, out := &in.ProcessedServiceAccounts, &out.ProcessedServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reports != nil {
+		in, out := &in.Reports, &out.Reports
+		*out = new(ReportsSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = new(WhitelistEntriesSummary)
+		**out = **in
+	}
+	if in.EntryResults != nil {
+		in, out := &in.EntryResults, &out.EntryResults
+		*out = make([]WhitelistEntryResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountTokens != nil {
+		in, out := &in.ServiceAccountTokens, &out.ServiceAccountTokens
+		*out = make([]ServiceAccountTokenStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WhitelistSource != nil {
+		in, out := &in.WhitelistSource, &out.WhitelistSource
+		*out = new(WhitelistSourceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WhitelistSnapshot != nil {
+		in, out := &in.WhitelistSnapshot, &out.WhitelistSnapshot
+		*out = new(WhitelistSnapshotStatus)
+		**out = **in
+	}
+	if in.ClusterIdentity != nil {
+		in, out := &in.ClusterIdentity, &out.ClusterIdentity
+		*out = new(ClusterIdentityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transfers != nil {
+		in, out := &in.Transfers, &out.Transfers
+		*out = make([]OwnershipTransferStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IncomingTransfers != nil {
+		in, out := &in.IncomingTransfers, &out.IncomingTransfers
+		*out = make([]OwnershipTransferStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OwnerReferences != nil {
+		in, out := &in.OwnerReferences, &out.OwnerReferences
+		*out = new(OwnerReferencesStatus)
+		**out = **in
+	}
+	if in.OwnershipConflicts != nil {
+		in, out := &in.OwnershipConflicts, &out.OwnershipConflicts
+		*out = make([]OwnershipConflict, len(*in))
+		copy(*out, *in)
+	}
+	if in.WithheldChanges != nil {
+		in, out := &in.WithheldChanges, &out.WithheldChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BreakGlassRoleBindings != nil {
+		in, out := &in.BreakGlassRoleBindings, &out.BreakGlassRoleBindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockedDeletions != nil {
+		in, out := &in.BlockedDeletions, &out.BlockedDeletions
+		*out = new(BlockedDeletionsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LdapGroups != nil {
+		in, out := &in.LdapGroups, &out.LdapGroups
+		*out = make([]LdapGroupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LdapGroupVerification != nil {
+		in, out := &in.LdapGroupVerification, &out.LdapGroupVerification
+		*out = new(LdapGroupVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LdapSync != nil {
+		in, out := &in.LdapSync, &out.LdapSync
+		*out = new(LdapSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make([]NetworkPolicyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastNetworkPolicyReconciliation != nil {
+		in, out := &in.LastNetworkPolicyReconciliation, &out.LastNetworkPolicyReconciliation
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionBinderStatus.
+func (in *PermissionBinderStatus) DeepCopy() *PermissionBinderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionBinderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportsSummary) DeepCopyInto(out *ReportsSummary) {
+	*out = *in
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LdapGroups != nil {
+		in, out := &in.LdapGroups, &out.LdapGroups
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportsSummary.
+func (in *ReportsSummary) DeepCopy() *ReportsSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportsSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountConfig) DeepCopyInto(out *ServiceAccountConfig) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ServiceAccountRoleRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(ServiceAccountTokenSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountConfig.
+func (in *ServiceAccountConfig) DeepCopy() *ServiceAccountConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountOverride) DeepCopyInto(out *ServiceAccountOverride) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespacePatterns != nil {
+		in, out := &in.NamespacePatterns, &out.NamespacePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountMapping != nil {
+		in, out := &in.ServiceAccountMapping, &out.ServiceAccountMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountOverride.
+func (in *ServiceAccountOverride) DeepCopy() *ServiceAccountOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountRoleRef) DeepCopyInto(out *ServiceAccountRoleRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountRoleRef.
+func (in *ServiceAccountRoleRef) DeepCopy() *ServiceAccountRoleRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountRoleRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenSpec) DeepCopyInto(out *ServiceAccountTokenSpec) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenSpec.
+func (in *ServiceAccountTokenSpec) DeepCopy() *ServiceAccountTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenStatus) DeepCopyInto(out *ServiceAccountTokenStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenStatus.
+func (in *ServiceAccountTokenStatus) DeepCopy() *ServiceAccountTokenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhitelistEntriesSummary) DeepCopyInto(out *WhitelistEntriesSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhitelistEntriesSummary.
+func (in *WhitelistEntriesSummary) DeepCopy() *WhitelistEntriesSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(WhitelistEntriesSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhitelistEntryResult) DeepCopyInto(out *WhitelistEntryResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhitelistEntryResult.
+func (in *WhitelistEntryResult) DeepCopy() *WhitelistEntryResult {
+	if in == nil {
+		return nil
+	}
+	out := new(WhitelistEntryResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhitelistSnapshotSpec) DeepCopyInto(out *WhitelistSnapshotSpec) {
+	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhitelistSnapshotSpec.
+func (in *WhitelistSnapshotSpec) DeepCopy() *WhitelistSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WhitelistSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhitelistSnapshotStatus) DeepCopyInto(out *WhitelistSnapshotStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhitelistSnapshotStatus.
+func (in *WhitelistSnapshotStatus) DeepCopy() *WhitelistSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WhitelistSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhitelistSourceSpec) DeepCopyInto(out *WhitelistSourceSpec) {
+	*out = *in
+	if in.LdapSearch != nil {
+		in, out := &in.LdapSearch, &out.LdapSearch
+		*out = new(LdapWhitelistSearchSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhitelistSourceSpec.
+func (in *WhitelistSourceSpec) DeepCopy() *WhitelistSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WhitelistSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhitelistSourceStatus) DeepCopyInto(out *WhitelistSourceStatus) {
+	*out = *in
+	if in.LastSearchTime != nil {
+		in, out := &in.LastSearchTime, &out.LastSearchTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhitelistSourceStatus.
+func (in *WhitelistSourceStatus) DeepCopy() *WhitelistSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WhitelistSourceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -19,6 +19,7 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
 	"strings"
 
@@ -29,6 +30,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -78,6 +80,9 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var clusterName string
+	var freeze bool
+	var freezeConfigMap string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -90,6 +95,14 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&clusterName, "cluster-name", os.Getenv("CLUSTER_NAME"),
+		"Cluster name used in LDAP group descriptions, NetworkPolicy Git paths and metrics. "+
+			"Defaults to the CLUSTER_NAME env; spec.clusterName of a PermissionBinder takes precedence.")
+	flag.BoolVar(&freeze, "freeze", os.Getenv("FREEZE") == "true" || os.Getenv("FREEZE") == "1",
+		"Emergency freeze: compute but do not apply changes for any PermissionBinder. Defaults to the FREEZE env.")
+	flag.StringVar(&freezeConfigMap, "freeze-configmap", os.Getenv("FREEZE_CONFIGMAP"),
+		"ConfigMap (namespace/name) whose data \"frozen: true\" freezes the operator at runtime. "+
+			"Defaults to the FREEZE_CONFIGMAP env; empty disables the ConfigMap switch.")
 
 	// Configure JSON structured logging for production environment
 	// This ensures all logs are machine-readable and can be easily ingested by SIEM systems
@@ -171,7 +184,8 @@ func main() {
 		// (list+watch on all Secrets), which requires broader RBAC and keeps all
 		// Secrets in memory. Disabling the cache for Secrets keeps the operator
 		// least-privileged: it only needs "get" on the individual Secrets
-		// referenced via ldapSecretRef / credentialsSecretRef.
+		// referenced via ldapSecretRef / credentialsSecretRef (plus write
+		// access for managed ServiceAccount token Secrets, no list/watch).
 		Client: ctrlclient.Options{
 			Cache: &ctrlclient.CacheOptions{
 				DisableFor: []ctrlclient.Object{&corev1.Secret{}},
@@ -249,11 +263,34 @@ func main() {
 		setupLog.Info("🔍 DEBUG MODE ENABLED - Detailed reconciliation logging will be active")
 	}
 
+	clusterName = strings.TrimSpace(clusterName)
+	if clusterName != "" {
+		setupLog.Info("Operator cluster name configured", "clusterName", clusterName)
+	}
+
+	if freeze {
+		setupLog.Info("EMERGENCY FREEZE ENABLED - changes are computed and reported but not applied")
+	}
+	var freezeConfigMapKey types.NamespacedName
+	if freezeConfigMap = strings.TrimSpace(freezeConfigMap); freezeConfigMap != "" {
+		namespace, name, found := strings.Cut(freezeConfigMap, "/")
+		if !found || namespace == "" || name == "" {
+			setupLog.Error(fmt.Errorf("invalid freeze ConfigMap %q", freezeConfigMap), "--freeze-configmap must be namespace/name")
+			os.Exit(1)
+		}
+		freezeConfigMapKey = types.NamespacedName{Name: name, Namespace: namespace}
+		setupLog.Info("Freeze ConfigMap configured", "configMap", freezeConfigMapKey.String())
+	}
+
 	if err = (&controller.PermissionBinderReconciler{
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		DebugMode:           debugMode,
 		ReconcileNamespaces: reconcileNamespaces,
+		ClusterName:         clusterName,
+		Recorder:            mgr.GetEventRecorderFor("permission-binder-operator"),
+		Frozen:              freeze,
+		FreezeConfigMap:     freezeConfigMapKey,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PermissionBinder")
 		os.Exit(1)
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// status.clusterIdentity.source values, in order of precedence
+	ClusterNameSourceSpec           = "spec"
+	ClusterNameSourceOperator       = "operator"
+	ClusterNameSourceGitRepository  = "gitRepository"
+	ClusterNameSourceInfrastructure = "infrastructure"
+	ClusterNameSourceClusterInfo    = "cluster-info"
+	ClusterNameSourceDefault        = "default"
+
+	// DefaultClusterName is used when no source provides a cluster name
+	DefaultClusterName = "kubernetes-cluster"
+)
+
+// infrastructureGVK is the OpenShift cluster-scoped Infrastructure config object.
+// It is read as unstructured (uncached) so that the operator does not depend on
+// the OpenShift API types and keeps working on plain Kubernetes.
+var infrastructureGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "Infrastructure"}
+
+// ResolveClusterIdentity determines the cluster name of a PermissionBinder.
+// Configured sources (spec.clusterName, the operator flag, gitRepository.clusterName)
+// take precedence over detected ones; configured sources that disagree with the
+// winner are reported as conflicts.
+func (r *PermissionBinderReconciler) ResolveClusterIdentity(ctx context.Context, pb *permissionv1.PermissionBinder) *permissionv1.ClusterIdentityStatus {
+	configured := []struct{ source, name string }{
+		{ClusterNameSourceSpec, pb.Spec.ClusterName},
+		{ClusterNameSourceOperator, r.ClusterName},
+	}
+	if pb.Spec.NetworkPolicy != nil && pb.Spec.NetworkPolicy.GitRepository != nil {
+		configured = append(configured, struct{ source, name string }{ClusterNameSourceGitRepository, pb.Spec.NetworkPolicy.GitRepository.ClusterName})
+	}
+
+	var identity *permissionv1.ClusterIdentityStatus
+	for _, candidate := range configured {
+		name := strings.TrimSpace(candidate.name)
+		switch {
+		case name == "":
+		case identity == nil:
+			identity = &permissionv1.ClusterIdentityStatus{Name: name, Source: candidate.source}
+		case name != identity.Name:
+			identity.Conflicts = append(identity.Conflicts, candidate.source+"="+name)
+		}
+	}
+	if identity != nil {
+		if len(identity.Conflicts) > 0 {
+			log.FromContext(ctx).Info("⚠️  Cluster name sources disagree, using the one with the highest precedence",
+				"clusterName", identity.Name,
+				"source", identity.Source,
+				"conflicts", identity.Conflicts)
+		}
+		return identity
+	}
+
+	name, source := r.detectClusterName(ctx)
+	return &permissionv1.ClusterIdentityStatus{Name: name, Source: source}
+}
+
+// detectClusterName reads the cluster name from the OpenShift Infrastructure
+// object or the cluster-info ConfigMap (kube-system, then kube-public)
+func (r *PermissionBinderReconciler) detectClusterName(ctx context.Context) (string, string) {
+	infrastructure := &unstructured.Unstructured{}
+	infrastructure.SetGroupVersionKind(infrastructureGVK)
+	if err := r.Get(ctx, client.ObjectKey{Name: "cluster"}, infrastructure); err == nil {
+		if name, _, _ := unstructured.NestedString(infrastructure.Object, "status", "infrastructureName"); name != "" {
+			return name, ClusterNameSourceInfrastructure
+		}
+	}
+
+	for _, namespace := range []string{"kube-system", "kube-public"} {
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Name: "cluster-info", Namespace: namespace}, configMap); err == nil {
+			if name := configMap.Data["cluster-name"]; name != "" {
+				return name, ClusterNameSourceClusterInfo
+			}
+		}
+	}
+
+	return DefaultClusterName, ClusterNameSourceDefault
+}
+
+// GetClusterName returns the cluster name of a PermissionBinder: the identity
+// resolved for the current reconciliation, or a fresh resolution
+func (r *PermissionBinderReconciler) GetClusterName(ctx context.Context, pb *permissionv1.PermissionBinder) string {
+	if pb.Status.ClusterIdentity != nil && pb.Status.ClusterIdentity.Name != "" {
+		return pb.Status.ClusterIdentity.Name
+	}
+	return r.ResolveClusterIdentity(ctx, pb).Name
+}
+
+// recordClusterIdentityMetric exposes the cluster identity of a PermissionBinder,
+// replacing the series of a previous identity
+func recordClusterIdentityMetric(pb *permissionv1.PermissionBinder, identity *permissionv1.ClusterIdentityStatus) {
+	forgetClusterIdentityMetric(pb)
+	key := types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}.String()
+	clusterInfo.WithLabelValues(key, identity.Name, identity.Source).Set(1)
+	clusterNameConflicts.WithLabelValues(key).Set(float64(len(identity.Conflicts)))
+}
+
+// forgetClusterIdentityMetric removes the cluster identity series of a deleted PermissionBinder
+func forgetClusterIdentityMetric(pb *permissionv1.PermissionBinder) {
+	key := types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}.String()
+	clusterInfo.DeletePartialMatch(prometheus.Labels{"permissionbinder": key})
+	clusterNameConflicts.DeleteLabelValues(key)
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestResolveClusterIdentity(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(infrastructureGVK, &unstructured.Unstructured{})
+
+	infrastructure := &unstructured.Unstructured{}
+	infrastructure.SetGroupVersionKind(infrastructureGVK)
+	infrastructure.SetName("cluster")
+	_ = unstructured.SetNestedField(infrastructure.Object, "ocp-prod-x7k2p", "status", "infrastructureName")
+	clusterInfoConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-info", Namespace: "kube-public"},
+		Data:       map[string]string{"cluster-name": "from-cluster-info"},
+	}
+
+	withGitRepository := func(name string) *permissionv1.NetworkPolicySpec {
+		return &permissionv1.NetworkPolicySpec{GitRepository: &permissionv1.GitRepositorySpec{ClusterName: name}}
+	}
+
+	tests := []struct {
+		name          string
+		spec          permissionv1.PermissionBinderSpec
+		operator      string
+		objects       []client.Object
+		wantName      string
+		wantSource    string
+		wantConflicts []string
+	}{
+		{
+			name:       "spec wins over the operator flag",
+			spec:       permissionv1.PermissionBinderSpec{ClusterName: "DEV-cluster"},
+			operator:   "DEV-cluster",
+			wantName:   "DEV-cluster",
+			wantSource: ClusterNameSourceSpec,
+		},
+		{
+			name:          "disagreeing configured sources are reported",
+			spec:          permissionv1.PermissionBinderSpec{ClusterName: "DEV-cluster", NetworkPolicy: withGitRepository("dev")},
+			operator:      "dev-01",
+			wantName:      "DEV-cluster",
+			wantSource:    ClusterNameSourceSpec,
+			wantConflicts: []string{"operator=dev-01", "gitRepository=dev"},
+		},
+		{
+			name:       "gitRepository.clusterName of existing CRs",
+			spec:       permissionv1.PermissionBinderSpec{NetworkPolicy: withGitRepository("PROD-cluster")},
+			objects:    []client.Object{infrastructure},
+			wantName:   "PROD-cluster",
+			wantSource: ClusterNameSourceGitRepository,
+		},
+		{
+			name:       "OpenShift Infrastructure object",
+			objects:    []client.Object{infrastructure, clusterInfoConfigMap},
+			wantName:   "ocp-prod-x7k2p",
+			wantSource: ClusterNameSourceInfrastructure,
+		},
+		{
+			name:       "cluster-info ConfigMap",
+			objects:    []client.Object{clusterInfoConfigMap},
+			wantName:   "from-cluster-info",
+			wantSource: ClusterNameSourceClusterInfo,
+		},
+		{
+			name:       "fallback",
+			wantName:   DefaultClusterName,
+			wantSource: ClusterNameSourceDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PermissionBinderReconciler{
+				Client:      fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				ClusterName: tt.operator,
+			}
+			pb := &permissionv1.PermissionBinder{Spec: tt.spec}
+
+			identity := r.ResolveClusterIdentity(context.Background(), pb)
+			if identity.Name != tt.wantName || identity.Source != tt.wantSource {
+				t.Errorf("identity = %s (%s), want %s (%s)", identity.Name, identity.Source, tt.wantName, tt.wantSource)
+			}
+			if !reflect.DeepEqual(identity.Conflicts, tt.wantConflicts) {
+				t.Errorf("conflicts = %v, want %v", identity.Conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestGetClusterName_UsesResolvedIdentity(t *testing.T) {
+	r := &PermissionBinderReconciler{ClusterName: "from-flag"}
+	pb := &permissionv1.PermissionBinder{}
+	pb.Status.ClusterIdentity = &permissionv1.ClusterIdentityStatus{Name: "resolved", Source: ClusterNameSourceSpec}
+
+	if got := r.GetClusterName(context.Background(), pb); got != "resolved" {
+		t.Errorf("GetClusterName() = %q, want the identity resolved for the reconciliation", got)
+	}
+}
@@ -26,9 +26,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
 )
@@ -42,8 +44,7 @@ func (r *PermissionBinderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		if !ok {
 			return []string{}
 		}
-		// Index by "namespace/name" format for ConfigMap reference
-		return []string{fmt.Sprintf("%s/%s", pb.Spec.ConfigMapNamespace, pb.Spec.ConfigMapName)}
+		return configMapRefs(pb)
 	}
 
 	// Register the indexer with the cache
@@ -56,6 +57,12 @@ func (r *PermissionBinderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return fmt.Errorf("failed to set up indexer for PermissionBinder: %w", err)
 	}
 
+	// LDAP directory work runs in its own controller and work queue
+	r.ldapSyncEvents = make(chan event.GenericEvent)
+	if err := (&LdapSyncReconciler{PermissionBinderReconciler: r}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to set up LDAP controller: %w", err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&permissionv1.PermissionBinder{}, builder.WithPredicates(r.permissionBinderPredicate())).
 		Watches(
@@ -69,9 +76,25 @@ func (r *PermissionBinderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			}),
 			builder.WithPredicates(r.configMapPredicate(mgr)),
 		).
+		// Receiving PermissionBinders of spec.transfers report and adopt handovers
+		Watches(&permissionv1.PermissionBinder{}, handler.EnqueueRequestsFromMapFunc(r.mapTransferTargets)).
+		// A deleted BindingLedger is released and recreated by its PermissionBinder
+		Watches(&permissionv1.BindingLedger{}, handler.EnqueueRequestsFromMapFunc(r.mapBindingLedgerToPermissionBinder)).
+		WatchesRawSource(source.Channel(r.ldapSyncEvents, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
 
+// configMapRefs returns the ConfigMaps a PermissionBinder reads, in "namespace/name"
+// format: the whitelist ConfigMap and the companion LDAP members ConfigMap
+func configMapRefs(pb *permissionv1.PermissionBinder) []string {
+	refs := []string{fmt.Sprintf("%s/%s", pb.Spec.ConfigMapNamespace, pb.Spec.ConfigMapName)}
+	if usesCompanionLdapMembersConfigMap(pb) {
+		key := ldapMembersConfigMapKey(pb)
+		refs = append(refs, fmt.Sprintf("%s/%s", key.Namespace, key.Name))
+	}
+	return refs
+}
+
 // isConfigMapReferenced checks if a ConfigMap is referenced by any PermissionBinder
 // Uses indexer for efficient lookup instead of listing all PermissionBinders
 func (r *PermissionBinderReconciler) isConfigMapReferenced(c client.Client, obj client.Object) bool {
@@ -79,6 +102,9 @@ func (r *PermissionBinderReconciler) isConfigMapReferenced(c client.Client, obj
 	if !ok {
 		return false
 	}
+	if r.isFreezeConfigMap(configMap) {
+		return true
+	}
 
 	ctx := context.Background()
 	var permissionBinders permissionv1.PermissionBinderList
@@ -125,7 +151,9 @@ func (r *PermissionBinderReconciler) mapConfigMapToPermissionBinder(ctx context.
 			continue
 		}
 		// Check if this ConfigMap is referenced by this PermissionBinder
-		if pb.Spec.ConfigMapName == obj.Name && pb.Spec.ConfigMapNamespace == obj.Namespace {
+		// (whitelist ConfigMap or companion LDAP members ConfigMap); the freeze
+		// ConfigMap concerns every PermissionBinder
+		if r.isFreezeConfigMap(obj) || containsString(configMapRefs(&pb), fmt.Sprintf("%s/%s", obj.Namespace, obj.Name)) {
 			if r.DebugMode {
 				logger.Info("🔍 DEBUG: ConfigMap watch triggered reconciliation",
 					"configMapName", obj.Name,
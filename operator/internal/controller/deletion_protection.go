@@ -0,0 +1,396 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+	"github.com/permission-binder-operator/operator/internal/controller/reportstore"
+)
+
+const (
+	// BlockedCondition is True while deletions exceeding spec.deletionProtection wait
+	// for acknowledgement
+	BlockedCondition = "Blocked"
+
+	// AnnotationApproveDeletions on a PermissionBinder acknowledges the blocked
+	// deletions whose status.blockedDeletions.hash it is set to
+	AnnotationApproveDeletions = "permission-binder.io/approve-deletions"
+
+	// EventReasonDeletionsBlocked is the reason of the Event of a halted mass deletion
+	EventReasonDeletionsBlocked = "DeletionsBlocked"
+
+	// Thresholds of a deletionProtection without explicit values
+	defaultMaxDeletions           = 10
+	defaultMaxDeletionPercent     = 25
+	defaultMinDeletionsForPercent = 3
+
+	// maxReportedBlockedDeletions bounds status.blockedDeletions.deletions
+	maxReportedBlockedDeletions = 100
+)
+
+// ldapGroupDeletionKey names the deletion of an operator-created LDAP group
+// (ldapGroupRetirement policy Delete) in status.blockedDeletions
+func ldapGroupDeletionKey(dn string) string {
+	return "LdapGroup " + dn
+}
+
+// networkPolicyRemovalKey names the NetworkPolicy removal Pull Request of a
+// namespace that left the whitelist in status.blockedDeletions
+func networkPolicyRemovalKey(namespace string) string {
+	return "NetworkPolicyRemoval " + namespace
+}
+
+// deletionsBlocked reports whether status.blockedDeletions waits for acknowledgement
+func deletionsBlocked(pb *permissionv1.PermissionBinder) bool {
+	blocked := pb.Status.BlockedDeletions
+	return blocked != nil && pb.Annotations[AnnotationApproveDeletions] != blocked.Hash
+}
+
+// pendingDeletion is a deletion held back by deferredDeletionsClient
+type pendingDeletion struct {
+	key  string
+	obj  client.Object
+	opts []client.DeleteOption
+}
+
+// deferredDeletionsClient holds deletions back until the reconciliation is complete,
+// so that spec.deletionProtection can halt a mass deletion before it starts. Other
+// writes go through. A deletion followed by a create of the same object is a
+// replacement (make-before-break, token Secret rotation) and is executed right away,
+// as are deletions of temporary transition RoleBindings.
+type deferredDeletionsClient struct {
+	client.Client
+
+	pending []pendingDeletion
+}
+
+func newDeferredDeletionsClient(c client.Client) *deferredDeletionsClient {
+	return &deferredDeletionsClient{Client: c}
+}
+
+func (c *deferredDeletionsClient) key(obj client.Object) string {
+	return describeObject(objectKind(c.Client, obj), client.ObjectKeyFromObject(obj))
+}
+
+// take removes and returns the pending deletion of key
+func (c *deferredDeletionsClient) take(key string) *pendingDeletion {
+	for i := range c.pending {
+		if c.pending[i].key == key {
+			deletion := c.pending[i]
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return &deletion
+		}
+	}
+	return nil
+}
+
+func (c *deferredDeletionsClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if roleBinding, ok := obj.(*rbacv1.RoleBinding); ok && roleBinding.Annotations[AnnotationTransitionFor] != "" {
+		return c.Client.Delete(ctx, obj, opts...)
+	}
+	key := c.key(obj)
+	if c.take(key) == nil {
+		log.FromContext(ctx).V(1).Info("Deletion held back until the reconciliation is complete", "object", key)
+	}
+	c.pending = append(c.pending, pendingDeletion{key: key, obj: obj.DeepCopyObject().(client.Object), opts: opts})
+	return nil
+}
+
+func (c *deferredDeletionsClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if deletion := c.take(c.key(obj)); deletion != nil {
+		if err := c.Client.Delete(ctx, deletion.obj, deletion.opts...); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *deferredDeletionsClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	// Written again - still desired
+	c.take(c.key(obj))
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *deferredDeletionsClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.take(c.key(obj))
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// deletionsDeferred reports whether k8sClient holds deletions back; applyDeletions
+// records their Events once they are executed
+func deletionsDeferred(k8sClient client.Client) bool {
+	_, deferred := k8sClient.(*deferredDeletionsClient)
+	return deferred
+}
+
+// Pending returns the held back deletions in order, e.g. "RoleBinding payments/payments-admin"
+func (c *deferredDeletionsClient) Pending() []string {
+	keys := make([]string, 0, len(c.pending))
+	for _, deletion := range c.pending {
+		keys = append(keys, deletion.key)
+	}
+	return keys
+}
+
+// applyDeletions executes the held back deletions, unless they exceed
+// spec.deletionProtection and are not acknowledged - then nothing is deleted and the
+// blocked deletions are returned. The due LDAP group deletions and the NetworkPolicy
+// removal Pull Requests count in the same budget; the caller opens the Pull Requests
+// only when nothing is blocked. LDAP groups are deleted by the LDAP controller, or
+// here once an acknowledgement covers them.
+func (r *PermissionBinderReconciler) applyDeletions(
+	ctx context.Context,
+	pb *permissionv1.PermissionBinder,
+	deletions *deferredDeletionsClient,
+	ldapGroups []permissionv1.LdapGroupStatus,
+	networkPolicyRemovals []string,
+) (*permissionv1.BlockedDeletionsStatus, error) {
+	logger := log.FromContext(ctx)
+	planned := deletions.Pending()
+	for _, group := range ldapGroups {
+		planned = append(planned, ldapGroupDeletionKey(group.DN))
+	}
+	for _, namespace := range networkPolicyRemovals {
+		planned = append(planned, networkPolicyRemovalKey(namespace))
+	}
+	if len(planned) == 0 {
+		return nil, nil
+	}
+
+	blocked, err := r.blockedDeletions(ctx, pb, planned)
+	if err != nil {
+		return nil, err
+	}
+	if blocked != nil {
+		logger.Info("⚠️  Deletions exceed deletionProtection - halted until acknowledged",
+			"deletions", blocked.Count,
+			"percent", blocked.Percent,
+			"hash", blocked.Hash)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(pb, corev1.EventTypeWarning, EventReasonDeletionsBlocked,
+				"%d deletions (%d%% of owned resources) exceed deletionProtection; annotate %s=%s to proceed",
+				blocked.Count, blocked.Percent, AnnotationApproveDeletions, blocked.Hash)
+		}
+		return blocked, nil
+	}
+
+	for _, deletion := range deletions.pending {
+		if err := r.Delete(ctx, deletion.obj, deletion.opts...); err != nil {
+			if !errors.IsNotFound(err) {
+				// Non-fatal like the deletions themselves - retried on the next reconciliation
+				logger.Error(err, "Failed to delete", "object", deletion.key)
+			}
+			continue
+		}
+		logger.Info("Deleted", "object", deletion.key)
+		if roleBinding, ok := deletion.obj.(*rbacv1.RoleBinding); ok {
+			r.recordEvent(pb, roleBinding, corev1.EventTypeNormal, EventReasonRoleBindingDeleted,
+				"Deleted RoleBinding %s/%s", roleBinding.Namespace, roleBinding.Name)
+		}
+	}
+	deletions.pending = nil
+
+	if len(ldapGroups) > 0 && pb.Annotations[AnnotationApproveDeletions] == hashDeletions(planned) {
+		// The acknowledgement covers the LDAP groups too - the LDAP controller only
+		// checks its own deletions and would ask for another one
+		r.deleteLdapGroups(ctx, pb, ldapGroups, time.Now())
+	}
+	return nil, nil
+}
+
+// blockedDeletions checks the planned deletions against spec.deletionProtection and
+// returns them when they exceed a threshold and are not acknowledged. The percent
+// threshold only applies above minDeletionsForPercent deletions: for a small
+// PermissionBinder a single deletion already is a large share.
+func (r *PermissionBinderReconciler) blockedDeletions(ctx context.Context, pb *permissionv1.PermissionBinder, deletions []string) (*permissionv1.BlockedDeletionsStatus, error) {
+	protection := pb.Spec.DeletionProtection
+	if protection == nil {
+		return nil, nil
+	}
+	maxDeletions, maxPercent := int32(defaultMaxDeletions), int32(defaultMaxDeletionPercent)
+	minForPercent := int32(defaultMinDeletionsForPercent)
+	if protection.MaxDeletions != nil {
+		maxDeletions = *protection.MaxDeletions
+	}
+	if protection.MaxDeletionPercent != nil {
+		maxPercent = *protection.MaxDeletionPercent
+	}
+	if protection.MinDeletionsForPercent != nil {
+		minForPercent = *protection.MinDeletionsForPercent
+	}
+
+	owned, err := r.countOwnedResources(ctx, pb)
+	if err != nil {
+		return nil, err
+	}
+	percent := 100
+	if owned > 0 {
+		percent = len(deletions) * 100 / owned
+	}
+	if len(deletions) <= int(maxDeletions) && (len(deletions) <= int(minForPercent) || percent <= int(maxPercent)) {
+		return nil, nil
+	}
+
+	hash := hashDeletions(deletions)
+	if pb.Annotations[AnnotationApproveDeletions] == hash {
+		log.FromContext(ctx).Info("Deletions exceeding deletionProtection acknowledged",
+			"deletions", len(deletions),
+			"percent", percent,
+			"hash", hash)
+		return nil, nil
+	}
+
+	sorted := append([]string(nil), deletions...)
+	sort.Strings(sorted)
+	if len(sorted) > maxReportedBlockedDeletions {
+		sorted = sorted[:maxReportedBlockedDeletions]
+	}
+	return &permissionv1.BlockedDeletionsStatus{
+		Hash:      hash,
+		Count:     len(deletions),
+		Percent:   percent,
+		Deletions: sorted,
+	}, nil
+}
+
+// ldapGroupDeletionsAllowed reports whether the LDAP controller may delete the given
+// groups now: nothing else waits for acknowledgement and the deletions are within
+// spec.deletionProtection, acknowledged on their own or part of the acknowledged
+// status.blockedDeletions
+func (r *PermissionBinderReconciler) ldapGroupDeletionsAllowed(ctx context.Context, pb *permissionv1.PermissionBinder, dns []string) (bool, error) {
+	if deletionsBlocked(pb) {
+		return false, nil
+	}
+	keys := make([]string, 0, len(dns))
+	for _, dn := range dns {
+		keys = append(keys, ldapGroupDeletionKey(dn))
+	}
+	if acknowledged := pb.Status.BlockedDeletions; acknowledged != nil {
+		covered := true
+		for _, key := range keys {
+			covered = covered && containsString(acknowledged.Deletions, key)
+		}
+		if covered {
+			return true, nil
+		}
+	}
+	blocked, err := r.blockedDeletions(ctx, pb, keys)
+	return blocked == nil, err
+}
+
+// countOwnedResources returns the number of RoleBindings and ServiceAccounts owned by
+// the PermissionBinder, its tracked LDAP groups and NetworkPolicy namespaces, the
+// base of maxDeletionPercent. Only resources carrying the operator's managed-by
+// labels are listed.
+func (r *PermissionBinderReconciler) countOwnedResources(ctx context.Context, pb *permissionv1.PermissionBinder) (int, error) {
+	owned := 0
+	// Whitelist RoleBindings carry LabelManagedBy, ServiceAccounts and their
+	// RoleBindings the app.kubernetes.io/managed-by label
+	counted := make(map[types.NamespacedName]bool)
+	for _, managedLabels := range []client.MatchingLabels{
+		{LabelManagedBy: ManagedByValue},
+		{"app.kubernetes.io/managed-by": ManagedByValue},
+	} {
+		var roleBindings rbacv1.RoleBindingList
+		if err := r.List(ctx, &roleBindings, managedLabels); err != nil {
+			return 0, fmt.Errorf("failed to list RoleBindings: %w", err)
+		}
+		for _, rb := range roleBindings.Items {
+			key := types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}
+			if !counted[key] && isOwnedByPermissionBinder(rb.Annotations, pb) {
+				counted[key] = true
+				owned++
+			}
+		}
+	}
+	var serviceAccounts corev1.ServiceAccountList
+	if err := r.List(ctx, &serviceAccounts, client.MatchingLabels{"app.kubernetes.io/managed-by": ManagedByValue}); err != nil {
+		return 0, fmt.Errorf("failed to list ServiceAccounts: %w", err)
+	}
+	for _, sa := range serviceAccounts.Items {
+		if isOwnedByPermissionBinder(sa.Annotations, pb) {
+			owned++
+		}
+	}
+	reports, err := reportstore.List(ctx, r, pb)
+	if err != nil {
+		return 0, err
+	}
+	owned += len(withLegacyLdapGroups(reportstore.LdapGroups(reports), pb.Status.LdapGroups)) + len(reportstore.NetworkPolicies(reports))
+	return owned, nil
+}
+
+// hashDeletions identifies a set of deletions independent of their order
+func hashDeletions(deletions []string) string {
+	sorted := append([]string(nil), deletions...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// blockedCondition returns the Blocked condition; blocked is nil when nothing waits
+// for acknowledgement
+func blockedCondition(generation int64, blocked *permissionv1.BlockedDeletionsStatus) metav1.Condition {
+	if blocked == nil {
+		return metav1.Condition{
+			Type:               BlockedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoMassDeletion",
+			Message:            "No deletions wait for acknowledgement",
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:   BlockedCondition,
+		Status: metav1.ConditionTrue,
+		Reason: "MassDeletion",
+		Message: fmt.Sprintf("%d deletions (%d%% of owned resources) exceed deletionProtection, see status.blockedDeletions; annotate %s=%s to proceed",
+			blocked.Count, blocked.Percent, AnnotationApproveDeletions, blocked.Hash),
+		ObservedGeneration: generation,
+	}
+}
+
+// recordBlockedDeletionsMetric exposes the number of blocked deletions of a PermissionBinder
+func recordBlockedDeletionsMetric(pb *permissionv1.PermissionBinder, blocked *permissionv1.BlockedDeletionsStatus) {
+	key := types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}.String()
+	count := 0
+	if blocked != nil {
+		count = blocked.Count
+	}
+	blockedDeletionsTotal.WithLabelValues(key).Set(float64(count))
+}
+
+// forgetBlockedDeletionsMetric removes the blocked deletions series of a deleted PermissionBinder
+func forgetBlockedDeletionsMetric(pb *permissionv1.PermissionBinder) {
+	blockedDeletionsTotal.DeleteLabelValues(types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}.String())
+}
@@ -0,0 +1,318 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestReconcileDeletionProtection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	maxDeletions := int32(1)
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 1,
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin"},
+			ClusterName:        "prod",
+			DeletionProtection: &permissionv1.DeletionProtectionSpec{MaxDeletions: &maxDeletions},
+		},
+		// The "view" role was removed from the role mapping
+		Status: permissionv1.PermissionBinderStatus{LastProcessedRoleMappingHash: "previous"},
+	}
+	objects := []client.Object{
+		pb,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+			Data:       map[string]string{"whitelist.txt": "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com"},
+		},
+	}
+	for _, namespace := range []string{"payments", "orders", "billing"} {
+		objects = append(objects, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace + "-view", Namespace: namespace,
+				Labels: map[string]string{LabelManagedBy: ManagedByValue},
+				Annotations: map[string]string{
+					AnnotationPermissionBinder:          "team-a",
+					AnnotationPermissionBinderNamespace: "operators",
+					AnnotationRole:                      "view",
+				},
+			},
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: "COMPANY-K8S-" + namespace + "-view"}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+		})
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme, Recorder: recorder}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(pb)
+
+	remainingViewBindings := func() int {
+		count := 0
+		for _, namespace := range []string{"payments", "orders", "billing"} {
+			var rb rbacv1.RoleBinding
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: namespace + "-view", Namespace: namespace}, &rb); err == nil {
+				count++
+			}
+		}
+		return count
+	}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := remainingViewBindings(); n != 3 {
+		t.Fatalf("%d of 3 RoleBindings left, a blocked mass deletion must not delete any", n)
+	}
+	var roleBinding rbacv1.RoleBinding
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: "payments-admin", Namespace: "payments"}, &roleBinding); err != nil {
+		t.Errorf("creations must proceed while deletions are blocked: %v", err)
+	}
+	var got permissionv1.PermissionBinder
+	if err := k8sClient.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	blocked := got.Status.BlockedDeletions
+	if blocked == nil || blocked.Count != 3 || len(blocked.Deletions) != 3 ||
+		blocked.Deletions[0] != "RoleBinding billing/billing-view" {
+		t.Fatalf("blockedDeletions = %+v, want the 3 view RoleBindings", blocked)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, BlockedCondition) {
+		t.Errorf("conditions = %+v, want Blocked True", got.Status.Conditions)
+	}
+	if got.Status.LastProcessedRoleMappingHash != "previous" {
+		t.Errorf("lastProcessedRoleMappingHash = %q, a blocked role mapping change must stay unprocessed",
+			got.Status.LastProcessedRoleMappingHash)
+	}
+	if len(recorder.Events) == 0 {
+		t.Error("expected a DeletionsBlocked event")
+	}
+
+	// A stale acknowledgement does not unblock
+	got.Annotations = map[string]string{AnnotationApproveDeletions: "0123456789abcdef"}
+	if err := k8sClient.Update(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := remainingViewBindings(); n != 3 {
+		t.Fatalf("%d of 3 RoleBindings left after a stale acknowledgement", n)
+	}
+
+	// Acknowledging the hash executes the deletions
+	if err := k8sClient.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	got.Annotations[AnnotationApproveDeletions] = blocked.Hash
+	if err := k8sClient.Update(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := remainingViewBindings(); n != 0 {
+		t.Errorf("%d RoleBindings left after the acknowledgement", n)
+	}
+	if err := k8sClient.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.BlockedDeletions != nil || meta.IsStatusConditionTrue(got.Status.Conditions, BlockedCondition) {
+		t.Errorf("status = %+v / %+v, want the block cleared", got.Status.BlockedDeletions, got.Status.Conditions)
+	}
+}
+
+func TestBlockedDeletions_SmallBinder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := newPermissionBinder("operators", "team-a")
+	pb.Spec.DeletionProtection = &permissionv1.DeletionProtectionSpec{}
+	owner := map[string]string{
+		AnnotationPermissionBinder:          "team-a",
+		AnnotationPermissionBinderNamespace: "operators",
+	}
+	var objects []client.Object
+	for _, namespace := range []string{"payments", "orders", "billing", "shipping"} {
+		objects = append(objects, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+			Name: namespace + "-admin", Namespace: namespace,
+			Labels: map[string]string{LabelManagedBy: ManagedByValue}, Annotations: owner,
+		}})
+	}
+	// Only resources with the managed-by labels are counted
+	objects = append(objects, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name: "unlabelled", Namespace: "payments", Annotations: owner,
+	}})
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+
+	owned, err := r.countOwnedResources(ctx, pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owned != 4 {
+		t.Errorf("countOwnedResources() = %d, want the 4 labelled RoleBindings", owned)
+	}
+
+	// 1 of 4 (25%) and 2 of 4 (50%) stay below minDeletionsForPercent
+	for _, deletions := range [][]string{
+		{"RoleBinding payments/payments-admin"},
+		{"RoleBinding payments/payments-admin", "RoleBinding orders/orders-admin"},
+	} {
+		blocked, err := r.blockedDeletions(ctx, pb, deletions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if blocked != nil {
+			t.Errorf("%d deletions blocked (%d%%), a small PermissionBinder must be able to delete them", blocked.Count, blocked.Percent)
+		}
+	}
+
+	// Deleting everything is still halted by the percent threshold
+	blocked, err := r.blockedDeletions(ctx, pb, []string{
+		"RoleBinding payments/payments-admin", "RoleBinding orders/orders-admin",
+		"RoleBinding billing/billing-admin", "RoleBinding shipping/shipping-admin",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocked == nil || blocked.Percent != 100 {
+		t.Errorf("blockedDeletions = %+v, want all 4 RoleBindings blocked", blocked)
+	}
+}
+
+func TestDeferredDeletionsClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+
+	existing := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-admin", Namespace: "payments"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+	}
+	obsolete := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-view", Namespace: "payments"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing, obsolete).Build()
+	ctx := context.Background()
+	desired := existing.DeepCopy()
+	desired.RoleRef.Name = "admin"
+
+	// A make-before-break replacement is not a deletion
+	deletions := newDeferredDeletionsClient(k8sClient)
+	if err := replaceRoleBinding(ctx, deletions, existing, desired, "rolebinding"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := deletions.Delete(ctx, obsolete); err != nil {
+		t.Fatal(err)
+	}
+	if got := deletions.Pending(); len(got) != 1 || got[0] != "RoleBinding payments/payments-view" {
+		t.Errorf("Pending() = %v, want only the obsolete RoleBinding", got)
+	}
+
+	var stored rbacv1.RoleBinding
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(existing), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.RoleRef.Name != "admin" {
+		t.Errorf("RoleRef = %s, want the replacement applied", stored.RoleRef.Name)
+	}
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obsolete), &stored); err != nil {
+		t.Errorf("the obsolete RoleBinding must not be deleted yet: %v", err)
+	}
+
+	if hashDeletions([]string{"a", "b"}) != hashDeletions([]string{"b", "a"}) {
+		t.Error("hashDeletions must not depend on the order")
+	}
+}
+
+func TestLdapGroupDeletionProtection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	maxDeletions := int32(1)
+	pb := newPermissionBinder("operators", "team-a")
+	pb.Spec.DeletionProtection = &permissionv1.DeletionProtectionSpec{MaxDeletions: &maxDeletions}
+	pb.Spec.LdapGroupRetirement = &permissionv1.LdapGroupRetirementSpec{Policy: LdapRetirementPolicyDelete, GracePeriod: "0s"}
+	pb.Status.ClusterIdentity = &permissionv1.ClusterIdentityStatus{Name: "prod"}
+
+	description := ldapGroupCreatedMarker("prod") + " on 2025-01-01 00:00:00 UTC."
+	conn := newFakeLdapClient()
+	tracked := make(map[string]permissionv1.LdapGroupStatus)
+	var toRetire []string
+	for _, name := range []string{"payments", "orders", "billing"} {
+		dn := "CN=COMPANY-K8S-" + name + "-admin,OU=K8S,DC=example,DC=com"
+		conn.addEntry(dn, map[string][]string{"description": {description}})
+		tracked[strings.ToLower(dn)] = permissionv1.LdapGroupStatus{DN: dn, State: LdapGroupStateActive}
+		toRetire = append(toRetire, strings.ToLower(dn))
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// An emptied whitelist retires every group - more deletions than allowed
+	r.retireLdapGroups(ctx, conn, pb, tracked, nil, toRetire, now)
+	if len(conn.entries) != 3 {
+		t.Fatalf("%d of 3 LDAP groups left, a blocked mass deletion must not delete any", len(conn.entries))
+	}
+	for _, group := range tracked {
+		if group.State != LdapGroupStatePendingDeletion || group.RemovedAt == nil {
+			t.Errorf("group = %+v, want PendingDeletion", group)
+		}
+	}
+
+	// The main reconciliation reports the due groups in the same budget
+	groups := sortedLdapGroups(tracked)
+	due := dueLdapGroupDeletions(pb, groups, now)
+	blocked, err := r.applyDeletions(ctx, pb, newDeferredDeletionsClient(k8sClient), due, []string{"payments"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocked == nil || blocked.Count != 4 ||
+		!containsString(blocked.Deletions, "LdapGroup CN=COMPANY-K8S-orders-admin,OU=K8S,DC=example,DC=com") ||
+		!containsString(blocked.Deletions, "NetworkPolicyRemoval payments") {
+		t.Fatalf("blockedDeletions = %+v, want the 3 LDAP groups and the NetworkPolicy removal", blocked)
+	}
+	pb.Status.BlockedDeletions = blocked
+	if got := nextLdapGroupDeletion(pb, groups, now); got != 0 {
+		t.Errorf("nextLdapGroupDeletion() = %v, blocked deletions must wait for the acknowledgement", got)
+	}
+
+	// Acknowledging the hash lets the LDAP controller delete the groups
+	pb.Annotations = map[string]string{AnnotationApproveDeletions: blocked.Hash}
+	r.retireLdapGroups(ctx, conn, pb, tracked, nil, toRetire, now)
+	if len(conn.entries) != 0 || len(tracked) != 0 {
+		t.Errorf("%d LDAP groups left (%d tracked) after the acknowledgement", len(conn.entries), len(tracked))
+	}
+}
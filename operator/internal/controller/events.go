@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+// Reasons of the Events of material actions. Ownership refusals, blocked deletions
+// and whitelist fallbacks have their own reasons next to the code emitting them;
+// the NetworkPolicy PR reasons are defined in the networkpolicy package.
+const (
+	EventReasonNamespaceCreated    = "NamespaceCreated"
+	EventReasonNamespaceAdopted    = "NamespaceAdopted"
+	EventReasonRoleBindingCreated  = "RoleBindingCreated"
+	EventReasonRoleBindingUpdated  = "RoleBindingUpdated"
+	EventReasonRoleBindingDeleted  = "RoleBindingDeleted"
+	EventReasonRoleBindingOrphaned = "RoleBindingOrphaned"
+	EventReasonClusterRoleMissing  = "ClusterRoleMissing"
+	EventReasonLdapGroupCreated    = "LdapGroupCreated"
+)
+
+// EventRecorder returns the recorder of the reconciler, nil when Events are not
+// recorded. It makes the reconciler a networkpolicy.ReconcilerInterface.
+func (r *PermissionBinderReconciler) EventRecorder() record.EventRecorder {
+	return r.Recorder
+}
+
+// recordEvent emits the same Event on the PermissionBinder and on the object the
+// action concerns, if any (LDAP groups are no cluster objects)
+func (r *PermissionBinderReconciler) recordEvent(permissionBinder *permissionv1.PermissionBinder, obj client.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(permissionBinder, eventType, reason, messageFmt, args...)
+	if obj != nil {
+		r.Recorder.Eventf(obj, eventType, reason, messageFmt, args...)
+	}
+}
+
+// roleBindingEvents records the Events of the ServiceAccount RoleBindings of the
+// PermissionBinder
+func (r *PermissionBinderReconciler) roleBindingEvents(permissionBinder *permissionv1.PermissionBinder) RoleBindingEventFunc {
+	return func(roleBinding *rbacv1.RoleBinding, reason, messageFmt string, args ...interface{}) {
+		r.recordEvent(permissionBinder, roleBinding, corev1.EventTypeNormal, reason, messageFmt, args...)
+	}
+}
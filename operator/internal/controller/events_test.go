@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestReconcileEvents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 1,
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin", "view": "view"},
+			ClusterName:        "prod",
+		},
+	}
+	whitelist := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data: map[string]string{"whitelist.txt": "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com\n" +
+			"CN=COMPANY-K8S-orphaned-view,OU=K8S,DC=example,DC=com"},
+	}
+	orphaned := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "orphaned",
+		Annotations: map[string]string{AnnotationOrphanedAt: "2025-01-01T00:00:00Z", AnnotationOrphanedBy: "permissionbinder-deletion"},
+	}}
+	view := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, whitelist, orphaned, view).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme, Recorder: recorder}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(pb)
+
+	reconcile := func() []string {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var events []string
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		return events
+	}
+	// Every Event is emitted on the PermissionBinder and on the affected object
+	expect := func(events []string, prefix string, want int) {
+		t.Helper()
+		got := 0
+		for _, event := range events {
+			if strings.HasPrefix(event, prefix) {
+				got++
+			}
+		}
+		if got != want {
+			t.Errorf("%d %q Events, want %d in %v", got, prefix, want, events)
+		}
+	}
+
+	events := reconcile()
+	expect(events, "Normal NamespaceCreated Created namespace payments", 2)
+	expect(events, "Normal NamespaceAdopted Namespace orphaned adopted", 2)
+	expect(events, "Normal RoleBindingCreated Created RoleBinding payments/payments-admin", 2)
+	expect(events, "Normal RoleBindingCreated Created RoleBinding orphaned/orphaned-view", 2)
+	expect(events, "Warning ClusterRoleMissing RoleBinding payments/payments-admin references ClusterRole admin", 2)
+	expect(events, "Warning ClusterRoleMissing RoleBinding orphaned/", 0)
+
+	// Unchanged resources are not reported again
+	var current permissionv1.PermissionBinder
+	if err := k8sClient.Get(ctx, key, &current); err != nil {
+		t.Fatal(err)
+	}
+	current.Spec.RoleMapping["admin"] = "edit"
+	current.Generation++
+	if err := k8sClient.Update(ctx, &current); err != nil {
+		t.Fatal(err)
+	}
+	events = reconcile()
+	expect(events, "Normal RoleBindingUpdated Updated RoleBinding payments/payments-admin granting ClusterRole edit", 2)
+	expect(events, "Normal RoleBindingUpdated Updated RoleBinding orphaned/", 0)
+	expect(events, "Normal NamespaceCreated", 0)
+	expect(events, "Normal NamespaceAdopted", 0)
+
+	// Removing a role from the mapping deletes its RoleBindings
+	if err := k8sClient.Get(ctx, key, &current); err != nil {
+		t.Fatal(err)
+	}
+	delete(current.Spec.RoleMapping, "admin")
+	current.Generation++
+	if err := k8sClient.Update(ctx, &current); err != nil {
+		t.Fatal(err)
+	}
+	events = reconcile()
+	expect(events, "Normal RoleBindingDeleted Deleted RoleBinding payments/payments-admin", 2)
+	expect(events, "Normal RoleBindingDeleted Deleted RoleBinding orphaned/", 0)
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// FrozenCondition is True while reconciliation computes but does not apply changes
+	// (operator-level freeze or spec.paused)
+	FrozenCondition = "Frozen"
+
+	// BreakGlassCondition is True while RoleBindings of the PermissionBinder carry
+	// an active break-glass annotation
+	BreakGlassCondition = "BreakGlass"
+
+	// AnnotationBreakGlass on a RoleBinding makes the operator leave it alone - no
+	// drift correction, no replacement, no deletion. The value is "true" or an
+	// RFC3339 expiry time after which the RoleBinding is reconciled again.
+	AnnotationBreakGlass = "permission-binder.io/break-glass"
+
+	// Data keys of the freeze ConfigMap (--freeze-configmap)
+	FreezeConfigMapFrozenKey = "frozen"
+	FreezeConfigMapReasonKey = "reason"
+
+	// Frozen condition reasons
+	FrozenReasonOperator = "OperatorFrozen"
+	FrozenReasonPaused   = "Paused"
+
+	// frozenRequeueInterval re-computes the withheld changes of a frozen PermissionBinder
+	frozenRequeueInterval = 5 * time.Minute
+
+	// breakGlassRecheckInterval notices removed break-glass annotations (RoleBindings
+	// are not watched)
+	breakGlassRecheckInterval = 5 * time.Minute
+
+	// maxReportedWithheldChanges bounds status.withheldChanges
+	maxReportedWithheldChanges = 100
+
+	// maxReportedBreakGlassRoleBindings bounds status.breakGlassRoleBindings
+	maxReportedBreakGlassRoleBindings = 100
+)
+
+// freezeState explains why a PermissionBinder does not apply changes
+type freezeState struct {
+	// Reason is the Frozen condition reason (FrozenReasonOperator | FrozenReasonPaused)
+	Reason string
+	// Message says which switch froze the PermissionBinder
+	Message string
+}
+
+// freezeFor returns why the PermissionBinder must not apply changes, or nil. The
+// operator-level switches (--freeze flag, freeze ConfigMap) take precedence over
+// spec.paused. An unreadable freeze ConfigMap is an error: the switch must never
+// be ignored by accident.
+func (r *PermissionBinderReconciler) freezeFor(ctx context.Context, pb *permissionv1.PermissionBinder) (*freezeState, error) {
+	if r.Frozen {
+		return &freezeState{Reason: FrozenReasonOperator, Message: "Operator frozen by --freeze"}, nil
+	}
+	if r.FreezeConfigMap.Name != "" {
+		var configMap corev1.ConfigMap
+		if err := r.Get(ctx, r.FreezeConfigMap, &configMap); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get freeze ConfigMap %s: %w", r.FreezeConfigMap, err)
+			}
+		} else if frozen := strings.TrimSpace(configMap.Data[FreezeConfigMapFrozenKey]); frozen == "true" || frozen == "1" {
+			message := fmt.Sprintf("Operator frozen by ConfigMap %s", r.FreezeConfigMap)
+			if reason := strings.TrimSpace(configMap.Data[FreezeConfigMapReasonKey]); reason != "" {
+				message += ": " + reason
+			}
+			return &freezeState{Reason: FrozenReasonOperator, Message: message}, nil
+		}
+	}
+	if pb.Spec.Paused {
+		return &freezeState{Reason: FrozenReasonPaused, Message: "PermissionBinder paused by spec.paused"}, nil
+	}
+	return nil, nil
+}
+
+// isFreezeConfigMap reports whether obj is the operator's freeze ConfigMap
+func (r *PermissionBinderReconciler) isFreezeConfigMap(obj *corev1.ConfigMap) bool {
+	return r.FreezeConfigMap.Name != "" &&
+		r.FreezeConfigMap == types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}
+}
+
+// reconcileFrozen computes the changes the reconciliation would apply - ownership
+// transfers, role mapping cleanup and the whitelist - against a client that only
+// records them, and reports them in the status. LastProcessed* are left alone, so
+// the first reconciliation after the freeze applies everything.
+func (r *PermissionBinderReconciler) reconcileFrozen(ctx context.Context, pb *permissionv1.PermissionBinder, freeze *freezeState) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	planner := newPlannedChangesClient(r.Client)
+	frozen := *r
+	frozen.Client = planner
+	frozen.Recorder = nil
+
+	plan := pb.DeepCopy()
+	plan.Status.ClusterIdentity = r.ResolveClusterIdentity(ctx, plan)
+	frozen.processOwnershipTransfers(ctx, plan)
+	if roleMappingChanged, _ := r.hasRoleMappingChanged(plan); roleMappingChanged {
+		if err := frozen.reconcileAllManagedResources(ctx, plan); err != nil {
+			return 0, err
+		}
+	}
+
+	var configMap corev1.ConfigMap
+	var err error
+	if ldapWhitelistSearchEnabled(plan) {
+		var ldapWhitelist *corev1.ConfigMap
+		if ldapWhitelist, _, err = frozen.getLdapWhitelistConfigMap(ctx, plan, time.Now()); err == nil {
+			configMap = *ldapWhitelist
+		}
+	} else {
+		err = r.Get(ctx, types.NamespacedName{Name: plan.Spec.ConfigMapName, Namespace: plan.Spec.ConfigMapNamespace}, &configMap)
+	}
+	if err != nil {
+		// Nothing to compute from - report the changes found so far
+		logger.Info("Whitelist unavailable while frozen", "error", err.Error())
+	} else if _, err := frozen.processConfigMap(ctx, plan, &configMap); err != nil {
+		return 0, err
+	}
+
+	changes := planner.Changes()
+	logger.Info("Reconciliation frozen - changes withheld",
+		"reason", freeze.Reason,
+		"message", freeze.Message,
+		"withheldChanges", len(changes))
+	withheld := changes
+	if len(withheld) > maxReportedWithheldChanges {
+		withheld = withheld[:maxReportedWithheldChanges]
+	}
+
+	breakGlass, breakGlassRecheck, err := r.breakGlassRoleBindings(ctx, pb, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	condition := frozenCondition(pb.Generation, freeze, len(changes))
+	breakGlassCond := breakGlassCondition(pb.Generation, len(breakGlass))
+	recordFreezeMetrics(pb, true, len(changes), len(breakGlass))
+
+	if !reflect.DeepEqual(pb.Status.WithheldChanges, withheld) ||
+		!reflect.DeepEqual(pb.Status.BreakGlassRoleBindings, boundedBreakGlassRoleBindings(breakGlass)) ||
+		conditionChanged(pb.Status.Conditions, condition) ||
+		conditionChanged(pb.Status.Conditions, breakGlassCond) {
+		err := r.updateStatus(ctx, pb, func(pb *permissionv1.PermissionBinder) {
+			pb.Status.WithheldChanges = withheld
+			pb.Status.BreakGlassRoleBindings = boundedBreakGlassRoleBindings(breakGlass)
+			meta.SetStatusCondition(&pb.Status.Conditions, condition)
+			meta.SetStatusCondition(&pb.Status.Conditions, breakGlassCond)
+			// Ready is False while frozen
+			setSummaryConditions(pb)
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return minRequeueAfter(frozenRequeueInterval, breakGlassRecheck), nil
+}
+
+// frozenCondition returns the Frozen condition; freeze is nil when changes are applied
+func frozenCondition(generation int64, freeze *freezeState, withheldChanges int) metav1.Condition {
+	if freeze == nil {
+		return metav1.Condition{
+			Type:               FrozenCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NotFrozen",
+			Message:            "Changes are applied",
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               FrozenCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             freeze.Reason,
+		Message:            fmt.Sprintf("%s; %d changes withheld, see status.withheldChanges", freeze.Message, withheldChanges),
+		ObservedGeneration: generation,
+	}
+}
+
+// breakGlassActive reports whether a break-glass annotation is set and not expired.
+// Values other than "true" and an RFC3339 time are ignored.
+func breakGlassActive(annotations map[string]string, now time.Time) bool {
+	value := strings.TrimSpace(annotations[AnnotationBreakGlass])
+	if value == "true" {
+		return true
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	return err == nil && now.Before(expiry)
+}
+
+// breakGlassRoleBindings returns the sorted RoleBindings ("namespace/name") of the
+// PermissionBinder with an active break-glass annotation, and when to check them
+// again (the earliest expiry, at most breakGlassRecheckInterval; 0 without any)
+func (r *PermissionBinderReconciler) breakGlassRoleBindings(ctx context.Context, pb *permissionv1.PermissionBinder, now time.Time) ([]string, time.Duration, error) {
+	var roleBindings rbacv1.RoleBindingList
+	if err := r.List(ctx, &roleBindings); err != nil {
+		return nil, 0, fmt.Errorf("failed to list RoleBindings: %w", err)
+	}
+	var result []string
+	var recheck time.Duration
+	for _, rb := range roleBindings.Items {
+		if !isOwnedByPermissionBinder(rb.Annotations, pb) || !breakGlassActive(rb.Annotations, now) {
+			continue
+		}
+		result = append(result, rb.Namespace+"/"+rb.Name)
+		next := breakGlassRecheckInterval
+		if expiry, err := time.Parse(time.RFC3339, rb.Annotations[AnnotationBreakGlass]); err == nil && expiry.Sub(now) < next {
+			next = expiry.Sub(now)
+		}
+		recheck = minRequeueAfter(recheck, next)
+	}
+	sort.Strings(result)
+	return result, recheck, nil
+}
+
+// boundedBreakGlassRoleBindings bounds the sorted list to maxReportedBreakGlassRoleBindings
+func boundedBreakGlassRoleBindings(roleBindings []string) []string {
+	if len(roleBindings) > maxReportedBreakGlassRoleBindings {
+		return roleBindings[:maxReportedBreakGlassRoleBindings]
+	}
+	return roleBindings
+}
+
+// breakGlassCondition returns the BreakGlass condition for the number of
+// break-glass RoleBindings of the PermissionBinder
+func breakGlassCondition(generation int64, roleBindings int) metav1.Condition {
+	if roleBindings == 0 {
+		return metav1.Condition{
+			Type:               BreakGlassCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoBreakGlass",
+			Message:            "All RoleBindings are reconciled",
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               BreakGlassCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "BreakGlassActive",
+		Message:            fmt.Sprintf("%d RoleBindings are left alone (break-glass), see status.breakGlassRoleBindings", roleBindings),
+		ObservedGeneration: generation,
+	}
+}
+
+// conditionChanged reports whether setting condition would change the status or
+// message of the existing condition of its type
+func conditionChanged(conditions []metav1.Condition, condition metav1.Condition) bool {
+	existing := findCondition(conditions, condition.Type)
+	return existing == nil || existing.Status != condition.Status ||
+		existing.Reason != condition.Reason || existing.Message != condition.Message
+}
+
+// recordFreezeMetrics exposes the freeze and break-glass state of a PermissionBinder
+func recordFreezeMetrics(pb *permissionv1.PermissionBinder, frozen bool, withheldChanges, breakGlass int) {
+	key := types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}.String()
+	frozenValue := 0.0
+	if frozen {
+		frozenValue = 1
+	}
+	frozenPermissionBinders.WithLabelValues(key).Set(frozenValue)
+	withheldChangesTotal.WithLabelValues(key).Set(float64(withheldChanges))
+	breakGlassRoleBindingsTotal.WithLabelValues(key).Set(float64(breakGlass))
+}
+
+// forgetFreezeMetrics removes the freeze series of a deleted PermissionBinder
+func forgetFreezeMetrics(pb *permissionv1.PermissionBinder) {
+	key := types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}.String()
+	frozenPermissionBinders.DeleteLabelValues(key)
+	withheldChangesTotal.DeleteLabelValues(key)
+	breakGlassRoleBindingsTotal.DeleteLabelValues(key)
+}
@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestFreezeFor(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	freezeKey := types.NamespacedName{Name: "permission-binder-freeze", Namespace: "operators"}
+	freezeConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: freezeKey.Name, Namespace: freezeKey.Namespace}, Data: data}
+	}
+	paused := newPermissionBinder("operators", "team-a")
+	paused.Spec.Paused = true
+
+	tests := []struct {
+		name        string
+		frozen      bool
+		configMap   *corev1.ConfigMap
+		pb          *permissionv1.PermissionBinder
+		wantReason  string
+		wantMessage string
+	}{
+		{name: "not frozen", pb: newPermissionBinder("operators", "team-a")},
+		{name: "freeze ConfigMap missing", pb: newPermissionBinder("operators", "team-a")},
+		{name: "freeze ConfigMap not frozen", configMap: freezeConfigMap(map[string]string{"frozen": "false"}),
+			pb: newPermissionBinder("operators", "team-a")},
+		{name: "--freeze", frozen: true, pb: paused, wantReason: FrozenReasonOperator, wantMessage: "--freeze"},
+		{name: "freeze ConfigMap with reason", configMap: freezeConfigMap(map[string]string{"frozen": "true", "reason": "INC-123"}),
+			pb: paused, wantReason: FrozenReasonOperator, wantMessage: "operators/permission-binder-freeze: INC-123"},
+		{name: "spec.paused", pb: paused, wantReason: FrozenReasonPaused, wantMessage: "spec.paused"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.configMap != nil {
+				builder = builder.WithObjects(tt.configMap)
+			}
+			r := &PermissionBinderReconciler{Client: builder.Build(), Scheme: scheme, Frozen: tt.frozen, FreezeConfigMap: freezeKey}
+			freeze, err := r.freezeFor(context.Background(), tt.pb)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantReason == "" {
+				if freeze != nil {
+					t.Errorf("freezeFor() = %+v, want nil", freeze)
+				}
+				return
+			}
+			if freeze == nil || freeze.Reason != tt.wantReason || !strings.Contains(freeze.Message, tt.wantMessage) {
+				t.Errorf("freezeFor() = %+v, want reason %s mentioning %q", freeze, tt.wantReason, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestReconcilePaused(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 1,
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin"},
+			ClusterName:        "prod",
+			Paused:             true,
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pb,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+			Data:       map[string]string{"whitelist.txt": "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com"},
+		},
+	).WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(pb)
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var roleBindings rbacv1.RoleBindingList
+	if err := k8sClient.List(ctx, &roleBindings); err != nil {
+		t.Fatal(err)
+	}
+	if len(roleBindings.Items) != 0 {
+		t.Fatalf("paused reconciliation created %d RoleBindings", len(roleBindings.Items))
+	}
+	var got permissionv1.PermissionBinder
+	if err := k8sClient.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !containsString(got.Status.WithheldChanges, "create Namespace payments") ||
+		!containsString(got.Status.WithheldChanges, "create RoleBinding payments/payments-admin") {
+		t.Errorf("withheldChanges = %v, want the namespace and RoleBinding creation", got.Status.WithheldChanges)
+	}
+	condition := meta.FindStatusCondition(got.Status.Conditions, FrozenCondition)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != FrozenReasonPaused {
+		t.Errorf("Frozen condition = %+v, want True/Paused", condition)
+	}
+	if got.Status.LastProcessedConfigMapVersion != "" {
+		t.Error("a paused reconciliation must not mark the whitelist as processed")
+	}
+
+	// Unpausing applies the withheld changes
+	got.Spec.Paused = false
+	if err := k8sClient.Update(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var roleBinding rbacv1.RoleBinding
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: "payments-admin", Namespace: "payments"}, &roleBinding); err != nil {
+		t.Fatalf("RoleBinding not created after unpausing: %v", err)
+	}
+	if err := k8sClient.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.WithheldChanges != nil || meta.IsStatusConditionTrue(got.Status.Conditions, FrozenCondition) {
+		t.Errorf("status = %v / %+v, want the freeze report cleared", got.Status.WithheldChanges, got.Status.Conditions)
+	}
+}
+
+func TestBreakGlass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	now := time.Now()
+	t.Run("annotation values", func(t *testing.T) {
+		for value, want := range map[string]bool{
+			"true":                                   true,
+			now.Add(time.Hour).Format(time.RFC3339):  true,
+			now.Add(-time.Hour).Format(time.RFC3339): false,
+			"yes":                                    false,
+			"":                                       false,
+		} {
+			if got := breakGlassActive(map[string]string{AnnotationBreakGlass: value}, now); got != want {
+				t.Errorf("breakGlassActive(%q) = %v, want %v", value, got, want)
+			}
+		}
+	})
+
+	pb := newPermissionBinder("operators", "team-a")
+	drifted := func(name, breakGlass string) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "payments",
+				Labels: map[string]string{LabelManagedBy: ManagedByValue},
+				Annotations: map[string]string{
+					AnnotationPermissionBinder:          "team-a",
+					AnnotationPermissionBinderNamespace: "operators",
+					AnnotationBreakGlass:                breakGlass,
+				},
+			},
+			Subjects: []rbacv1.Subject{{Kind: "User", Name: "incident-responder"}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+		}
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		drifted("payments-admin", now.Add(time.Hour).Format(time.RFC3339)),
+		drifted("payments-view", now.Add(-time.Hour).Format(time.RFC3339)),
+	).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+
+	for _, role := range []string{"admin", "view"} {
+		if _, err := r.createRoleBinding(ctx, "payments", "payments-"+role, role, "COMPANY-K8S-payments-"+role, role, pb); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for name, wantSubject := range map[string]string{
+		"payments-admin": "incident-responder",
+		"payments-view":  "COMPANY-K8S-payments-view",
+	} {
+		var rb rbacv1.RoleBinding
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "payments"}, &rb); err != nil {
+			t.Fatal(err)
+		}
+		if rb.Subjects[0].Name != wantSubject {
+			t.Errorf("RoleBinding %s subject = %q, want %q", name, rb.Subjects[0].Name, wantSubject)
+		}
+	}
+
+	roleBindings, recheck, err := r.breakGlassRoleBindings(ctx, pb, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roleBindings) != 1 || roleBindings[0] != "payments/payments-admin" {
+		t.Errorf("breakGlassRoleBindings() = %v, want payments/payments-admin", roleBindings)
+	}
+	if recheck <= 0 || recheck > breakGlassRecheckInterval {
+		t.Errorf("recheck = %v, want at most %v", recheck, breakGlassRecheckInterval)
+	}
+	if condition := breakGlassCondition(1, len(roleBindings)); condition.Status != metav1.ConditionTrue {
+		t.Errorf("condition = %+v, want True", condition)
+	}
+}
+
+func TestPlannedChangesClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+
+	existing := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-admin", Namespace: "payments"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+	desired := existing.DeepCopy()
+	desired.RoleRef.Name = "admin"
+
+	// A make-before-break replacement plans all its steps
+	planner := newPlannedChangesClient(k8sClient)
+	if err := replaceRoleBinding(context.Background(), planner, existing, desired, "rolebinding"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"create RoleBinding payments/payments-admin-transition",
+		"delete RoleBinding payments/payments-admin",
+		"create RoleBinding payments/payments-admin",
+		"delete RoleBinding payments/payments-admin-transition",
+	}
+	if got := planner.Changes(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Changes() = %v, want %v", got, want)
+	}
+
+	var stored rbacv1.RoleBinding
+	if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(existing), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.RoleRef.Name != "edit" {
+		t.Errorf("RoleRef = %s, the planner must not apply changes", stored.RoleRef.Name)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/go-ldap/ldap/v3/gssapi"
+	krbclient "github.com/jcmturner/gokrb5/v8/client"
+	krbconfig "github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+)
+
+const (
+	// Optional LDAP Secret keys selecting and configuring the bind method
+	ldapSecretKeyBindMethod    = "bind_method"
+	ldapSecretKeyClientCert    = "tls.crt"
+	ldapSecretKeyClientKey     = "tls.key"
+	ldapSecretKeyKeytab        = "krb5.keytab"
+	ldapSecretKeyKrb5Conf      = "krb5.conf"
+	ldapSecretKeyKerberosRealm = "kerberos_realm"
+	ldapSecretKeyKerberosSPN   = "kerberos_spn"
+
+	// bind_method values
+	LdapBindMethodSimple       = "simple"
+	LdapBindMethodSASLExternal = "sasl_external"
+	LdapBindMethodGSSAPI       = "gssapi"
+)
+
+// LdapBindOptions select how the operator authenticates to the LDAP server
+type LdapBindOptions struct {
+	// Method is simple (default, domain_username/domain_password), sasl_external
+	// (client certificate from tls.crt/tls.key) or gssapi (Kerberos keytab)
+	Method string
+	// ClientCert is the client certificate presented during the TLS handshake.
+	// Required for sasl_external; with the other methods it enables mutual TLS.
+	ClientCert *tls.Certificate
+	// Keytab and Krb5Conf are the raw "krb5.keytab" and "krb5.conf" Secret values (gssapi)
+	Keytab   []byte
+	Krb5Conf string
+	// KerberosRealm overrides the realm of domain_username and the default_realm of krb5.conf
+	KerberosRealm string
+	// KerberosSPN is the service principal of the LDAP server (default "ldap/<server host>")
+	KerberosSPN string
+}
+
+// parseLdapBindOptions reads the optional bind keys of the LDAP Secret
+func parseLdapBindOptions(data map[string][]byte) (LdapBindOptions, error) {
+	options := LdapBindOptions{Method: LdapBindMethodSimple}
+
+	if value, ok := data[ldapSecretKeyBindMethod]; ok {
+		method := strings.TrimSpace(string(value))
+		switch method {
+		case LdapBindMethodSimple, LdapBindMethodSASLExternal, LdapBindMethodGSSAPI:
+			options.Method = method
+		default:
+			return options, fmt.Errorf("%s: must be %q, %q or %q, got %q", ldapSecretKeyBindMethod,
+				LdapBindMethodSimple, LdapBindMethodSASLExternal, LdapBindMethodGSSAPI, method)
+		}
+	}
+
+	certPEM, hasCert := data[ldapSecretKeyClientCert]
+	keyPEM, hasKey := data[ldapSecretKeyClientKey]
+	if hasCert != hasKey {
+		return options, fmt.Errorf("%s and %s must be set together", ldapSecretKeyClientCert, ldapSecretKeyClientKey)
+	}
+	if hasCert {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return options, fmt.Errorf("invalid client certificate in %s/%s: %w", ldapSecretKeyClientCert, ldapSecretKeyClientKey, err)
+		}
+		options.ClientCert = &cert
+	}
+	if options.Method == LdapBindMethodSASLExternal && options.ClientCert == nil {
+		return options, fmt.Errorf("%s %q requires %s and %s", ldapSecretKeyBindMethod, LdapBindMethodSASLExternal,
+			ldapSecretKeyClientCert, ldapSecretKeyClientKey)
+	}
+
+	options.KerberosRealm = strings.TrimSpace(string(data[ldapSecretKeyKerberosRealm]))
+	options.KerberosSPN = strings.TrimSpace(string(data[ldapSecretKeyKerberosSPN]))
+	if options.Method == LdapBindMethodGSSAPI {
+		options.Keytab = data[ldapSecretKeyKeytab]
+		options.Krb5Conf = string(data[ldapSecretKeyKrb5Conf])
+		if len(options.Keytab) == 0 || options.Krb5Conf == "" {
+			return options, fmt.Errorf("%s %q requires %s and %s", ldapSecretKeyBindMethod, LdapBindMethodGSSAPI,
+				ldapSecretKeyKeytab, ldapSecretKeyKrb5Conf)
+		}
+		// Parse once here so that a broken keytab or krb5.conf is reported with the Secret
+		if err := keytab.New().Unmarshal(options.Keytab); err != nil {
+			return options, fmt.Errorf("invalid %s: %w", ldapSecretKeyKeytab, err)
+		}
+		if _, err := krbconfig.NewFromString(options.Krb5Conf); err != nil {
+			return options, fmt.Errorf("invalid %s: %w", ldapSecretKeyKrb5Conf, err)
+		}
+	}
+
+	return options, nil
+}
+
+// applyClientCertificate adds the client certificate (if any) to an LDAP TLS config
+func (o LdapBindOptions) applyClientCertificate(tlsConfig *tls.Config) {
+	if o.ClientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*o.ClientCert}
+	}
+}
+
+// bindLdap authenticates an established connection with the configured bind
+// method. secure reports whether the connection is protected by TLS (LDAPS or
+// StartTLS) - SASL EXTERNAL relies on the TLS client certificate and fails without it.
+func bindLdap(conn *ldap.Conn, server string, creds *LdapCredentials, secure bool) error {
+	switch creds.Bind.Method {
+	case LdapBindMethodSASLExternal:
+		if !secure {
+			return fmt.Errorf("%s %q requires ldaps:// or %s", ldapSecretKeyBindMethod, LdapBindMethodSASLExternal, ldapSecretKeyStartTLS)
+		}
+		return conn.ExternalBind()
+	case LdapBindMethodGSSAPI:
+		return gssapiBind(conn, server, creds)
+	default:
+		return conn.Bind(creds.Username, creds.Password)
+	}
+}
+
+// gssapiBind logs in to Kerberos with the keytab of the Secret and performs a
+// SASL GSSAPI bind for the service principal of the LDAP server
+func gssapiBind(conn *ldap.Conn, server string, creds *LdapCredentials) error {
+	kt := keytab.New()
+	if err := kt.Unmarshal(creds.Bind.Keytab); err != nil {
+		return fmt.Errorf("invalid %s: %w", ldapSecretKeyKeytab, err)
+	}
+	cfg, err := krbconfig.NewFromString(creds.Bind.Krb5Conf)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", ldapSecretKeyKrb5Conf, err)
+	}
+
+	principal, realm := splitKerberosPrincipal(creds.Username)
+	if creds.Bind.KerberosRealm != "" {
+		realm = creds.Bind.KerberosRealm
+	}
+	if realm == "" {
+		realm = cfg.LibDefaults.DefaultRealm
+	}
+	if realm == "" {
+		return fmt.Errorf("no Kerberos realm: set %s, use user@REALM in domain_username or default_realm in %s",
+			ldapSecretKeyKerberosRealm, ldapSecretKeyKrb5Conf)
+	}
+
+	// Active Directory KDCs do not support FAST
+	krb := &gssapi.Client{Client: krbclient.NewWithKeytab(principal, realm, kt, cfg, krbclient.DisablePAFXFAST(true))}
+	defer krb.Close()
+	if err := krb.Login(); err != nil {
+		return fmt.Errorf("kerberos login as %s@%s failed: %w", principal, realm, err)
+	}
+
+	spn := creds.Bind.KerberosSPN
+	if spn == "" {
+		spn = "ldap/" + ldapServerHost(server)
+	}
+	return conn.GSSAPIBind(krb, spn, "")
+}
+
+// splitKerberosPrincipal splits "user@REALM" into principal and realm
+func splitKerberosPrincipal(username string) (string, string) {
+	if i := strings.LastIndex(username, "@"); i > 0 {
+		return username[:i], username[i+1:]
+	}
+	return username, ""
+}
+
+// ldapServerHost returns the host name of an LDAP server URL ("ldaps://dc1.example.com:636" -> "dc1.example.com")
+func ldapServerHost(server string) string {
+	addr := strings.TrimPrefix(strings.TrimPrefix(server, "ldaps://"), "ldap://")
+	addr = strings.TrimSuffix(addr, "/")
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
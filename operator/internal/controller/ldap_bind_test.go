@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+)
+
+// generateTestClientCertPEM returns a self-signed client certificate and its key as PEM
+func generateTestClientCertPEM(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "permission-binder-operator"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// generateTestKeytab returns a marshalled keytab with one entry for principal
+func generateTestKeytab(t *testing.T, principal, realm string) []byte {
+	t.Helper()
+
+	kt := keytab.New()
+	if err := kt.AddEntry(principal, realm, "secret", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96); err != nil {
+		t.Fatalf("Failed to add keytab entry: %v", err)
+	}
+	data, err := kt.Marshal()
+	if err != nil {
+		t.Fatalf("Failed to marshal keytab: %v", err)
+	}
+	return data
+}
+
+func TestParseLdapBindOptions(t *testing.T) {
+	certPEM, keyPEM := generateTestClientCertPEM(t)
+	keytabData := generateTestKeytab(t, "svc-permission-binder", "EXAMPLE.COM")
+	krb5Conf := "[libdefaults]\n  default_realm = EXAMPLE.COM\n"
+
+	tests := []struct {
+		name        string
+		data        map[string][]byte
+		wantMethod  string
+		wantCert    bool
+		expectError bool
+	}{
+		{name: "default is simple bind", data: map[string][]byte{}, wantMethod: LdapBindMethodSimple},
+		{name: "unknown method", data: map[string][]byte{"bind_method": []byte("ntlm")}, expectError: true},
+		{
+			name:       "simple bind with client certificate (mutual TLS)",
+			data:       map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM},
+			wantMethod: LdapBindMethodSimple,
+			wantCert:   true,
+		},
+		{
+			name:       "sasl_external with client certificate",
+			data:       map[string][]byte{"bind_method": []byte("sasl_external"), "tls.crt": certPEM, "tls.key": keyPEM},
+			wantMethod: LdapBindMethodSASLExternal,
+			wantCert:   true,
+		},
+		{name: "sasl_external without client certificate", data: map[string][]byte{"bind_method": []byte("sasl_external")}, expectError: true},
+		{name: "certificate without key", data: map[string][]byte{"tls.crt": certPEM}, expectError: true},
+		{name: "invalid certificate", data: map[string][]byte{"tls.crt": []byte("x"), "tls.key": keyPEM}, expectError: true},
+		{
+			name:       "gssapi with keytab and krb5.conf",
+			data:       map[string][]byte{"bind_method": []byte("gssapi"), "krb5.keytab": keytabData, "krb5.conf": []byte(krb5Conf)},
+			wantMethod: LdapBindMethodGSSAPI,
+		},
+		{name: "gssapi without keytab", data: map[string][]byte{"bind_method": []byte("gssapi"), "krb5.conf": []byte(krb5Conf)}, expectError: true},
+		{
+			name:        "gssapi with invalid keytab",
+			data:        map[string][]byte{"bind_method": []byte("gssapi"), "krb5.keytab": []byte("garbage"), "krb5.conf": []byte(krb5Conf)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := parseLdapBindOptions(tt.data)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if options.Method != tt.wantMethod {
+				t.Errorf("Method = %q, want %q", options.Method, tt.wantMethod)
+			}
+			if (options.ClientCert != nil) != tt.wantCert {
+				t.Errorf("ClientCert set = %v, want %v", options.ClientCert != nil, tt.wantCert)
+			}
+
+			tlsConfig := &tls.Config{}
+			options.applyClientCertificate(tlsConfig)
+			if (len(tlsConfig.Certificates) == 1) != tt.wantCert {
+				t.Errorf("TLS config certificates = %d, want client certificate %v", len(tlsConfig.Certificates), tt.wantCert)
+			}
+		})
+	}
+}
+
+func TestSplitKerberosPrincipal(t *testing.T) {
+	if principal, realm := splitKerberosPrincipal("svc-pb@EXAMPLE.COM"); principal != "svc-pb" || realm != "EXAMPLE.COM" {
+		t.Errorf("got %q, %q", principal, realm)
+	}
+	if principal, realm := splitKerberosPrincipal("svc-pb"); principal != "svc-pb" || realm != "" {
+		t.Errorf("got %q, %q", principal, realm)
+	}
+}
+
+func TestLdapServerHost(t *testing.T) {
+	tests := map[string]string{
+		"ldaps://dc1.example.com:636": "dc1.example.com",
+		"ldap://dc2.example.com":      "dc2.example.com",
+		"dc3.example.com:389":         "dc3.example.com",
+		"ldaps://dc4.example.com/":    "dc4.example.com",
+	}
+	for server, want := range tests {
+		if got := ldapServerHost(server); got != want {
+			t.Errorf("ldapServerHost(%q) = %q, want %q", server, got, want)
+		}
+	}
+}
+
+func TestLdapConnectionFingerprint_BindOptions(t *testing.T) {
+	base := &LdapCredentials{Server: "ldaps://dc1", Username: "svc", Password: "pw"}
+	gssapi := &LdapCredentials{Server: "ldaps://dc1", Username: "svc", Password: "pw",
+		Bind: LdapBindOptions{Method: LdapBindMethodGSSAPI, Keytab: []byte{1, 2}}}
+	if ldapConnectionFingerprint(base, true) == ldapConnectionFingerprint(gssapi, true) {
+		t.Error("bind method change must yield a new pooled connection")
+	}
+}
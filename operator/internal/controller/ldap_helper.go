@@ -20,7 +20,9 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 	"time"
@@ -42,12 +44,17 @@ type LdapGroupInfo struct {
 
 // LdapCredentials contains LDAP connection credentials from Secret
 type LdapCredentials struct {
+	// Server is the raw "domain_server" value (one or more comma/newline separated URLs)
 	Server   string
 	Username string
 	Password string
 	// CACert is an optional PEM-encoded CA certificate (from the "ca.crt" Secret key)
 	// used to verify the LDAPS server certificate when it is issued by a private CA
 	CACert string
+	// Options are the optional connection settings (see LdapConnectionOptions)
+	Options LdapConnectionOptions
+	// Bind selects the bind method (see LdapBindOptions)
+	Bind LdapBindOptions
 }
 
 // ParseCN extracts group name and path from LDAP CN string
@@ -97,30 +104,55 @@ func (r *PermissionBinderReconciler) GetLdapCredentials(ctx context.Context, pb
 		return nil, fmt.Errorf("domain_server not found in Secret %s/%s", secret.Namespace, secret.Name)
 	}
 
+	// Optional: SASL EXTERNAL / GSSAPI instead of simple bind
+	bind, err := parseLdapBindOptions(secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LDAP bind option in Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	// Simple bind needs username and password, GSSAPI the Kerberos principal.
+	// SASL EXTERNAL authenticates with the client certificate; a username is
+	// still used as placeholder member by the GroupOfNames schema profile.
 	username, ok := secret.Data["domain_username"]
-	if !ok {
+	if !ok && bind.Method != LdapBindMethodSASLExternal {
 		return nil, fmt.Errorf("domain_username not found in Secret %s/%s", secret.Namespace, secret.Name)
 	}
 
 	password, ok := secret.Data["domain_password"]
-	if !ok {
+	if !ok && bind.Method == LdapBindMethodSimple {
 		return nil, fmt.Errorf("domain_password not found in Secret %s/%s", secret.Namespace, secret.Name)
 	}
 
 	// Optional: CA certificate for verifying LDAPS servers on a private CA
 	caCert := string(secret.Data["ca.crt"])
 
+	// Optional: StartTLS, server selection and timeouts
+	options, err := parseLdapConnectionOptions(secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LDAP connection option in Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	options.Servers = splitLdapServers(string(server))
+	if len(options.Servers) == 0 {
+		return nil, fmt.Errorf("domain_server is empty in Secret %s/%s", secret.Namespace, secret.Name)
+	}
+
 	logger.Info("Successfully retrieved LDAP credentials",
 		"secret", secretKey.Name,
 		"namespace", secretKey.Namespace,
 		"server", string(server),
-		"hasCaCert", caCert != "")
+		"hasCaCert", caCert != "",
+		"startTls", options.StartTLS,
+		"serverSelection", options.ServerSelection,
+		"bindMethod", bind.Method,
+		"hasClientCert", bind.ClientCert != nil)
 
 	return &LdapCredentials{
 		Server:   string(server),
 		Username: string(username),
 		Password: string(password),
 		CACert:   caCert,
+		Options:  options,
+		Bind:     bind,
 	}, nil
 }
 
@@ -148,49 +180,104 @@ func buildTlsConfig(tlsVerify bool, caCertPEM string) (*tls.Config, error) {
 	return tlsConfig, nil
 }
 
-// ConnectLdap establishes connection to LDAP/AD server
+// ConnectLdap establishes connection to LDAP/AD server. The servers of
+// creds.Options are tried in order (failover) or starting at the next server
+// (round-robin) until one accepts the connection and the bind.
 func ConnectLdap(creds *LdapCredentials, tlsVerify bool) (*ldap.Conn, error) {
+	servers := creds.Options.Servers
+	if len(servers) == 0 {
+		servers = splitLdapServers(creds.Server)
+	}
+	if len(servers) == 0 {
+		ldapConnectionsTotal.WithLabelValues("error").Inc()
+		return nil, fmt.Errorf("no LDAP server configured")
+	}
+
+	var errs []error
+	for _, server := range orderLdapServers(servers, creds.Options.ServerSelection) {
+		conn, err := connectLdapServer(server, creds, tlsVerify)
+		if err == nil {
+			ldapConnectionsTotal.WithLabelValues("success").Inc()
+			return conn, nil
+		}
+		ldapConnectionsTotal.WithLabelValues("error").Inc()
+		errs = append(errs, err)
+	}
+	return nil, fmt.Errorf("all LDAP servers failed: %w", errors.Join(errs...))
+}
+
+// connectLdapServer dials and binds to a single LDAP server URL
+func connectLdapServer(server string, creds *LdapCredentials, tlsVerify bool) (*ldap.Conn, error) {
+	options := creds.Options
+	dialer := &net.Dialer{Timeout: options.connectTimeout()}
+
 	var conn *ldap.Conn
 	var err error
+	secure := false
 
 	// Check if using LDAPS (secure)
-	if strings.HasPrefix(creds.Server, "ldaps://") {
+	if strings.HasPrefix(server, "ldaps://") {
 		// LDAPS connection with configurable TLS verification and optional custom CA
-		serverAddr := strings.TrimPrefix(creds.Server, "ldaps://")
 		tlsConfig, tlsErr := buildTlsConfig(tlsVerify, creds.CACert)
 		if tlsErr != nil {
-			ldapConnectionsTotal.WithLabelValues("error").Inc()
-			return nil, fmt.Errorf("failed to build TLS config for LDAP server %s: %w", creds.Server, tlsErr)
+			return nil, fmt.Errorf("failed to build TLS config for LDAP server %s: %w", server, tlsErr)
 		}
-		conn, err = ldap.DialURL(fmt.Sprintf("ldaps://%s", serverAddr), ldap.DialWithTLSConfig(tlsConfig))
+		creds.Bind.applyClientCertificate(tlsConfig)
+		conn, err = ldap.DialURL(server, ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConfig))
+		secure = true
 	} else {
-		// Plain LDAP connection
-		serverAddr := strings.TrimPrefix(creds.Server, "ldap://")
-		if !strings.Contains(serverAddr, "://") {
-			serverAddr = creds.Server // No prefix, assume plain
+		// Plain LDAP connection (optionally upgraded with StartTLS)
+		serverAddr := strings.TrimPrefix(server, "ldap://")
+		conn, err = ldap.DialURL(fmt.Sprintf("ldap://%s", serverAddr), ldap.DialWithDialer(dialer))
+		if err == nil && options.StartTLS {
+			tlsConfig, tlsErr := buildTlsConfig(tlsVerify, creds.CACert)
+			if tlsErr != nil {
+				conn.Close()
+				return nil, fmt.Errorf("failed to build TLS config for LDAP server %s: %w", server, tlsErr)
+			}
+			// StartTLS upgrades an established connection - the server name for
+			// certificate verification is not derived from the dial address
+			host, _, splitErr := net.SplitHostPort(serverAddr)
+			if splitErr != nil {
+				host = serverAddr
+			}
+			tlsConfig.ServerName = host
+			creds.Bind.applyClientCertificate(tlsConfig)
+			if err = conn.StartTLS(tlsConfig); err != nil {
+				conn.Close()
+				return nil, fmt.Errorf("failed to StartTLS with LDAP server %s: %w", server, err)
+			}
+			secure = true
 		}
-		conn, err = ldap.DialURL(fmt.Sprintf("ldap://%s", serverAddr))
 	}
 
 	if err != nil {
-		ldapConnectionsTotal.WithLabelValues("error").Inc()
-		return nil, fmt.Errorf("failed to connect to LDAP server %s: %w", creds.Server, err)
+		return nil, fmt.Errorf("failed to connect to LDAP server %s: %w", server, err)
 	}
+	conn.SetTimeout(options.operationTimeout())
 
-	// Bind (authenticate)
-	err = conn.Bind(creds.Username, creds.Password)
+	// Bind (authenticate) with the configured bind method
+	err = bindLdap(conn, server, creds, secure)
 	if err != nil {
 		conn.Close()
-		ldapConnectionsTotal.WithLabelValues("error").Inc()
-		return nil, fmt.Errorf("failed to bind to LDAP server: %w", err)
+		return nil, fmt.Errorf("failed to bind to LDAP server %s: %w", server, err)
 	}
 
-	ldapConnectionsTotal.WithLabelValues("success").Inc()
 	return conn, nil
 }
 
-// CreateLdapGroup creates an LDAP/AD group if it doesn't exist
-func CreateLdapGroup(ctx context.Context, conn *ldap.Conn, groupInfo *LdapGroupInfo, clusterName string) error {
+// CreateLdapGroup creates an LDAP/AD group with the attributes of the schema profile
+// if it doesn't exist. It reports whether the group is operator-created (created now,
+// or existing with the creation marker of this cluster in its description) and
+// therefore subject to ldapGroupRetirement.
+func CreateLdapGroup(ctx context.Context, conn ldap.Client, groupInfo *LdapGroupInfo, clusterName string, profile *LdapSchemaProfile) (bool, error) {
+	owned, _, err := createLdapGroup(ctx, conn, groupInfo, clusterName, profile)
+	return owned, err
+}
+
+// createLdapGroup is CreateLdapGroup that also reports whether the group was
+// created by this call
+func createLdapGroup(ctx context.Context, conn ldap.Client, groupInfo *LdapGroupInfo, clusterName string, profile *LdapSchemaProfile) (owned, created bool, err error) {
 	logger := log.FromContext(ctx)
 
 	// Check if group already exists
@@ -211,18 +298,32 @@ func CreateLdapGroup(ctx context.Context, conn *ldap.Conn, groupInfo *LdapGroupI
 			"dn", groupInfo.FullDN,
 			"cluster", clusterName)
 		ldapGroupOperationsTotal.WithLabelValues("exists").Inc()
-		return nil // Group exists, nothing to do
+		return IsOperatorCreatedLdapGroup(sr.Entries[0].GetAttributeValue("description"), clusterName), false, nil
 	}
 
 	// Group doesn't exist, create it with cluster information
 	timestamp := time.Now().UTC().Format("2006-01-02 15:04:05 UTC")
-	description := fmt.Sprintf("Created by permission-binder-operator from cluster '%s' on %s. Kubernetes namespace permission group.", clusterName, timestamp)
-
-	addRequest := ldap.NewAddRequest(groupInfo.FullDN, nil)
-	addRequest.Attribute("objectClass", []string{"top", "group"})
-	addRequest.Attribute("cn", []string{groupInfo.GroupName})
-	addRequest.Attribute("sAMAccountName", []string{groupInfo.GroupName})
-	addRequest.Attribute("description", []string{description})
+	description := fmt.Sprintf("%s on %s. Kubernetes namespace permission group.", ldapGroupCreatedMarker(clusterName), timestamp)
+
+	data := LdapGroupTemplateData{
+		GroupName:         groupInfo.GroupName,
+		Description:       description,
+		ClusterName:       clusterName,
+		BindDN:            profile.BindDN,
+		PlaceholderMember: profile.PlaceholderMember,
+	}
+	if profile.AllocateGidNumber {
+		data.GidNumber, err = profile.AllocateGidNumberFor(conn, ldapDomainBaseDN(groupInfo.FullDN))
+		if err != nil {
+			ldapGroupOperationsTotal.WithLabelValues("error").Inc()
+			return false, false, fmt.Errorf("failed to allocate gidNumber for LDAP group %s: %w", groupInfo.FullDN, err)
+		}
+	}
+	addRequest, err := profile.NewGroupAddRequest(groupInfo.FullDN, data)
+	if err != nil {
+		ldapGroupOperationsTotal.WithLabelValues("error").Inc()
+		return false, false, fmt.Errorf("failed to build LDAP group %s: %w", groupInfo.FullDN, err)
+	}
 
 	err = conn.Add(addRequest)
 	if err != nil {
@@ -233,10 +334,10 @@ func CreateLdapGroup(ctx context.Context, conn *ldap.Conn, groupInfo *LdapGroupI
 				"dn", groupInfo.FullDN,
 				"cluster", clusterName)
 			ldapGroupOperationsTotal.WithLabelValues("exists").Inc()
-			return nil
+			return false, false, nil
 		}
 		ldapGroupOperationsTotal.WithLabelValues("error").Inc()
-		return fmt.Errorf("failed to create LDAP group %s: %w", groupInfo.FullDN, err)
+		return false, false, fmt.Errorf("failed to create LDAP group %s: %w", groupInfo.FullDN, err)
 	}
 
 	ldapGroupOperationsTotal.WithLabelValues("created").Inc()
@@ -245,56 +346,37 @@ func CreateLdapGroup(ctx context.Context, conn *ldap.Conn, groupInfo *LdapGroupI
 		"dn", groupInfo.FullDN,
 		"path", groupInfo.Path,
 		"cluster", clusterName,
+		"schema", profile.Name,
+		"gidNumber", data.GidNumber,
 		"description", description)
 
-	return nil
+	return true, true, nil
 }
 
-// GetClusterName attempts to detect the cluster name from Kubernetes API server
-func (r *PermissionBinderReconciler) GetClusterName(ctx context.Context) string {
-	// Try to get cluster name from kube-system ConfigMap (common pattern)
-	configMap := &corev1.ConfigMap{}
-	err := r.Get(ctx, client.ObjectKey{Name: "cluster-info", Namespace: "kube-system"}, configMap)
-	if err == nil {
-		if clusterName, ok := configMap.Data["cluster-name"]; ok && clusterName != "" {
-			return clusterName
-		}
-	}
-
-	// Fallback: try to get from kube-public namespace
-	err = r.Get(ctx, client.ObjectKey{Name: "cluster-info", Namespace: "kube-public"}, configMap)
-	if err == nil {
-		if clusterName, ok := configMap.Data["cluster-name"]; ok && clusterName != "" {
-			return clusterName
-		}
-	}
-
-	// Fallback: use hostname or default
-	// In production, cluster name should be configured via ConfigMap or env var
-	return "kubernetes-cluster"
-}
-
-// ProcessLdapGroupCreation handles LDAP group creation for all whitelist entries
-func (r *PermissionBinderReconciler) ProcessLdapGroupCreation(ctx context.Context, pb *permissionv1.PermissionBinder, whitelistEntries []string) error {
+// connectLdapForBinder returns a bound connection to the LDAP server configured by
+// the PermissionBinder's ldapSecretRef and ldapTlsVerify, and resolves its ldapSchema
+// profile. The connection comes from the shared pool and is reused across
+// reconciles; Close releases it back to the pool. Its operations are throttled by
+// the ldapRateLimit token bucket of the Secret.
+func (r *PermissionBinderReconciler) connectLdapForBinder(ctx context.Context, pb *permissionv1.PermissionBinder) (ldap.Client, *LdapSchemaProfile, error) {
 	logger := log.FromContext(ctx)
 
-	if !pb.Spec.CreateLdapGroups {
-		logger.V(1).Info("LDAP group creation disabled, skipping")
-		return nil
-	}
-
-	logger.Info("🔐 Starting LDAP group creation process",
-		"entries", len(whitelistEntries))
-
-	// Get cluster name for AD group description
-	clusterName := r.GetClusterName(ctx)
-	logger.Info("Detected cluster name", "cluster", clusterName)
-
 	// Get LDAP credentials
 	creds, err := r.GetLdapCredentials(ctx, pb)
 	if err != nil {
 		logger.Error(err, "Failed to get LDAP credentials")
-		return err
+		return nil, nil, err
+	}
+
+	// Only a simple bind authenticates as a DN
+	bindDN := ""
+	if creds.Bind.Method == LdapBindMethodSimple {
+		bindDN = creds.Username
+	}
+	profile, err := ResolveLdapSchemaProfile(pb.Spec.LdapSchema, bindDN)
+	if err != nil {
+		logger.Error(err, "Invalid ldapSchema")
+		return nil, nil, err
 	}
 
 	// Get TLS verification setting (default: true)
@@ -303,55 +385,141 @@ func (r *PermissionBinderReconciler) ProcessLdapGroupCreation(ctx context.Contex
 		tlsVerify = *pb.Spec.LdapTlsVerify
 	}
 
-	// Connect to LDAP
-	conn, err := ConnectLdap(creds, tlsVerify)
+	// Connect to LDAP (or reuse the pooled connection of this Secret)
+	poolKey := ldapSecretKey(pb)
+	conn, reused, err := defaultLdapPool.Get(poolKey, ldapConnectionFingerprint(creds, tlsVerify),
+		creds.Options.poolIdleTimeout(), time.Now(), func() (ldap.Client, error) {
+			return ConnectLdap(creds, tlsVerify)
+		})
 	if err != nil {
 		logger.Error(err, "Failed to connect to LDAP server")
-		return err
+		return nil, nil, err
+	}
+	if reused {
+		ldapConnectionsTotal.WithLabelValues("reused").Inc()
 	}
-	defer conn.Close()
 
 	logger.Info("Connected to LDAP server",
 		"server", creds.Server,
 		"tlsVerify", tlsVerify,
-		"customCa", creds.CACert != "")
+		"startTls", creds.Options.StartTLS,
+		"customCa", creds.CACert != "",
+		"pooled", reused,
+		"schema", profile.Name)
+
+	// Every operation takes a token from the Secret's bucket (ldapRateLimit)
+	limit, burst := ldapRateLimit(pb)
+	return &rateLimitedLdapConn{
+		Client:    conn,
+		ctx:       ctx,
+		limiter:   defaultLdapRateLimiters.get(poolKey, pb.Namespace+"/"+pb.Name, limit, burst, time.Now()),
+		secretKey: poolKey,
+	}, profile, nil
+}
+
+// ProcessLdapGroupCreation handles LDAP group creation for all whitelist entries.
+// It returns the DNs of the operator-created groups among them and the state of
+// every entry's group. Groups confirmed
+// to exist (ldapRateLimit.existenceCacheTtl, or while processing the same
+// whitelist version) are not looked up again.
+func (r *PermissionBinderReconciler) ProcessLdapGroupCreation(ctx context.Context, pb *permissionv1.PermissionBinder, whitelistEntries []string, version string) ([]string, []permissionv1.LdapGroupSyncStatus, error) {
+	logger := log.FromContext(ctx)
+
+	if !pb.Spec.CreateLdapGroups {
+		logger.V(1).Info("LDAP group creation disabled, skipping")
+		return nil, nil, nil
+	}
+	if pb.Spec.LdapSecretRef == nil {
+		return nil, nil, fmt.Errorf("createLdapGroups requires ldapSecretRef")
+	}
+
+	logger.Info("🔐 Starting LDAP group creation process",
+		"entries", len(whitelistEntries))
+
+	// Get cluster name for AD group description
+	clusterName := r.GetClusterName(ctx, pb)
+	logger.Info("Using cluster name", "cluster", clusterName)
+
+	secretKey := ldapSecretKey(pb)
+	cacheTTL := ldapExistenceCacheTTL(pb)
+
+	// Connect only once an entry is not answered by the group cache
+	var conn ldap.Client
+	var profile *LdapSchemaProfile
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
 
 	// Process each whitelist entry
 	successCount := 0
 	errorCount := 0
+	cachedCount := 0
+	var ownedGroups []string
+	groups := make([]permissionv1.LdapGroupSyncStatus, 0, len(whitelistEntries))
 
 	for _, entry := range whitelistEntries {
 		// Parse CN to extract group info
 		groupInfo, err := ParseCN(entry)
 		if err != nil {
 			logger.Error(err, "Failed to parse CN", "entry", entry)
+			groups = append(groups, permissionv1.LdapGroupSyncStatus{DN: entry, State: LdapGroupError, Message: err.Error()})
 			errorCount++
 			continue
 		}
 
+		if owned, ok := defaultLdapGroupCache.confirmed(secretKey, groupInfo.FullDN, clusterName, version, cacheTTL, time.Now()); ok {
+			ldapGroupOperationsTotal.WithLabelValues("cached").Inc()
+			if owned {
+				ownedGroups = append(ownedGroups, groupInfo.FullDN)
+			}
+			groups = append(groups, permissionv1.LdapGroupSyncStatus{DN: entry, State: LdapGroupPresent})
+			cachedCount++
+			successCount++
+			continue
+		}
+
+		if conn == nil {
+			conn, profile, err = r.connectLdapForBinder(ctx, pb)
+			if err != nil {
+				return ownedGroups, groups, err
+			}
+		}
+
 		// Create LDAP group (with cluster name in description)
-		err = CreateLdapGroup(ctx, conn, groupInfo, clusterName)
+		owned, created, err := createLdapGroup(ctx, conn, groupInfo, clusterName, profile)
 		if err != nil {
 			logger.Error(err, "Failed to create LDAP group",
 				"group", groupInfo.GroupName,
 				"dn", groupInfo.FullDN)
+			groups = append(groups, permissionv1.LdapGroupSyncStatus{DN: entry, State: LdapGroupError, Message: err.Error()})
 			errorCount++
 			continue
 		}
+		if created {
+			r.recordEvent(pb, nil, corev1.EventTypeNormal, EventReasonLdapGroupCreated, "Created LDAP group %s", groupInfo.FullDN)
+		}
+		defaultLdapGroupCache.confirm(secretKey, groupInfo.FullDN, clusterName, version, owned, cacheTTL, time.Now())
+		if owned {
+			ownedGroups = append(ownedGroups, groupInfo.FullDN)
+		}
+		groups = append(groups, permissionv1.LdapGroupSyncStatus{DN: entry, State: LdapGroupPresent})
 
 		successCount++
 	}
 
 	logger.Info("✅ LDAP group creation completed",
 		"created", successCount,
+		"cached", cachedCount,
 		"errors", errorCount,
 		"total", len(whitelistEntries),
 		"cluster", clusterName)
 
 	// Return error only if ALL operations failed
 	if errorCount > 0 && successCount == 0 {
-		return fmt.Errorf("all LDAP group creation operations failed (%d errors)", errorCount)
+		return ownedGroups, groups, fmt.Errorf("all LDAP group creation operations failed (%d errors)", errorCount)
 	}
 
-	return nil
+	return ownedGroups, groups, nil
 }
@@ -0,0 +1,497 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// LDAP group lifecycle states (status.ldapGroups[].state)
+	LdapGroupStateActive          = "Active"
+	LdapGroupStateRetired         = "Retired"
+	LdapGroupStatePendingDeletion = "PendingDeletion"
+
+	// ldapGroupRetirement.policy values
+	LdapRetirementPolicyNone     = "None"
+	LdapRetirementPolicyMove     = "Move"
+	LdapRetirementPolicyDescribe = "Describe"
+	LdapRetirementPolicyDelete   = "Delete"
+
+	// defaultLdapGroupGracePeriod is used when ldapGroupRetirement.gracePeriod is unset or invalid
+	defaultLdapGroupGracePeriod = 168 * time.Hour
+
+	// ldapGroupRetiredMarker starts the retirement note appended to the group description
+	ldapGroupRetiredMarker = "Retired by permission-binder-operator"
+)
+
+// ldapGroupCreatedMarker is the description prefix of groups created by the operator in a cluster
+func ldapGroupCreatedMarker(clusterName string) string {
+	return fmt.Sprintf("Created by permission-binder-operator from cluster '%s'", clusterName)
+}
+
+// IsOperatorCreatedLdapGroup reports whether a group description carries the
+// creation marker of this cluster. Groups without it are never retired.
+func IsOperatorCreatedLdapGroup(description, clusterName string) bool {
+	return strings.HasPrefix(description, ldapGroupCreatedMarker(clusterName))
+}
+
+// ldapRetirementPolicy returns the configured retirement policy (None when unset)
+func ldapRetirementPolicy(pb *permissionv1.PermissionBinder) string {
+	if pb.Spec.LdapGroupRetirement == nil || pb.Spec.LdapGroupRetirement.Policy == "" {
+		return LdapRetirementPolicyNone
+	}
+	return pb.Spec.LdapGroupRetirement.Policy
+}
+
+// ldapGroupGracePeriod returns the deletion grace period of the Delete policy
+func ldapGroupGracePeriod(pb *permissionv1.PermissionBinder) time.Duration {
+	if pb.Spec.LdapGroupRetirement == nil || pb.Spec.LdapGroupRetirement.GracePeriod == "" {
+		return defaultLdapGroupGracePeriod
+	}
+	gracePeriod, err := time.ParseDuration(pb.Spec.LdapGroupRetirement.GracePeriod)
+	if err != nil || gracePeriod < 0 {
+		return defaultLdapGroupGracePeriod
+	}
+	return gracePeriod
+}
+
+// nextLdapGroupDeletion returns the delay until the earliest pending group
+// deletion (at least one second), or 0 when no deletion is pending
+func nextLdapGroupDeletion(pb *permissionv1.PermissionBinder, groups []permissionv1.LdapGroupStatus, now time.Time) time.Duration {
+	if ldapRetirementPolicy(pb) != LdapRetirementPolicyDelete || deletionsBlocked(pb) {
+		// Blocked deletions wait for the acknowledgement, which triggers a reconciliation
+		return 0
+	}
+	gracePeriod := ldapGroupGracePeriod(pb)
+	var next time.Duration
+	for _, group := range groups {
+		if group.State != LdapGroupStatePendingDeletion || group.RemovedAt == nil {
+			continue
+		}
+		delay := group.RemovedAt.Add(gracePeriod).Sub(now)
+		if delay < time.Second {
+			delay = time.Second
+		}
+		if next == 0 || delay < next {
+			next = delay
+		}
+	}
+	return next
+}
+
+// ldapGroupDeletionDue reports whether a pending deletion of the tracked groups has
+// reached its grace period
+func ldapGroupDeletionDue(pb *permissionv1.PermissionBinder, groups []permissionv1.LdapGroupStatus, now time.Time) bool {
+	next := nextLdapGroupDeletion(pb, groups, now)
+	return next > 0 && next <= time.Second
+}
+
+// ldapGroupDeletionDueAt reports whether a group leaving the whitelist is deleted
+// now (policy Delete, grace period over)
+func ldapGroupDeletionDueAt(group permissionv1.LdapGroupStatus, gracePeriod time.Duration, now time.Time) bool {
+	if group.RemovedAt == nil {
+		return gracePeriod == 0
+	}
+	return !now.Before(group.RemovedAt.Add(gracePeriod))
+}
+
+// dueLdapGroupDeletions returns the tracked groups pending deletion whose grace
+// period is over, the LDAP share of the deletionProtection budget
+func dueLdapGroupDeletions(pb *permissionv1.PermissionBinder, groups []permissionv1.LdapGroupStatus, now time.Time) []permissionv1.LdapGroupStatus {
+	if ldapRetirementPolicy(pb) != LdapRetirementPolicyDelete {
+		return nil
+	}
+	gracePeriod := ldapGroupGracePeriod(pb)
+	var due []permissionv1.LdapGroupStatus
+	for _, group := range groups {
+		if group.State == LdapGroupStatePendingDeletion && group.RemovedAt != nil && ldapGroupDeletionDueAt(group, gracePeriod, now) {
+			due = append(due, group)
+		}
+	}
+	return due
+}
+
+// readLdapGroupDescription returns the description of a group and whether it exists
+func readLdapGroupDescription(conn ldap.Client, dn string) (string, bool, error) {
+	searchRequest := ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0, 0, false,
+		"(objectClass=*)",
+		[]string{"description"},
+		nil,
+	)
+	sr, err := conn.Search(searchRequest)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to read LDAP group %s: %w", dn, err)
+	}
+	if len(sr.Entries) == 0 {
+		return "", false, nil
+	}
+	return sr.Entries[0].GetAttributeValue("description"), true, nil
+}
+
+// setLdapGroupDescription replaces the description of a group
+func setLdapGroupDescription(conn ldap.Client, dn, description string) error {
+	modifyRequest := ldap.NewModifyRequest(dn, nil)
+	modifyRequest.Replace("description", []string{description})
+	if err := conn.Modify(modifyRequest); err != nil {
+		return fmt.Errorf("failed to update description of LDAP group %s: %w", dn, err)
+	}
+	return nil
+}
+
+// RetireLdapGroup applies the retirement policy to an operator-created group whose
+// whitelist entry was removed. It returns the new tracking status and whether the
+// group is still tracked (false once deleted, gone, or found not to be ours).
+func RetireLdapGroup(
+	ctx context.Context,
+	conn ldap.Client,
+	group permissionv1.LdapGroupStatus,
+	spec *permissionv1.LdapGroupRetirementSpec,
+	gracePeriod time.Duration,
+	clusterName string,
+	now time.Time,
+) (permissionv1.LdapGroupStatus, bool, error) {
+	return retireLdapGroup(ctx, conn, group, spec, gracePeriod, clusterName, now, true)
+}
+
+// retireLdapGroup is RetireLdapGroup; without deletionAllowed (deletionProtection) a
+// group due for deletion stays pending
+func retireLdapGroup(
+	ctx context.Context,
+	conn ldap.Client,
+	group permissionv1.LdapGroupStatus,
+	spec *permissionv1.LdapGroupRetirementSpec,
+	gracePeriod time.Duration,
+	clusterName string,
+	now time.Time,
+	deletionAllowed bool,
+) (permissionv1.LdapGroupStatus, bool, error) {
+	logger := log.FromContext(ctx)
+
+	description, found, err := readLdapGroupDescription(conn, group.DN)
+	if err != nil {
+		return group, true, err
+	}
+	if !found {
+		logger.Info("Retired LDAP group no longer exists, no longer tracking it", "dn", group.DN)
+		return group, false, nil
+	}
+	if !IsOperatorCreatedLdapGroup(description, clusterName) {
+		// Never touch groups the operator did not create
+		ldapGroupOperationsTotal.WithLabelValues("skipped_foreign").Inc()
+		logger.Info("⚠️  LDAP group was not created by this operator - leaving it untouched",
+			"dn", group.DN,
+			"cluster", clusterName)
+		return group, false, nil
+	}
+
+	if group.RemovedAt == nil {
+		removedAt := metav1.NewTime(now)
+		group.RemovedAt = &removedAt
+	}
+
+	// Mark the group (once) so administrators see why it is unused
+	if !strings.Contains(description, ldapGroupRetiredMarker) {
+		retired := fmt.Sprintf("%s %s on %s: removed from the whitelist.",
+			description,
+			ldapGroupRetiredMarker,
+			now.UTC().Format("2006-01-02 15:04:05 UTC"))
+		if err := setLdapGroupDescription(conn, group.DN, retired); err != nil {
+			ldapGroupOperationsTotal.WithLabelValues("error").Inc()
+			return group, true, err
+		}
+	}
+
+	switch spec.Policy {
+	case LdapRetirementPolicyMove:
+		if spec.RetiredOU == "" {
+			return group, true, fmt.Errorf("ldapGroupRetirement.retiredOu is required for policy Move")
+		}
+		groupInfo, err := ParseCN(group.DN)
+		if err != nil {
+			return group, true, err
+		}
+		rdn := fmt.Sprintf("CN=%s", ldap.EscapeDN(groupInfo.GroupName))
+		if err := conn.ModifyDN(ldap.NewModifyDNRequest(group.DN, rdn, true, spec.RetiredOU)); err != nil {
+			ldapGroupOperationsTotal.WithLabelValues("error").Inc()
+			return group, true, fmt.Errorf("failed to move LDAP group %s to %s: %w", group.DN, spec.RetiredOU, err)
+		}
+		group.State = LdapGroupStateRetired
+		group.RetiredDN = fmt.Sprintf("%s,%s", rdn, spec.RetiredOU)
+		ldapGroupOperationsTotal.WithLabelValues("retired").Inc()
+		logger.Info("📦 LDAP group moved to retired OU",
+			"audit", true,
+			"action", "retire-move",
+			"dn", group.DN,
+			"retiredDn", group.RetiredDN,
+			"cluster", clusterName)
+
+	case LdapRetirementPolicyDelete:
+		if now.Before(group.RemovedAt.Add(gracePeriod)) {
+			if group.State != LdapGroupStatePendingDeletion {
+				logger.Info("⏳ LDAP group scheduled for deletion",
+					"audit", true,
+					"action", "retire-schedule-delete",
+					"dn", group.DN,
+					"deleteAfter", group.RemovedAt.Add(gracePeriod).UTC().Format(time.RFC3339),
+					"cluster", clusterName)
+			}
+			group.State = LdapGroupStatePendingDeletion
+			return group, true, nil
+		}
+		if !deletionAllowed {
+			if group.State != LdapGroupStatePendingDeletion {
+				logger.Info("⏳ LDAP group deletion held back by deletionProtection",
+					"audit", true,
+					"action", "retire-hold-delete",
+					"dn", group.DN,
+					"cluster", clusterName)
+			}
+			group.State = LdapGroupStatePendingDeletion
+			return group, true, nil
+		}
+		if err := conn.Del(ldap.NewDelRequest(group.DN, nil)); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			ldapGroupOperationsTotal.WithLabelValues("error").Inc()
+			return group, true, fmt.Errorf("failed to delete LDAP group %s: %w", group.DN, err)
+		}
+		ldapGroupOperationsTotal.WithLabelValues("deleted").Inc()
+		logger.Info("🗑️  LDAP group deleted after grace period",
+			"audit", true,
+			"action", "retire-delete",
+			"dn", group.DN,
+			"cluster", clusterName)
+		return group, false, nil
+
+	default: // Describe
+		group.State = LdapGroupStateRetired
+		ldapGroupOperationsTotal.WithLabelValues("retired").Inc()
+		logger.Info("📝 LDAP group marked as retired",
+			"audit", true,
+			"action", "retire-describe",
+			"dn", group.DN,
+			"cluster", clusterName)
+	}
+
+	return group, true, nil
+}
+
+// RestoreLdapGroup removes the retirement note from a group whose whitelist entry
+// came back before it was moved or deleted
+func RestoreLdapGroup(ctx context.Context, conn ldap.Client, dn, clusterName string) error {
+	description, found, err := readLdapGroupDescription(conn, dn)
+	if err != nil || !found {
+		return err
+	}
+	idx := strings.Index(description, " "+ldapGroupRetiredMarker)
+	if idx < 0 || !IsOperatorCreatedLdapGroup(description, clusterName) {
+		return nil
+	}
+	if err := setLdapGroupDescription(conn, dn, description[:idx]); err != nil {
+		return err
+	}
+	ldapGroupOperationsTotal.WithLabelValues("restored").Inc()
+	log.FromContext(ctx).Info("♻️  LDAP group restored (whitelist entry re-added)",
+		"audit", true,
+		"action", "restore",
+		"dn", dn,
+		"cluster", clusterName)
+	return nil
+}
+
+// ProcessLdapGroupLifecycle updates the tracked operator-created LDAP groups (groups):
+// ownedGroups (created or confirmed in this reconciliation) become Active, and
+// tracked groups whose DN left the whitelist are retired according to
+// ldapGroupRetirement. whitelist maps the lowercased DNs of the whitelist entries to
+// their target namespace, which the groups take. Groups whose LDAP operation fails
+// keep their previous state.
+func (r *PermissionBinderReconciler) ProcessLdapGroupLifecycle(
+	ctx context.Context,
+	pb *permissionv1.PermissionBinder,
+	groups []permissionv1.LdapGroupStatus,
+	whitelist map[string]string,
+	ownedGroups []string,
+	now time.Time,
+) []permissionv1.LdapGroupStatus {
+	logger := log.FromContext(ctx)
+	policy := ldapRetirementPolicy(pb)
+
+	tracked := make(map[string]permissionv1.LdapGroupStatus, len(groups))
+	for _, group := range groups {
+		tracked[strings.ToLower(group.DN)] = group
+	}
+
+	var toRestore, toRetire []string
+	for _, dn := range ownedGroups {
+		key := strings.ToLower(dn)
+		if previous, ok := tracked[key]; ok && previous.State != LdapGroupStateActive && previous.RetiredDN == "" {
+			toRestore = append(toRestore, dn)
+		}
+		tracked[key] = permissionv1.LdapGroupStatus{DN: dn, State: LdapGroupStateActive}
+	}
+	for key, group := range tracked {
+		if namespace, ok := whitelist[key]; ok {
+			group.Namespace = namespace
+			tracked[key] = group
+			continue
+		}
+		switch {
+		case policy == LdapRetirementPolicyNone:
+			// Tracked only - record when the entry left the whitelist
+			if group.State == LdapGroupStateActive {
+				removedAt := metav1.NewTime(now)
+				group.State = LdapGroupStateRetired
+				group.RemovedAt = &removedAt
+				tracked[key] = group
+			}
+		case group.State == LdapGroupStateActive,
+			group.State == LdapGroupStatePendingDeletion && policy == LdapRetirementPolicyDelete:
+			toRetire = append(toRetire, key)
+		}
+	}
+	if len(toRestore) == 0 {
+		toRetire = filterDueLdapGroups(pb, tracked, toRetire, now)
+	}
+
+	if len(toRestore) > 0 || len(toRetire) > 0 {
+		conn, _, err := r.connectLdapForBinder(ctx, pb)
+		if err != nil {
+			logger.Error(err, "⚠️  LDAP group lifecycle skipped - cannot connect (non-fatal)")
+			return sortedLdapGroups(tracked)
+		}
+		defer conn.Close()
+		r.retireLdapGroups(ctx, conn, pb, tracked, toRestore, toRetire, now)
+	}
+
+	return sortedLdapGroups(tracked)
+}
+
+// retireLdapGroups restores and retires tracked groups (keyed by lowercased DN) in
+// place. Deletions count against spec.deletionProtection: while they exceed it, the
+// groups stay pending deletion.
+func (r *PermissionBinderReconciler) retireLdapGroups(
+	ctx context.Context,
+	conn ldap.Client,
+	pb *permissionv1.PermissionBinder,
+	tracked map[string]permissionv1.LdapGroupStatus,
+	toRestore, toRetire []string,
+	now time.Time,
+) {
+	logger := log.FromContext(ctx)
+	clusterName := r.GetClusterName(ctx, pb)
+	for _, dn := range toRestore {
+		if err := RestoreLdapGroup(ctx, conn, dn, clusterName); err != nil {
+			logger.Error(err, "Failed to restore LDAP group", "dn", dn)
+		}
+	}
+
+	sort.Strings(toRetire)
+	gracePeriod := ldapGroupGracePeriod(pb)
+	deletionAllowed := true
+	if ldapRetirementPolicy(pb) == LdapRetirementPolicyDelete {
+		var due []string
+		for _, key := range toRetire {
+			if ldapGroupDeletionDueAt(tracked[key], gracePeriod, now) {
+				due = append(due, tracked[key].DN)
+			}
+		}
+		if len(due) > 0 {
+			allowed, err := r.ldapGroupDeletionsAllowed(ctx, pb, due)
+			if err != nil {
+				logger.Error(err, "Failed to check LDAP group deletions against deletionProtection (will retry)")
+			}
+			deletionAllowed = allowed && err == nil
+		}
+	}
+	for _, key := range toRetire {
+		group, keep, err := retireLdapGroup(ctx, conn, tracked[key], pb.Spec.LdapGroupRetirement, gracePeriod, clusterName, now, deletionAllowed)
+		if err != nil {
+			logger.Error(err, "Failed to retire LDAP group (will retry)", "dn", tracked[key].DN)
+			continue
+		}
+		if keep {
+			tracked[key] = group
+		} else {
+			delete(tracked, key)
+		}
+	}
+}
+
+// deleteLdapGroups deletes groups pending deletion whose deletion was acknowledged
+// (status.blockedDeletions). Failures are logged; the LDAP controller retries them.
+func (r *PermissionBinderReconciler) deleteLdapGroups(ctx context.Context, pb *permissionv1.PermissionBinder, groups []permissionv1.LdapGroupStatus, now time.Time) {
+	logger := log.FromContext(ctx)
+	conn, _, err := r.connectLdapForBinder(ctx, pb)
+	if err != nil {
+		logger.Error(err, "⚠️  Acknowledged LDAP group deletions skipped - cannot connect (non-fatal)")
+		return
+	}
+	defer conn.Close()
+	clusterName := r.GetClusterName(ctx, pb)
+	gracePeriod := ldapGroupGracePeriod(pb)
+	for _, group := range groups {
+		if _, _, err := retireLdapGroup(ctx, conn, group, pb.Spec.LdapGroupRetirement, gracePeriod, clusterName, now, true); err != nil {
+			logger.Error(err, "Failed to delete LDAP group (will retry)", "dn", group.DN)
+		}
+	}
+}
+
+// filterDueLdapGroups drops groups already pending deletion whose grace period has
+// not passed yet, so no LDAP connection is opened just to wait
+func filterDueLdapGroups(pb *permissionv1.PermissionBinder, tracked map[string]permissionv1.LdapGroupStatus, keys []string, now time.Time) []string {
+	gracePeriod := ldapGroupGracePeriod(pb)
+	var due []string
+	for _, key := range keys {
+		group := tracked[key]
+		if group.State == LdapGroupStatePendingDeletion && group.RemovedAt != nil && now.Before(group.RemovedAt.Add(gracePeriod)) {
+			continue
+		}
+		due = append(due, key)
+	}
+	return due
+}
+
+// sortedLdapGroups returns the tracked groups sorted by DN (stable status)
+func sortedLdapGroups(tracked map[string]permissionv1.LdapGroupStatus) []permissionv1.LdapGroupStatus {
+	if len(tracked) == 0 {
+		return nil
+	}
+	groups := make([]permissionv1.LdapGroupStatus, 0, len(tracked))
+	for _, group := range tracked {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return strings.ToLower(groups[i].DN) < strings.ToLower(groups[j].DN)
+	})
+	return groups
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestCreateLdapGroup_Ownership(t *testing.T) {
+	const ownDN = "CN=OWN,OU=K8S,DC=example,DC=com"
+	const foreignDN = "CN=FOREIGN,OU=K8S,DC=example,DC=com"
+
+	conn := newFakeLdapClient()
+	conn.addEntry(foreignDN, map[string][]string{"description": {"Managed by the identity team"}})
+	profile, _ := ResolveLdapSchemaProfile(nil, "CN=svc,DC=example,DC=com")
+
+	owned, err := CreateLdapGroup(context.Background(), conn, &LdapGroupInfo{GroupName: "OWN", FullDN: ownDN}, "prod", profile)
+	if err != nil || !owned {
+		t.Fatalf("new group: owned=%v err=%v, want owned", owned, err)
+	}
+	owned, err = CreateLdapGroup(context.Background(), conn, &LdapGroupInfo{GroupName: "OWN", FullDN: ownDN}, "prod", profile)
+	if err != nil || !owned {
+		t.Errorf("existing operator-created group: owned=%v err=%v, want owned", owned, err)
+	}
+	owned, err = CreateLdapGroup(context.Background(), conn, &LdapGroupInfo{GroupName: "OWN", FullDN: ownDN}, "other-cluster", profile)
+	if err != nil || owned {
+		t.Errorf("group created by another cluster: owned=%v err=%v, want not owned", owned, err)
+	}
+	owned, err = CreateLdapGroup(context.Background(), conn, &LdapGroupInfo{GroupName: "FOREIGN", FullDN: foreignDN}, "prod", profile)
+	if err != nil || owned {
+		t.Errorf("foreign group: owned=%v err=%v, want not owned", owned, err)
+	}
+}
+
+func TestCreateLdapGroup_ReportsCreation(t *testing.T) {
+	const dn = "CN=NEW,OU=K8S,DC=example,DC=com"
+	conn := newFakeLdapClient()
+	profile, _ := ResolveLdapSchemaProfile(nil, "CN=svc,DC=example,DC=com")
+	groupInfo := &LdapGroupInfo{GroupName: "NEW", FullDN: dn}
+
+	if _, created, err := createLdapGroup(context.Background(), conn, groupInfo, "prod", profile); err != nil || !created {
+		t.Fatalf("new group: created=%v err=%v, want created", created, err)
+	}
+	// An existing group is not reported again (no second LdapGroupCreated Event)
+	if _, created, err := createLdapGroup(context.Background(), conn, groupInfo, "prod", profile); err != nil || created {
+		t.Errorf("existing group: created=%v err=%v, want not created", created, err)
+	}
+}
+
+func TestRetireLdapGroup(t *testing.T) {
+	const groupDN = "CN=G1,OU=K8S,DC=example,DC=com"
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ownDescription := ldapGroupCreatedMarker("prod") + " on 2025-01-01 00:00:00 UTC. Kubernetes namespace permission group."
+
+	newConn := func(description string) *fakeLdapClient {
+		conn := newFakeLdapClient()
+		conn.addEntry(groupDN, map[string][]string{"description": {description}})
+		return conn
+	}
+	active := permissionv1.LdapGroupStatus{DN: groupDN, State: LdapGroupStateActive}
+
+	t.Run("describe appends a retirement note", func(t *testing.T) {
+		conn := newConn(ownDescription)
+		spec := &permissionv1.LdapGroupRetirementSpec{Policy: LdapRetirementPolicyDescribe}
+		group, keep, err := RetireLdapGroup(context.Background(), conn, active, spec, time.Hour, "prod", now)
+		if err != nil || !keep {
+			t.Fatalf("keep=%v err=%v", keep, err)
+		}
+		if group.State != LdapGroupStateRetired || group.RemovedAt == nil {
+			t.Errorf("group = %+v, want Retired with removedAt", group)
+		}
+		description := conn.entries[strings.ToLower(groupDN)]["description"][0]
+		if !strings.HasPrefix(description, ownDescription) || !strings.Contains(description, ldapGroupRetiredMarker) {
+			t.Errorf("description = %q, want original text plus retirement note", description)
+		}
+
+		// Re-adding the entry removes the note again
+		if err := RestoreLdapGroup(context.Background(), conn, groupDN, "prod"); err != nil {
+			t.Fatalf("restore: %v", err)
+		}
+		if got := conn.entries[strings.ToLower(groupDN)]["description"][0]; got != ownDescription {
+			t.Errorf("restored description = %q, want %q", got, ownDescription)
+		}
+	})
+
+	t.Run("move relocates the group to the retired OU", func(t *testing.T) {
+		conn := newConn(ownDescription)
+		spec := &permissionv1.LdapGroupRetirementSpec{Policy: LdapRetirementPolicyMove, RetiredOU: "OU=Retired,DC=example,DC=com"}
+		group, keep, err := RetireLdapGroup(context.Background(), conn, active, spec, time.Hour, "prod", now)
+		if err != nil || !keep {
+			t.Fatalf("keep=%v err=%v", keep, err)
+		}
+		if group.RetiredDN != "CN=G1,OU=Retired,DC=example,DC=com" {
+			t.Errorf("retiredDn = %q", group.RetiredDN)
+		}
+		if _, exists := conn.entries[strings.ToLower(groupDN)]; exists {
+			t.Error("group still exists at its original DN")
+		}
+		if _, exists := conn.entries[strings.ToLower(group.RetiredDN)]; !exists {
+			t.Error("group not found in the retired OU")
+		}
+	})
+
+	t.Run("delete waits for the grace period", func(t *testing.T) {
+		conn := newConn(ownDescription)
+		spec := &permissionv1.LdapGroupRetirementSpec{Policy: LdapRetirementPolicyDelete}
+		group, keep, err := RetireLdapGroup(context.Background(), conn, active, spec, time.Hour, "prod", now)
+		if err != nil || !keep || group.State != LdapGroupStatePendingDeletion {
+			t.Fatalf("first pass: group=%+v keep=%v err=%v, want PendingDeletion", group, keep, err)
+		}
+		if _, exists := conn.entries[strings.ToLower(groupDN)]; !exists {
+			t.Fatal("group deleted before the grace period")
+		}
+
+		_, keep, err = RetireLdapGroup(context.Background(), conn, group, spec, time.Hour, "prod", now.Add(2*time.Hour))
+		if err != nil || keep {
+			t.Fatalf("after grace period: keep=%v err=%v, want deleted", keep, err)
+		}
+		if _, exists := conn.entries[strings.ToLower(groupDN)]; exists {
+			t.Error("group not deleted after the grace period")
+		}
+	})
+
+	t.Run("groups not created by the operator are never touched", func(t *testing.T) {
+		conn := newConn("Managed by the identity team")
+		spec := &permissionv1.LdapGroupRetirementSpec{Policy: LdapRetirementPolicyDelete}
+		_, keep, err := RetireLdapGroup(context.Background(), conn, active, spec, 0, "prod", now)
+		if err != nil || keep {
+			t.Fatalf("keep=%v err=%v, want untracked without error", keep, err)
+		}
+		if len(conn.modifies) != 0 {
+			t.Errorf("expected no Modify calls, got %d", len(conn.modifies))
+		}
+		if _, exists := conn.entries[strings.ToLower(groupDN)]; !exists {
+			t.Error("foreign group was deleted")
+		}
+	})
+}
+
+func TestNextLdapGroupDeletion(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	removedAt := metav1.NewTime(now.Add(-30 * time.Minute))
+	pb := &permissionv1.PermissionBinder{
+		Spec: permissionv1.PermissionBinderSpec{
+			LdapGroupRetirement: &permissionv1.LdapGroupRetirementSpec{Policy: LdapRetirementPolicyDelete, GracePeriod: "1h"},
+		},
+	}
+	groups := []permissionv1.LdapGroupStatus{
+		{DN: "CN=A,DC=example,DC=com", State: LdapGroupStateActive},
+		{DN: "CN=B,DC=example,DC=com", State: LdapGroupStatePendingDeletion, RemovedAt: &removedAt},
+	}
+
+	if got := nextLdapGroupDeletion(pb, groups, now); got != 30*time.Minute {
+		t.Errorf("nextLdapGroupDeletion() = %v, want 30m", got)
+	}
+	if got := nextLdapGroupDeletion(pb, groups, now.Add(time.Hour)); got != time.Second {
+		t.Errorf("overdue deletion = %v, want 1s", got)
+	}
+	pb.Spec.LdapGroupRetirement.Policy = LdapRetirementPolicyDescribe
+	if got := nextLdapGroupDeletion(pb, groups, now); got != 0 {
+		t.Errorf("non-Delete policy = %v, want 0", got)
+	}
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// LdapMembersKeySuffix is the ConfigMap key suffix declaring the members of
+	// a group: "<group CN>.members", one member per line
+	LdapMembersKeySuffix = ".members"
+
+	// defaultMaxLdapMemberRemovals is the removal cap when maxRemovalsPerReconcile is unset
+	defaultMaxLdapMemberRemovals = 10
+)
+
+// LdapMembershipResult summarizes membership changes of one reconciliation
+type LdapMembershipResult struct {
+	Added    int
+	Removed  int
+	Deferred int // removals held back by the per-reconcile cap
+	Errors   int
+}
+
+// ParseLdapGroupMembers extracts the declared members per group CN from
+// ConfigMap data. Keys without the ".members" suffix are ignored; empty lines
+// and "#" comments are skipped.
+func ParseLdapGroupMembers(data map[string]string) map[string][]string {
+	members := make(map[string][]string)
+	for key, value := range data {
+		if !strings.HasSuffix(key, LdapMembersKeySuffix) {
+			continue
+		}
+		groupName := strings.TrimSuffix(key, LdapMembersKeySuffix)
+		if groupName == "" {
+			continue
+		}
+		list := []string{}
+		for _, line := range strings.Split(value, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			list = append(list, line)
+		}
+		members[groupName] = list
+	}
+	return members
+}
+
+// ldapMembersConfigMapKey returns the ConfigMap declaring LDAP group members:
+// ldapGroupMembers.configMapName in configMapNamespace, or the whitelist ConfigMap
+func ldapMembersConfigMapKey(pb *permissionv1.PermissionBinder) types.NamespacedName {
+	name := pb.Spec.ConfigMapName
+	if pb.Spec.LdapGroupMembers != nil && pb.Spec.LdapGroupMembers.ConfigMapName != "" {
+		name = pb.Spec.LdapGroupMembers.ConfigMapName
+	}
+	return types.NamespacedName{Name: name, Namespace: pb.Spec.ConfigMapNamespace}
+}
+
+// usesCompanionLdapMembersConfigMap reports whether members are declared in a
+// ConfigMap other than the whitelist ConfigMap
+func usesCompanionLdapMembersConfigMap(pb *permissionv1.PermissionBinder) bool {
+	return pb.Spec.LdapGroupMembers != nil && pb.Spec.LdapGroupMembers.Enabled &&
+		pb.Spec.LdapGroupMembers.ConfigMapName != "" &&
+		pb.Spec.LdapGroupMembers.ConfigMapName != pb.Spec.ConfigMapName
+}
+
+// getLdapMembersConfigMap fetches the companion LDAP members ConfigMap. It
+// returns nil when membership management is disabled or no companion
+// ConfigMap is configured.
+func (r *PermissionBinderReconciler) getLdapMembersConfigMap(ctx context.Context, pb *permissionv1.PermissionBinder) (*corev1.ConfigMap, error) {
+	if !usesCompanionLdapMembersConfigMap(pb) {
+		return nil, nil
+	}
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, ldapMembersConfigMapKey(pb), &configMap); err != nil {
+		return nil, fmt.Errorf("failed to get LDAP members ConfigMap %s: %w", ldapMembersConfigMapKey(pb), err)
+	}
+	return &configMap, nil
+}
+
+// ldapDomainBaseDN returns the DC=... suffix of a DN (search base for
+// user name lookups and gidNumber allocation), or the whole DN when it has no DC components
+func ldapDomainBaseDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return dn
+	}
+	start := len(parsed.RDNs)
+	for start > 0 {
+		rdn := parsed.RDNs[start-1]
+		if len(rdn.Attributes) != 1 || !strings.EqualFold(rdn.Attributes[0].Type, "DC") {
+			break
+		}
+		start--
+	}
+	if start == len(parsed.RDNs) {
+		return dn
+	}
+	return (&ldap.DN{RDNs: parsed.RDNs[start:]}).String()
+}
+
+// resolveLdapMember returns the member attribute value of a declared member.
+// Values containing "=" are treated as DNs, anything else as a user name
+// (profile UserNameAttribute). DN-valued profiles look user names up below
+// baseDN; name-valued profiles (memberUid) read the user name of a DN.
+func resolveLdapMember(conn ldap.Client, profile *LdapSchemaProfile, member, baseDN string) (string, error) {
+	isDN := strings.Contains(member, "=")
+	if isDN {
+		if _, err := ldap.ParseDN(member); err != nil {
+			return "", fmt.Errorf("invalid member DN %q: %w", member, err)
+		}
+	}
+
+	switch {
+	case isDN && profile.MemberIsDN, !isDN && !profile.MemberIsDN:
+		return member, nil
+
+	case isDN:
+		searchRequest := ldap.NewSearchRequest(
+			member,
+			ldap.ScopeBaseObject,
+			ldap.NeverDerefAliases,
+			0, 0, false,
+			"(objectClass=*)",
+			[]string{profile.UserNameAttribute},
+			nil,
+		)
+		sr, err := conn.Search(searchRequest)
+		if err != nil {
+			return "", fmt.Errorf("failed to look up member %q: %w", member, err)
+		}
+		if len(sr.Entries) == 0 || sr.Entries[0].GetAttributeValue(profile.UserNameAttribute) == "" {
+			return "", fmt.Errorf("member %q has no %s attribute", member, profile.UserNameAttribute)
+		}
+		return sr.Entries[0].GetAttributeValue(profile.UserNameAttribute), nil
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2, 0, false,
+		fmt.Sprintf("(%s=%s)", profile.UserNameAttribute, ldap.EscapeFilter(member)),
+		[]string{"dn"},
+		nil,
+	)
+	sr, err := conn.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up member %q: %w", member, err)
+	}
+	if len(sr.Entries) != 1 {
+		return "", fmt.Errorf("member %q matched %d entries below %s (expected exactly 1)", member, len(sr.Entries), baseDN)
+	}
+	return sr.Entries[0].DN, nil
+}
+
+// containsLdapMember reports whether members contains member, comparing DNs or
+// user names (case-insensitive) depending on the profile
+func containsLdapMember(profile *LdapSchemaProfile, members []string, member string) bool {
+	if profile.MemberIsDN {
+		return containsLdapDN(members, member)
+	}
+	for _, candidate := range members {
+		if strings.EqualFold(candidate, member) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsLdapDN reports whether dns contains dn (case-insensitive DN comparison)
+func containsLdapDN(dns []string, dn string) bool {
+	parsed, err := ldap.ParseDN(dn)
+	for _, candidate := range dns {
+		if err != nil {
+			if strings.EqualFold(candidate, dn) {
+				return true
+			}
+			continue
+		}
+		other, otherErr := ldap.ParseDN(candidate)
+		if otherErr == nil && parsed.EqualFold(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReconcileLdapGroupMembers makes the member attribute of a group (per schema
+// profile) match the declared members using LDAP Modify operations (one per
+// member, so a single bad member does not block the others). Removals consume
+// removalBudget; removals beyond it are deferred and counted. Every change is
+// audit-logged.
+func ReconcileLdapGroupMembers(
+	ctx context.Context,
+	conn ldap.Client,
+	profile *LdapSchemaProfile,
+	groupInfo *LdapGroupInfo,
+	declared []string,
+	removalBudget *int,
+	clusterName string,
+) (LdapMembershipResult, error) {
+	logger := log.FromContext(ctx)
+	result := LdapMembershipResult{}
+
+	// Current members
+	searchRequest := ldap.NewSearchRequest(
+		groupInfo.FullDN,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0, 0, false,
+		"(objectClass=*)",
+		[]string{profile.MemberAttribute},
+		nil,
+	)
+	sr, err := conn.Search(searchRequest)
+	if err != nil {
+		return result, fmt.Errorf("failed to read members of LDAP group %s: %w", groupInfo.FullDN, err)
+	}
+	if len(sr.Entries) == 0 {
+		return result, fmt.Errorf("LDAP group %s not found", groupInfo.FullDN)
+	}
+	current := sr.Entries[0].GetAttributeValues(profile.MemberAttribute)
+
+	// Desired members (resolved to member attribute values); the placeholder
+	// member of groupOfNames groups is always kept
+	baseDN := ldapDomainBaseDN(groupInfo.FullDN)
+	desired := make([]string, 0, len(declared)+1)
+	if profile.PlaceholderMember != "" {
+		desired = append(desired, profile.PlaceholderMember)
+	}
+	resolveFailed := false
+	for _, member := range declared {
+		memberDN, err := resolveLdapMember(conn, profile, member, baseDN)
+		if err != nil {
+			logger.Error(err, "Failed to resolve declared LDAP group member",
+				"group", groupInfo.GroupName,
+				"member", member)
+			ldapGroupMembershipChangesTotal.WithLabelValues("error").Inc()
+			result.Errors++
+			resolveFailed = true
+			continue
+		}
+		if !containsLdapMember(profile, desired, memberDN) {
+			desired = append(desired, memberDN)
+		}
+	}
+
+	for _, memberDN := range desired {
+		if containsLdapMember(profile, current, memberDN) {
+			continue
+		}
+		modifyRequest := ldap.NewModifyRequest(groupInfo.FullDN, nil)
+		modifyRequest.Add(profile.MemberAttribute, []string{memberDN})
+		if err := conn.Modify(modifyRequest); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultAttributeOrValueExists) {
+			logger.Error(err, "Failed to add LDAP group member",
+				"group", groupInfo.GroupName,
+				"member", memberDN)
+			ldapGroupMembershipChangesTotal.WithLabelValues("error").Inc()
+			result.Errors++
+			continue
+		}
+		ldapGroupMembershipChangesTotal.WithLabelValues("add").Inc()
+		result.Added++
+		logger.Info("📝 LDAP group member added",
+			"audit", true,
+			"action", "add",
+			"group", groupInfo.GroupName,
+			"groupDn", groupInfo.FullDN,
+			"member", memberDN,
+			"cluster", clusterName)
+	}
+
+	// A member that could not be resolved must not look like a removal
+	// candidate - skip removals for this group until all members resolve
+	if resolveFailed {
+		logger.Info("Skipping LDAP member removals - not all declared members could be resolved",
+			"group", groupInfo.GroupName)
+		return result, nil
+	}
+
+	var toRemove []string
+	for _, memberDN := range current {
+		if !containsLdapMember(profile, desired, memberDN) {
+			toRemove = append(toRemove, memberDN)
+		}
+	}
+	sort.Strings(toRemove)
+
+	for _, memberDN := range toRemove {
+		if *removalBudget <= 0 {
+			ldapGroupMembershipChangesTotal.WithLabelValues("deferred").Inc()
+			result.Deferred++
+			logger.Info("📝 LDAP group member removal deferred (maxRemovalsPerReconcile reached)",
+				"audit", true,
+				"action", "remove-deferred",
+				"group", groupInfo.GroupName,
+				"groupDn", groupInfo.FullDN,
+				"member", memberDN,
+				"cluster", clusterName)
+			continue
+		}
+		modifyRequest := ldap.NewModifyRequest(groupInfo.FullDN, nil)
+		modifyRequest.Delete(profile.MemberAttribute, []string{memberDN})
+		if err := conn.Modify(modifyRequest); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+			logger.Error(err, "Failed to remove LDAP group member",
+				"group", groupInfo.GroupName,
+				"member", memberDN)
+			ldapGroupMembershipChangesTotal.WithLabelValues("error").Inc()
+			result.Errors++
+			continue
+		}
+		*removalBudget--
+		ldapGroupMembershipChangesTotal.WithLabelValues("remove").Inc()
+		result.Removed++
+		logger.Info("📝 LDAP group member removed",
+			"audit", true,
+			"action", "remove",
+			"group", groupInfo.GroupName,
+			"groupDn", groupInfo.FullDN,
+			"member", memberDN,
+			"cluster", clusterName)
+	}
+
+	return result, nil
+}
+
+// ProcessLdapGroupMembers reconciles the members of every whitelist group
+// that has declared members in membersData
+func (r *PermissionBinderReconciler) ProcessLdapGroupMembers(
+	ctx context.Context,
+	pb *permissionv1.PermissionBinder,
+	whitelistEntries []string,
+	membersData map[string]string,
+) (LdapMembershipResult, error) {
+	logger := log.FromContext(ctx).WithValues("permissionBinder", pb.Name, "permissionBinderNamespace", pb.Namespace)
+	ctx = log.IntoContext(ctx, logger)
+	total := LdapMembershipResult{}
+
+	declaredMembers := ParseLdapGroupMembers(membersData)
+	if len(declaredMembers) == 0 {
+		logger.V(1).Info("No LDAP group members declared, skipping membership management")
+		return total, nil
+	}
+
+	var groups []*LdapGroupInfo
+	for _, entry := range whitelistEntries {
+		groupInfo, err := ParseCN(entry)
+		if err != nil {
+			continue
+		}
+		if _, declared := declaredMembers[groupInfo.GroupName]; declared {
+			groups = append(groups, groupInfo)
+		}
+	}
+	if len(groups) == 0 {
+		logger.V(1).Info("No whitelist group has declared members, skipping membership management")
+		return total, nil
+	}
+
+	removalBudget := defaultMaxLdapMemberRemovals
+	if pb.Spec.LdapGroupMembers.MaxRemovalsPerReconcile != nil {
+		removalBudget = int(*pb.Spec.LdapGroupMembers.MaxRemovalsPerReconcile)
+	}
+
+	clusterName := r.GetClusterName(ctx, pb)
+	conn, profile, err := r.connectLdapForBinder(ctx, pb)
+	if err != nil {
+		return total, err
+	}
+	defer conn.Close()
+
+	logger.Info("🔐 Reconciling LDAP group members",
+		"groups", len(groups),
+		"maxRemovals", removalBudget)
+
+	for _, groupInfo := range groups {
+		result, err := ReconcileLdapGroupMembers(ctx, conn, profile, groupInfo, declaredMembers[groupInfo.GroupName], &removalBudget, clusterName)
+		total.Added += result.Added
+		total.Removed += result.Removed
+		total.Deferred += result.Deferred
+		total.Errors += result.Errors
+		if err != nil {
+			logger.Error(err, "Failed to reconcile LDAP group members", "group", groupInfo.GroupName)
+			total.Errors++
+		}
+	}
+
+	logger.Info("✅ LDAP group membership reconciliation completed",
+		"added", total.Added,
+		"removed", total.Removed,
+		"deferred", total.Deferred,
+		"errors", total.Errors)
+	return total, nil
+}
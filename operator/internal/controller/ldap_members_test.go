@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// fakeLdapClient is an in-memory ldap.Client holding entries by DN.
+// Methods not overridden panic through the nil embedded interface.
+type fakeLdapClient struct {
+	ldap.Client
+	entries  map[string]map[string][]string // lower-cased DN -> attributes
+	dns      map[string]string              // lower-cased DN -> original DN
+	modifies []*ldap.ModifyRequest
+	closed   bool
+	// pagingSizes records the page sizes of SearchWithPaging calls
+	pagingSizes []uint32
+}
+
+func newFakeLdapClient() *fakeLdapClient {
+	return &fakeLdapClient{
+		entries: make(map[string]map[string][]string),
+		dns:     make(map[string]string),
+	}
+}
+
+func (f *fakeLdapClient) addEntry(dn string, attrs map[string][]string) {
+	f.entries[strings.ToLower(dn)] = attrs
+	f.dns[strings.ToLower(dn)] = dn
+}
+
+func (f *fakeLdapClient) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeLdapClient) IsClosing() bool {
+	return f.closed
+}
+
+func (f *fakeLdapClient) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	result := &ldap.SearchResult{}
+	if req.Scope == ldap.ScopeBaseObject {
+		attrs, ok := f.entries[strings.ToLower(req.BaseDN)]
+		if !ok {
+			return nil, ldap.NewError(ldap.LDAPResultNoSuchObject, nil)
+		}
+		result.Entries = append(result.Entries, ldap.NewEntry(f.dns[strings.ToLower(req.BaseDN)], attrs))
+		return result, nil
+	}
+	// Subtree search: only "(attr=value)", "(attr=*)" and "(attr=prefix*)" filters are supported
+	filter := strings.Trim(req.Filter, "()")
+	attr, value, _ := strings.Cut(filter, "=")
+	prefix, isPrefix := strings.CutSuffix(value, "*")
+	for key, attrs := range f.entries {
+		if !strings.HasSuffix(key, strings.ToLower(req.BaseDN)) {
+			continue
+		}
+		for _, v := range attrs[attr] {
+			if (isPrefix && strings.HasPrefix(strings.ToLower(v), strings.ToLower(prefix))) || strings.EqualFold(v, value) {
+				result.Entries = append(result.Entries, ldap.NewEntry(f.dns[key], attrs))
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeLdapClient) SearchWithPaging(req *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error) {
+	f.pagingSizes = append(f.pagingSizes, pagingSize)
+	return f.Search(req)
+}
+
+func (f *fakeLdapClient) Modify(req *ldap.ModifyRequest) error {
+	f.modifies = append(f.modifies, req)
+	attrs, ok := f.entries[strings.ToLower(req.DN)]
+	if !ok {
+		return ldap.NewError(ldap.LDAPResultNoSuchObject, nil)
+	}
+	for _, change := range req.Changes {
+		name := change.Modification.Type
+		switch change.Operation {
+		case ldap.AddAttribute:
+			attrs[name] = append(attrs[name], change.Modification.Vals...)
+		case ldap.DeleteAttribute:
+			for _, val := range change.Modification.Vals {
+				attrs[name] = removeString(attrs[name], val)
+			}
+		case ldap.ReplaceAttribute:
+			attrs[name] = change.Modification.Vals
+		}
+	}
+	return nil
+}
+
+func (f *fakeLdapClient) Add(req *ldap.AddRequest) error {
+	if _, exists := f.entries[strings.ToLower(req.DN)]; exists {
+		return ldap.NewError(ldap.LDAPResultEntryAlreadyExists, nil)
+	}
+	attrs := make(map[string][]string)
+	for _, attr := range req.Attributes {
+		attrs[attr.Type] = attr.Vals
+	}
+	f.addEntry(req.DN, attrs)
+	return nil
+}
+
+func (f *fakeLdapClient) Del(req *ldap.DelRequest) error {
+	if _, exists := f.entries[strings.ToLower(req.DN)]; !exists {
+		return ldap.NewError(ldap.LDAPResultNoSuchObject, nil)
+	}
+	delete(f.entries, strings.ToLower(req.DN))
+	delete(f.dns, strings.ToLower(req.DN))
+	return nil
+}
+
+func (f *fakeLdapClient) ModifyDN(req *ldap.ModifyDNRequest) error {
+	attrs, exists := f.entries[strings.ToLower(req.DN)]
+	if !exists {
+		return ldap.NewError(ldap.LDAPResultNoSuchObject, nil)
+	}
+	delete(f.entries, strings.ToLower(req.DN))
+	delete(f.dns, strings.ToLower(req.DN))
+	f.addEntry(req.NewRDN+","+req.NewSuperior, attrs)
+	return nil
+}
+
+func TestParseLdapGroupMembers(t *testing.T) {
+	data := map[string]string{
+		"whitelist.txt":       "CN=G1,OU=K8S,DC=example,DC=com",
+		"G1.members":          "# admins\nCN=alice,OU=Users,DC=example,DC=com\n\n  bob  \n",
+		"G2.members":          "",
+		".members":            "ignored",
+		"unrelated-key.other": "x",
+	}
+
+	got := ParseLdapGroupMembers(data)
+	want := map[string][]string{
+		"G1": {"CN=alice,OU=Users,DC=example,DC=com", "bob"},
+		"G2": {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLdapGroupMembers() = %v, want %v", got, want)
+	}
+}
+
+func TestLdapDomainBaseDN(t *testing.T) {
+	got := ldapDomainBaseDN("CN=G1,OU=K8S,DC=example,DC=com")
+	if !strings.EqualFold(got, "DC=example,DC=com") {
+		t.Errorf("ldapDomainBaseDN() = %q, want DC=example,DC=com", got)
+	}
+}
+
+func TestReconcileLdapGroupMembers(t *testing.T) {
+	const groupDN = "CN=G1,OU=K8S,DC=example,DC=com"
+	const aliceDN = "CN=alice,OU=Users,DC=example,DC=com"
+	const bobDN = "CN=bob,OU=Users,DC=example,DC=com"
+	const carolDN = "CN=carol,OU=Users,DC=example,DC=com"
+	const daveDN = "CN=dave,OU=Users,DC=example,DC=com"
+
+	newConn := func() *fakeLdapClient {
+		conn := newFakeLdapClient()
+		conn.addEntry(groupDN, map[string][]string{
+			// alice is present with a differently-cased DN and must not be re-added
+			"member": {"cn=Alice,ou=Users,dc=example,dc=com", carolDN, daveDN},
+		})
+		conn.addEntry(bobDN, map[string][]string{"sAMAccountName": {"bob"}})
+		return conn
+	}
+	groupInfo := &LdapGroupInfo{GroupName: "G1", FullDN: groupDN}
+	profile, _ := ResolveLdapSchemaProfile(nil, "")
+
+	t.Run("adds and removes members", func(t *testing.T) {
+		conn := newConn()
+		budget := 10
+		result, err := ReconcileLdapGroupMembers(context.Background(), conn, profile, groupInfo,
+			[]string{aliceDN, "bob"}, &budget, "test-cluster")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Added != 1 || result.Removed != 2 || result.Deferred != 0 || result.Errors != 0 {
+			t.Errorf("result = %+v, want 1 added, 2 removed", result)
+		}
+		members := conn.entries[strings.ToLower(groupDN)]["member"]
+		if len(members) != 2 || !containsLdapDN(members, aliceDN) || !containsLdapDN(members, bobDN) {
+			t.Errorf("members = %v, want alice and bob", members)
+		}
+		if budget != 8 {
+			t.Errorf("removal budget = %d, want 8", budget)
+		}
+	})
+
+	t.Run("defers removals beyond the cap", func(t *testing.T) {
+		conn := newConn()
+		budget := 1
+		result, err := ReconcileLdapGroupMembers(context.Background(), conn, profile, groupInfo,
+			[]string{aliceDN}, &budget, "test-cluster")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Removed != 1 || result.Deferred != 1 {
+			t.Errorf("result = %+v, want 1 removed, 1 deferred", result)
+		}
+		if got := len(conn.entries[strings.ToLower(groupDN)]["member"]); got != 2 {
+			t.Errorf("member count = %d, want 2", got)
+		}
+	})
+
+	t.Run("skips removals when a member cannot be resolved", func(t *testing.T) {
+		conn := newConn()
+		budget := 10
+		result, err := ReconcileLdapGroupMembers(context.Background(), conn, profile, groupInfo,
+			[]string{aliceDN, "unknown-user"}, &budget, "test-cluster")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Errors != 1 || result.Removed != 0 {
+			t.Errorf("result = %+v, want 1 error and no removals", result)
+		}
+		if len(conn.modifies) != 0 {
+			t.Errorf("expected no Modify calls, got %d", len(conn.modifies))
+		}
+	})
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// Optional LDAP Secret keys for connection handling
+	ldapSecretKeyStartTLS         = "start_tls"
+	ldapSecretKeyServerSelection  = "server_selection"
+	ldapSecretKeyConnectTimeout   = "connect_timeout"
+	ldapSecretKeyOperationTimeout = "operation_timeout"
+	ldapSecretKeyPoolIdleTimeout  = "pool_idle_timeout"
+
+	// server_selection values
+	LdapServerSelectionFailover   = "failover"
+	LdapServerSelectionRoundRobin = "round-robin"
+
+	defaultLdapConnectTimeout   = 10 * time.Second
+	defaultLdapOperationTimeout = 30 * time.Second
+	defaultLdapPoolIdleTimeout  = 5 * time.Minute
+)
+
+// LdapConnectionOptions are the optional connection settings of the LDAP Secret
+type LdapConnectionOptions struct {
+	// Servers are the LDAP server URLs from "domain_server" (comma or newline separated)
+	Servers []string
+	// StartTLS upgrades ldap:// connections with StartTLS ("start_tls": "true")
+	StartTLS bool
+	// ServerSelection is failover (default: always prefer the first reachable
+	// server) or round-robin (start at the next server on every new connection)
+	ServerSelection string
+	// ConnectTimeout limits dialing a single server ("connect_timeout", default 10s)
+	ConnectTimeout time.Duration
+	// OperationTimeout limits a single LDAP request ("operation_timeout", default 30s)
+	OperationTimeout time.Duration
+	// PoolIdleTimeout closes pooled connections unused for this long ("pool_idle_timeout", default 5m)
+	PoolIdleTimeout time.Duration
+}
+
+// parseLdapConnectionOptions reads the optional connection keys of the LDAP Secret
+func parseLdapConnectionOptions(data map[string][]byte) (LdapConnectionOptions, error) {
+	options := LdapConnectionOptions{ServerSelection: LdapServerSelectionFailover}
+
+	if value, ok := data[ldapSecretKeyStartTLS]; ok {
+		startTLS, err := strconv.ParseBool(strings.TrimSpace(string(value)))
+		if err != nil {
+			return options, fmt.Errorf("%s: %w", ldapSecretKeyStartTLS, err)
+		}
+		options.StartTLS = startTLS
+	}
+
+	if value, ok := data[ldapSecretKeyServerSelection]; ok {
+		selection := strings.TrimSpace(string(value))
+		if selection != LdapServerSelectionFailover && selection != LdapServerSelectionRoundRobin {
+			return options, fmt.Errorf("%s: must be %q or %q, got %q",
+				ldapSecretKeyServerSelection, LdapServerSelectionFailover, LdapServerSelectionRoundRobin, selection)
+		}
+		options.ServerSelection = selection
+	}
+
+	durations := []struct {
+		key    string
+		target *time.Duration
+	}{
+		{ldapSecretKeyConnectTimeout, &options.ConnectTimeout},
+		{ldapSecretKeyOperationTimeout, &options.OperationTimeout},
+		{ldapSecretKeyPoolIdleTimeout, &options.PoolIdleTimeout},
+	}
+	for _, d := range durations {
+		value, ok := data[d.key]
+		if !ok {
+			continue
+		}
+		parsed, err := time.ParseDuration(strings.TrimSpace(string(value)))
+		if err != nil || parsed <= 0 {
+			return options, fmt.Errorf("%s: invalid duration %q", d.key, string(value))
+		}
+		*d.target = parsed
+	}
+
+	return options, nil
+}
+
+func (o LdapConnectionOptions) connectTimeout() time.Duration {
+	if o.ConnectTimeout > 0 {
+		return o.ConnectTimeout
+	}
+	return defaultLdapConnectTimeout
+}
+
+func (o LdapConnectionOptions) operationTimeout() time.Duration {
+	if o.OperationTimeout > 0 {
+		return o.OperationTimeout
+	}
+	return defaultLdapOperationTimeout
+}
+
+func (o LdapConnectionOptions) poolIdleTimeout() time.Duration {
+	if o.PoolIdleTimeout > 0 {
+		return o.PoolIdleTimeout
+	}
+	return defaultLdapPoolIdleTimeout
+}
+
+// splitLdapServers splits a "domain_server" value into server URLs
+func splitLdapServers(value string) []string {
+	var servers []string
+	for _, server := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' '
+	}) {
+		if server = strings.TrimSpace(server); server != "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// ldapRoundRobinCounter picks the first server of the next round-robin connection
+var ldapRoundRobinCounter atomic.Uint64
+
+// orderLdapServers returns the servers in the order they are tried
+func orderLdapServers(servers []string, selection string) []string {
+	if selection != LdapServerSelectionRoundRobin || len(servers) < 2 {
+		return servers
+	}
+	start := int(ldapRoundRobinCounter.Add(1)-1) % len(servers)
+	ordered := make([]string, 0, len(servers))
+	ordered = append(ordered, servers[start:]...)
+	return append(ordered, servers[:start]...)
+}
+
+// ldapConnectionFingerprint identifies the connection settings of a pooled
+// connection - any credential or option change yields a new connection
+func ldapConnectionFingerprint(creds *LdapCredentials, tlsVerify bool) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00%s\x00%v\x00%+v",
+		creds.Server, creds.Username, creds.Password, creds.CACert, tlsVerify, creds.Options)
+	fmt.Fprintf(hash, "\x00%s\x00%s\x00%s\x00%s\x00%x",
+		creds.Bind.Method, creds.Bind.KerberosRealm, creds.Bind.KerberosSPN, creds.Bind.Krb5Conf, creds.Bind.Keytab)
+	if creds.Bind.ClientCert != nil {
+		for _, der := range creds.Bind.ClientCert.Certificate {
+			fmt.Fprintf(hash, "\x00%x", der)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ldapPool keeps one shared, bound LDAP connection per LDAP Secret so that
+// reconciles reuse it instead of dialing and binding every time. A connection
+// is replaced when the Secret content changes, after a network error, when
+// the server closed it, or after it was idle for longer than pool_idle_timeout.
+// Dials run outside mu: a slow or unreachable server only delays the reconciles
+// of its own Secret.
+type ldapPool struct {
+	mu      sync.Mutex
+	entries map[string]*ldapPoolEntry
+	// dialing serializes the dials per key, so concurrent reconciles of the
+	// same Secret share one new connection instead of dialing twice
+	dialing map[string]*sync.Mutex
+}
+
+type ldapPoolEntry struct {
+	fingerprint string
+	conn        ldap.Client
+	lastUsed    time.Time
+	idleTimeout time.Duration
+	inUse       int
+	broken      bool
+}
+
+// defaultLdapPool is shared by all reconcilers of the operator process
+var defaultLdapPool = &ldapPool{entries: make(map[string]*ldapPoolEntry)}
+
+// Get returns the pooled connection for key, connecting with dial when there is
+// no usable one. Close() on the returned client releases it back to the pool.
+func (p *ldapPool) Get(key, fingerprint string, idleTimeout time.Duration, now time.Time, dial func() (ldap.Client, error)) (ldap.Client, bool, error) {
+	if conn := p.checkout(key, fingerprint, now); conn != nil {
+		return conn, true, nil
+	}
+
+	dialMu := p.dialLock(key)
+	dialMu.Lock()
+	defer dialMu.Unlock()
+
+	// Dialed by a concurrent Get while waiting for the dial lock
+	if conn := p.checkout(key, fingerprint, now); conn != nil {
+		return conn, true, nil
+	}
+
+	conn, err := dial()
+	if err != nil {
+		return nil, false, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if entry, ok := p.entries[key]; ok {
+		p.evictLocked(key, entry)
+	}
+	entry := &ldapPoolEntry{
+		fingerprint: fingerprint,
+		conn:        conn,
+		lastUsed:    now,
+		idleTimeout: idleTimeout,
+		inUse:       1,
+	}
+	p.entries[key] = entry
+	return &pooledLdapConn{Client: conn, pool: p, entry: entry}, false, nil
+}
+
+// checkout returns the usable pooled connection of key, nil after evicting an
+// unusable one
+func (p *ldapPool) checkout(key, fingerprint string, now time.Time) ldap.Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[key]
+	if !ok {
+		return nil
+	}
+	usable := entry.fingerprint == fingerprint && !entry.broken &&
+		!entry.conn.IsClosing() && now.Sub(entry.lastUsed) < entry.idleTimeout
+	if !usable {
+		p.evictLocked(key, entry)
+		return nil
+	}
+	entry.inUse++
+	entry.lastUsed = now
+	return &pooledLdapConn{Client: entry.conn, pool: p, entry: entry}
+}
+
+// dialLock returns the lock serializing the dials of key
+func (p *ldapPool) dialLock(key string) *sync.Mutex {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.dialing == nil {
+		p.dialing = make(map[string]*sync.Mutex)
+	}
+	if p.dialing[key] == nil {
+		p.dialing[key] = &sync.Mutex{}
+	}
+	return p.dialing[key]
+}
+
+// evictLocked removes an entry; its connection is closed once no longer in use
+func (p *ldapPool) evictLocked(key string, entry *ldapPoolEntry) {
+	if p.entries[key] == entry {
+		delete(p.entries, key)
+	}
+	entry.broken = true
+	if entry.inUse == 0 {
+		entry.conn.Close()
+	}
+}
+
+// release returns a checked-out connection to the pool
+func (p *ldapPool) release(entry *ldapPoolEntry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry.inUse--
+	if entry.broken && entry.inUse == 0 {
+		entry.conn.Close()
+	}
+}
+
+// markBroken evicts the connection after a network failure so the next Get reconnects
+func (p *ldapPool) markBroken(entry *ldapPoolEntry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, candidate := range p.entries {
+		if candidate == entry {
+			p.evictLocked(key, entry)
+			return
+		}
+	}
+	entry.broken = true
+}
+
+// pooledLdapConn is a checked-out pooled connection. Close releases it to the
+// pool; network errors of its operations evict it from the pool.
+type pooledLdapConn struct {
+	ldap.Client
+	pool     *ldapPool
+	entry    *ldapPoolEntry
+	released atomic.Bool
+}
+
+// Close releases the connection back to the pool (the connection stays open)
+func (c *pooledLdapConn) Close() error {
+	if c.released.CompareAndSwap(false, true) {
+		c.pool.release(c.entry)
+	}
+	return nil
+}
+
+// check evicts the connection when err indicates a broken connection
+func (c *pooledLdapConn) check(err error) error {
+	if err != nil && (ldap.IsErrorWithCode(err, ldap.ErrorNetwork) || ldap.IsErrorWithCode(err, ldap.LDAPResultServerDown) ||
+		ldap.IsErrorWithCode(err, ldap.LDAPResultUnavailable) || c.Client.IsClosing()) {
+		c.pool.markBroken(c.entry)
+	}
+	return err
+}
+
+func (c *pooledLdapConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	sr, err := c.Client.Search(req)
+	return sr, c.check(err)
+}
+
+func (c *pooledLdapConn) SearchWithPaging(req *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error) {
+	sr, err := c.Client.SearchWithPaging(req, pagingSize)
+	return sr, c.check(err)
+}
+
+func (c *pooledLdapConn) Add(req *ldap.AddRequest) error {
+	return c.check(c.Client.Add(req))
+}
+
+func (c *pooledLdapConn) Modify(req *ldap.ModifyRequest) error {
+	return c.check(c.Client.Modify(req))
+}
+
+func (c *pooledLdapConn) ModifyDN(req *ldap.ModifyDNRequest) error {
+	return c.check(c.Client.ModifyDN(req))
+}
+
+func (c *pooledLdapConn) Del(req *ldap.DelRequest) error {
+	return c.check(c.Client.Del(req))
+}
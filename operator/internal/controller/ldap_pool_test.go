@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+func TestParseLdapConnectionOptions(t *testing.T) {
+	options, err := parseLdapConnectionOptions(map[string][]byte{
+		"start_tls":         []byte("true"),
+		"server_selection":  []byte("round-robin"),
+		"connect_timeout":   []byte("3s"),
+		"operation_timeout": []byte("1m"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !options.StartTLS || options.ServerSelection != LdapServerSelectionRoundRobin ||
+		options.connectTimeout() != 3*time.Second || options.operationTimeout() != time.Minute ||
+		options.poolIdleTimeout() != defaultLdapPoolIdleTimeout {
+		t.Errorf("options = %+v", options)
+	}
+
+	defaults, err := parseLdapConnectionOptions(map[string][]byte{})
+	if err != nil || defaults.StartTLS || defaults.ServerSelection != LdapServerSelectionFailover ||
+		defaults.connectTimeout() != defaultLdapConnectTimeout {
+		t.Errorf("defaults = %+v, err = %v", defaults, err)
+	}
+
+	for _, data := range []map[string][]byte{
+		{"start_tls": []byte("maybe")},
+		{"server_selection": []byte("random")},
+		{"connect_timeout": []byte("-1s")},
+	} {
+		if _, err := parseLdapConnectionOptions(data); err == nil {
+			t.Errorf("expected an error for %v", data)
+		}
+	}
+}
+
+func TestSplitAndOrderLdapServers(t *testing.T) {
+	servers := splitLdapServers("ldaps://dc1:636, ldaps://dc2:636\nldaps://dc3:636")
+	want := []string{"ldaps://dc1:636", "ldaps://dc2:636", "ldaps://dc3:636"}
+	if !reflect.DeepEqual(servers, want) {
+		t.Fatalf("splitLdapServers() = %v, want %v", servers, want)
+	}
+
+	if got := orderLdapServers(servers, LdapServerSelectionFailover); !reflect.DeepEqual(got, want) {
+		t.Errorf("failover order = %v, want %v", got, want)
+	}
+
+	first := orderLdapServers(servers, LdapServerSelectionRoundRobin)
+	second := orderLdapServers(servers, LdapServerSelectionRoundRobin)
+	if len(first) != 3 || len(second) != 3 || first[0] == second[0] || second[0] != first[1] {
+		t.Errorf("round-robin orders %v and %v should start at consecutive servers", first, second)
+	}
+}
+
+func TestLdapPool(t *testing.T) {
+	pool := &ldapPool{entries: make(map[string]*ldapPoolEntry)}
+	now := time.Now()
+	var dialed []*fakeLdapClient
+	dial := func() (ldap.Client, error) {
+		conn := newFakeLdapClient()
+		dialed = append(dialed, conn)
+		return conn, nil
+	}
+
+	conn, reused, err := pool.Get("ns/secret", "v1", time.Minute, now, dial)
+	if err != nil || reused {
+		t.Fatalf("first Get: reused=%v err=%v", reused, err)
+	}
+	conn.Close()
+	if dialed[0].closed {
+		t.Fatal("Close must release the connection to the pool, not close it")
+	}
+
+	// Reused while the settings are unchanged
+	conn, reused, _ = pool.Get("ns/secret", "v1", time.Minute, now.Add(time.Second), dial)
+	if !reused || len(dialed) != 1 {
+		t.Fatalf("second Get: reused=%v dials=%d, want reuse", reused, len(dialed))
+	}
+
+	// A network error evicts the connection; it is closed on release
+	conn.(*pooledLdapConn).check(ldap.NewError(ldap.ErrorNetwork, errors.New("connection reset")))
+	conn.Close()
+	if !dialed[0].closed {
+		t.Error("broken connection not closed after release")
+	}
+	conn, reused, _ = pool.Get("ns/secret", "v1", time.Minute, now.Add(2*time.Second), dial)
+	if reused || len(dialed) != 2 {
+		t.Fatalf("Get after network error: reused=%v dials=%d, want reconnect", reused, len(dialed))
+	}
+	conn.Close()
+
+	// Changed Secret content reconnects
+	conn, reused, _ = pool.Get("ns/secret", "v2", time.Minute, now.Add(3*time.Second), dial)
+	if reused || len(dialed) != 3 || !dialed[1].closed {
+		t.Fatalf("Get with new fingerprint: reused=%v dials=%d, want reconnect and old closed", reused, len(dialed))
+	}
+	conn.Close()
+
+	// Idle connections are replaced
+	_, reused, _ = pool.Get("ns/secret", "v2", time.Minute, now.Add(10*time.Minute), dial)
+	if reused || len(dialed) != 4 {
+		t.Errorf("Get after idle timeout: reused=%v dials=%d, want reconnect", reused, len(dialed))
+	}
+}
+
+// TestLdapPoolDialOutsideLock verifies that a slow dial only holds up its own key:
+// another Secret is served meanwhile, and a concurrent Get of the same key waits
+// for the dial and shares its connection.
+func TestLdapPoolDialOutsideLock(t *testing.T) {
+	pool := &ldapPool{entries: make(map[string]*ldapPoolEntry)}
+	now := time.Now()
+	slow := newFakeLdapClient()
+	dialing := make(chan struct{})
+	unblock := make(chan struct{})
+	slowDials := 0
+	slowDial := func() (ldap.Client, error) {
+		slowDials++
+		close(dialing)
+		<-unblock
+		return slow, nil
+	}
+
+	type got struct {
+		conn   ldap.Client
+		reused bool
+		err    error
+	}
+	first := make(chan got)
+	go func() {
+		conn, reused, err := pool.Get("ns/slow", "v1", time.Minute, now, slowDial)
+		first <- got{conn, reused, err}
+	}()
+	<-dialing
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, reused, err := pool.Get("ns/fast", "v1", time.Minute, now, func() (ldap.Client, error) {
+			return newFakeLdapClient(), nil
+		})
+		if err != nil || reused {
+			t.Errorf("Get of another key: reused=%v err=%v", reused, err)
+			return
+		}
+		conn.Close()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Get of another key blocked by a slow dial")
+	}
+
+	second := make(chan got)
+	go func() {
+		conn, reused, err := pool.Get("ns/slow", "v1", time.Minute, now, slowDial)
+		second <- got{conn, reused, err}
+	}()
+	close(unblock)
+
+	for _, result := range []got{<-first, <-second} {
+		if result.err != nil || result.conn.(*pooledLdapConn).Client != slow {
+			t.Fatalf("Get of the slow key = %+v, want the dialed connection", result)
+		}
+		result.conn.Close()
+	}
+	if slowDials != 1 {
+		t.Errorf("slow key dialed %d times, want once", slowDials)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"golang.org/x/time/rate"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	defaultLdapOperationsPerSecond = 20
+	defaultLdapOperationsBurst     = 40
+	defaultLdapExistenceCacheTTL   = 30 * time.Minute
+
+	// ldapRateLimitConfigTTL is how long the rate limit of a PermissionBinder keeps
+	// constraining the shared limiter after its last connection
+	ldapRateLimitConfigTTL = time.Hour
+	// ldapGroupCachePruneInterval bounds how often the group cache is swept
+	ldapGroupCachePruneInterval = time.Minute
+)
+
+// ldapRateLimit returns the token bucket parameters of a PermissionBinder
+func ldapRateLimit(pb *permissionv1.PermissionBinder) (rate.Limit, int) {
+	perSecond, burst := int32(defaultLdapOperationsPerSecond), int32(defaultLdapOperationsBurst)
+	if spec := pb.Spec.LdapRateLimit; spec != nil {
+		if spec.OperationsPerSecond != nil && *spec.OperationsPerSecond > 0 {
+			perSecond = *spec.OperationsPerSecond
+		}
+		if spec.Burst != nil && *spec.Burst > 0 {
+			burst = *spec.Burst
+		}
+	}
+	return rate.Limit(perSecond), int(burst)
+}
+
+// ldapExistenceCacheTTL parses ldapRateLimit.existenceCacheTtl (default 30m, 0 disables the cache)
+func ldapExistenceCacheTTL(pb *permissionv1.PermissionBinder) time.Duration {
+	if pb.Spec.LdapRateLimit == nil || pb.Spec.LdapRateLimit.ExistenceCacheTTL == "" {
+		return defaultLdapExistenceCacheTTL
+	}
+	ttl, err := time.ParseDuration(pb.Spec.LdapRateLimit.ExistenceCacheTTL)
+	if err != nil || ttl < 0 {
+		return defaultLdapExistenceCacheTTL
+	}
+	return ttl
+}
+
+// ldapSecretKey identifies the LDAP server of a PermissionBinder (its ldapSecretRef).
+// Connections, rate limiters and the group cache are shared per key.
+func ldapSecretKey(pb *permissionv1.PermissionBinder) string {
+	return fmt.Sprintf("%s/%s", pb.Spec.LdapSecretRef.Namespace, pb.Spec.LdapSecretRef.Name)
+}
+
+// ldapRateLimitConfig is the rate limit a PermissionBinder configured for a Secret
+type ldapRateLimitConfig struct {
+	limit  rate.Limit
+	burst  int
+	seenAt time.Time
+}
+
+// ldapRateLimiters holds one token bucket per LDAP Secret so that several
+// PermissionBinders using the same directory share its budget. The bucket runs at
+// the most restrictive rate and burst configured by those PermissionBinders.
+type ldapRateLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	// configs holds the configured rate per key and PermissionBinder
+	configs map[string]map[string]ldapRateLimitConfig
+}
+
+var defaultLdapRateLimiters = newLdapRateLimiters()
+
+func newLdapRateLimiters() *ldapRateLimiters {
+	return &ldapRateLimiters{
+		limiters: make(map[string]*rate.Limiter),
+		configs:  make(map[string]map[string]ldapRateLimitConfig),
+	}
+}
+
+// get records the rate configured by binder and returns the limiter of key, set
+// to the minimum of the rates configured by the PermissionBinders that used key
+// within ldapRateLimitConfigTTL
+func (l *ldapRateLimiters) get(key, binder string, limit rate.Limit, burst int, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	configs, found := l.configs[key]
+	if !found {
+		configs = make(map[string]ldapRateLimitConfig)
+		l.configs[key] = configs
+	}
+	configs[binder] = ldapRateLimitConfig{limit: limit, burst: burst, seenAt: now}
+	for other, config := range configs {
+		if now.Sub(config.seenAt) > ldapRateLimitConfigTTL {
+			delete(configs, other)
+			continue
+		}
+		limit = min(limit, config.limit)
+		burst = min(burst, config.burst)
+	}
+
+	limiter, found := l.limiters[key]
+	if !found {
+		limiter = rate.NewLimiter(limit, burst)
+		l.limiters[key] = limiter
+		return limiter
+	}
+	if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	if limiter.Burst() != burst {
+		limiter.SetBurst(burst)
+	}
+	return limiter
+}
+
+// ldapGroupCacheEntry records that a group was confirmed to exist
+type ldapGroupCacheEntry struct {
+	owned       bool
+	clusterName string
+	// version is the whitelist ConfigMap version being processed at confirmation
+	version     string
+	confirmedAt time.Time
+}
+
+// ldapGroupCache remembers groups known to exist so that createLdapGroups does
+// not search every whitelist entry on every processing of the whitelist
+type ldapGroupCache struct {
+	mu      sync.Mutex
+	entries map[string]ldapGroupCacheEntry
+	// prunedAt is the time of the last sweep of expired entries
+	prunedAt time.Time
+}
+
+var defaultLdapGroupCache = &ldapGroupCache{entries: make(map[string]ldapGroupCacheEntry)}
+
+func ldapGroupCacheKey(secretKey, dn string) string {
+	return secretKey + "\x00" + strings.ToLower(dn)
+}
+
+// confirmed reports whether dn is known to exist, and whether the operator owns it.
+// A confirmation made while processing the same whitelist version is always
+// used; older ones only within ttl.
+func (c *ldapGroupCache) confirmed(secretKey, dn, clusterName, version string, ttl time.Duration, now time.Time) (bool, bool) {
+	if ttl <= 0 {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, found := c.entries[ldapGroupCacheKey(secretKey, dn)]
+	if !found || entry.clusterName != clusterName {
+		return false, false
+	}
+	if (version != "" && entry.version == version) || now.Sub(entry.confirmedAt) < ttl {
+		return entry.owned, true
+	}
+	return false, false
+}
+
+// confirm records that dn exists. Entries that expired under ttl and were not
+// confirmed for version are swept at most once per ldapGroupCachePruneInterval,
+// so that groups gone from all whitelists do not stay in memory.
+func (c *ldapGroupCache) confirm(secretKey, dn, clusterName, version string, owned bool, ttl time.Duration, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ttl > 0 && now.Sub(c.prunedAt) >= ldapGroupCachePruneInterval {
+		for key, entry := range c.entries {
+			if entry.version != version && now.Sub(entry.confirmedAt) >= ttl {
+				delete(c.entries, key)
+			}
+		}
+		c.prunedAt = now
+	}
+	c.entries[ldapGroupCacheKey(secretKey, dn)] = ldapGroupCacheEntry{
+		owned:       owned,
+		clusterName: clusterName,
+		version:     version,
+		confirmedAt: now,
+	}
+}
+
+// forget drops dn, e.g. after the group was deleted or moved away
+func (c *ldapGroupCache) forget(secretKey, dn string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, ldapGroupCacheKey(secretKey, dn))
+}
+
+// rateLimitedLdapConn takes a token from the LDAP Secret's bucket before every
+// search and write, and drops deleted or moved groups from the group cache
+type rateLimitedLdapConn struct {
+	ldap.Client
+	ctx       context.Context
+	limiter   *rate.Limiter
+	secretKey string
+}
+
+// wait blocks until the bucket has a token (or the reconciliation is cancelled)
+func (c *rateLimitedLdapConn) wait() error {
+	if c.limiter.Allow() {
+		return nil
+	}
+	ldapOperationsThrottledTotal.Inc()
+	if err := c.limiter.Wait(c.ctx); err != nil {
+		return fmt.Errorf("LDAP rate limit: %w", err)
+	}
+	return nil
+}
+
+func (c *rateLimitedLdapConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+	return c.Client.Search(req)
+}
+
+func (c *rateLimitedLdapConn) SearchWithPaging(req *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+	return c.Client.SearchWithPaging(req, pagingSize)
+}
+
+func (c *rateLimitedLdapConn) Add(req *ldap.AddRequest) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	return c.Client.Add(req)
+}
+
+func (c *rateLimitedLdapConn) Modify(req *ldap.ModifyRequest) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	return c.Client.Modify(req)
+}
+
+func (c *rateLimitedLdapConn) ModifyDN(req *ldap.ModifyDNRequest) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	defaultLdapGroupCache.forget(c.secretKey, req.DN)
+	return c.Client.ModifyDN(req)
+}
+
+func (c *rateLimitedLdapConn) Del(req *ldap.DelRequest) error {
+	if err := c.wait(); err != nil {
+		return err
+	}
+	defaultLdapGroupCache.forget(c.secretKey, req.DN)
+	return c.Client.Del(req)
+}
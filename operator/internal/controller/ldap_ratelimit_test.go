@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"golang.org/x/time/rate"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestLdapGroupCache_Confirmed(t *testing.T) {
+	const dn = "CN=COMPANY-K8S-app-admin,OU=K8S,DC=example,DC=com"
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cache := &ldapGroupCache{entries: make(map[string]ldapGroupCacheEntry)}
+	cache.confirm("operators/ldap", dn, "prod", "100", true, 30*time.Minute, now)
+
+	tests := []struct {
+		name        string
+		dn          string
+		clusterName string
+		version     string
+		ttl         time.Duration
+		at          time.Time
+		want        bool
+	}{
+		{name: "within ttl", dn: dn, clusterName: "prod", version: "101", ttl: 30 * time.Minute, at: now.Add(10 * time.Minute), want: true},
+		{name: "DN case differs", dn: "cn=company-k8s-app-admin,ou=k8s,dc=example,dc=com", clusterName: "prod", version: "101", ttl: 30 * time.Minute, at: now, want: true},
+		{name: "expired", dn: dn, clusterName: "prod", version: "101", ttl: 30 * time.Minute, at: now.Add(time.Hour), want: false},
+		{name: "expired but confirmed for the same whitelist version", dn: dn, clusterName: "prod", version: "100", ttl: 30 * time.Minute, at: now.Add(time.Hour), want: true},
+		{name: "other cluster name", dn: dn, clusterName: "dev", version: "100", ttl: 30 * time.Minute, at: now, want: false},
+		{name: "cache disabled", dn: dn, clusterName: "prod", version: "100", ttl: 0, at: now, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owned, ok := cache.confirmed("operators/ldap", tt.dn, tt.clusterName, tt.version, tt.ttl, tt.at)
+			if ok != tt.want {
+				t.Errorf("confirmed() = %v, want %v", ok, tt.want)
+			}
+			if ok && !owned {
+				t.Error("ownership of the cached group was lost")
+			}
+		})
+	}
+
+	if _, ok := cache.confirmed("other/ldap", dn, "prod", "100", time.Hour, now); ok {
+		t.Error("cache entries must not be shared between LDAP Secrets")
+	}
+}
+
+func TestRateLimitedLdapConn(t *testing.T) {
+	const dn = "CN=COMPANY-K8S-app-admin,OU=K8S,DC=example,DC=com"
+	conn := newFakeLdapClient()
+	conn.addEntry(dn, map[string][]string{"description": {"x"}})
+	defaultLdapGroupCache.confirm("operators/ldap", dn, "prod", "1", true, time.Hour, time.Now())
+	t.Cleanup(func() { defaultLdapGroupCache.forget("operators/ldap", dn) })
+
+	limited := &rateLimitedLdapConn{
+		Client:    conn,
+		ctx:       context.Background(),
+		limiter:   rate.NewLimiter(rate.Every(50*time.Millisecond), 1),
+		secretKey: "operators/ldap",
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := limited.Search(ldap.NewSearchRequest(dn, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false, "(objectClass=*)", nil, nil)); err != nil {
+			t.Fatalf("Search() error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 operations with burst 1 at 20/s took %v, want them throttled", elapsed)
+	}
+
+	if err := limited.Del(ldap.NewDelRequest(dn, nil)); err != nil {
+		t.Fatalf("Del() error: %v", err)
+	}
+	if _, ok := defaultLdapGroupCache.confirmed("operators/ldap", dn, "prod", "1", time.Hour, time.Now()); ok {
+		t.Error("deleted group is still cached as existing")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limited.ctx = ctx
+	limited.limiter = rate.NewLimiter(rate.Every(time.Hour), 1)
+	limited.limiter.Allow()
+	if err := limited.Modify(ldap.NewModifyRequest(dn, nil)); err == nil {
+		t.Error("expected an error when the reconciliation is cancelled while throttled")
+	}
+}
+
+func TestLdapGroupCache_PrunesExpiredEntries(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cache := &ldapGroupCache{entries: make(map[string]ldapGroupCacheEntry)}
+	cache.confirm("operators/ldap", "CN=old,DC=example,DC=com", "prod", "100", true, 30*time.Minute, now)
+	cache.confirm("operators/ldap", "CN=current,DC=example,DC=com", "prod", "101", true, 30*time.Minute, now)
+
+	// An hour later version 101 is processed: the expired entry of version 100 is
+	// dropped, the one confirmed for version 101 stays usable
+	cache.confirm("operators/ldap", "CN=new,DC=example,DC=com", "prod", "101", true, 30*time.Minute, now.Add(time.Hour))
+	if len(cache.entries) != 2 {
+		t.Errorf("entries = %d, want 2 after pruning", len(cache.entries))
+	}
+	if _, ok := cache.confirmed("operators/ldap", "CN=current,DC=example,DC=com", "prod", "101", 30*time.Minute, now.Add(time.Hour)); !ok {
+		t.Error("entry confirmed for the current version must not be pruned")
+	}
+}
+
+func TestLdapRateLimiters_Get(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	limiters := newLdapRateLimiters()
+	first := limiters.get("operators/ldap", "team-a/binder", 5, 40, now)
+	second := limiters.get("operators/ldap", "team-b/binder", 20, 10, now)
+	if first != second {
+		t.Fatal("PermissionBinders of one LDAP Secret must share a limiter")
+	}
+	if second.Limit() != 5 || second.Burst() != 10 {
+		t.Errorf("limit = %v burst = %d, want the minimum of the configurations", second.Limit(), second.Burst())
+	}
+
+	// A PermissionBinder with a higher rate cannot raise the shared limit ...
+	limiters.get("operators/ldap", "team-b/binder", 20, 40, now.Add(time.Minute))
+	if second.Limit() != 5 || second.Burst() != 40 {
+		t.Errorf("limit = %v burst = %d, want 5 and 40", second.Limit(), second.Burst())
+	}
+	// ... until the more restrictive one has not connected for ldapRateLimitConfigTTL
+	limiters.get("operators/ldap", "team-b/binder", 20, 40, now.Add(ldapRateLimitConfigTTL+time.Minute))
+	if second.Limit() != 20 {
+		t.Errorf("limit = %v, want 20 once the restrictive configuration expired", second.Limit())
+	}
+	if other := limiters.get("operators/other", "team-a/binder", 5, 40, now); other == second {
+		t.Error("LDAP Secrets must not share a limiter")
+	}
+
+	perSecond := int32(2)
+	limit, burst := ldapRateLimit(&permissionv1.PermissionBinder{Spec: permissionv1.PermissionBinderSpec{
+		LdapRateLimit: &permissionv1.LdapRateLimitSpec{OperationsPerSecond: &perSecond},
+	}})
+	if limit != 2 || burst != defaultLdapOperationsBurst {
+		t.Errorf("ldapRateLimit() = %v, %d", limit, burst)
+	}
+}
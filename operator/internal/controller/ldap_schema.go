@@ -0,0 +1,256 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-ldap/ldap/v3"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// ldapSchema.profile values
+	LdapSchemaActiveDirectory = "ActiveDirectory"
+	LdapSchemaGroupOfNames    = "GroupOfNames"
+	LdapSchemaPosixGroup      = "PosixGroup"
+	LdapSchemaCustom          = "Custom"
+
+	// Default gidNumber range for posixGroup allocation
+	defaultGidNumberMin = 10000
+	defaultGidNumberMax = 59999
+
+	// ldapGidSearchPageSize is the page size of the gidNumber search
+	ldapGidSearchPageSize = 500
+)
+
+// LdapSchemaProfile describes how groups are created and how their members are
+// stored for one directory flavour
+type LdapSchemaProfile struct {
+	Name          string
+	ObjectClasses []string
+	// Attributes are text/template values (one value per line), see LdapGroupTemplateData
+	Attributes map[string]string
+	// MemberAttribute holds the members of a group
+	MemberAttribute string
+	// MemberIsDN is true when MemberAttribute holds DNs, false for user names (memberUid)
+	MemberIsDN bool
+	// UserNameAttribute resolves non-DN members to entries (and DN members to names)
+	UserNameAttribute string
+	// PlaceholderMember is always kept in the group (groupOfNames requires a member)
+	PlaceholderMember string
+	// BindDN is the bind user (template field .BindDN)
+	BindDN string
+	// AllocateGidNumber assigns the next free gidNumber in [GidNumberMin, GidNumberMax]
+	AllocateGidNumber bool
+	GidNumberMin      int64
+	GidNumberMax      int64
+	// GidSearchBaseDN overrides the subtree searched for gidNumbers in use
+	GidSearchBaseDN string
+}
+
+// LdapGroupTemplateData is available to the attribute templates of a profile
+type LdapGroupTemplateData struct {
+	GroupName         string
+	Description       string
+	ClusterName       string
+	BindDN            string
+	PlaceholderMember string
+	GidNumber         int64
+}
+
+// ResolveLdapSchemaProfile returns the schema profile selected by spec (ActiveDirectory
+// when nil). bindDN is the DN of a simple bind (empty for bind methods without a DN)
+// and the default placeholder member of groupOfNames groups.
+func ResolveLdapSchemaProfile(spec *permissionv1.LdapSchemaSpec, bindDN string) (*LdapSchemaProfile, error) {
+	name := LdapSchemaActiveDirectory
+	if spec != nil && spec.Profile != "" {
+		name = spec.Profile
+	}
+
+	profile := &LdapSchemaProfile{
+		Name:         name,
+		BindDN:       bindDN,
+		GidNumberMin: defaultGidNumberMin,
+		GidNumberMax: defaultGidNumberMax,
+	}
+	switch name {
+	case LdapSchemaActiveDirectory:
+		profile.ObjectClasses = []string{"top", "group"}
+		profile.Attributes = map[string]string{
+			"cn":             "{{ .GroupName }}",
+			"sAMAccountName": "{{ .GroupName }}",
+			"description":    "{{ .Description }}",
+		}
+		profile.MemberAttribute = "member"
+		profile.MemberIsDN = true
+		profile.UserNameAttribute = "sAMAccountName"
+	case LdapSchemaGroupOfNames:
+		profile.ObjectClasses = []string{"top", "groupOfNames"}
+		profile.Attributes = map[string]string{
+			"cn":          "{{ .GroupName }}",
+			"description": "{{ .Description }}",
+			"member":      "{{ .PlaceholderMember }}",
+		}
+		profile.MemberAttribute = "member"
+		profile.MemberIsDN = true
+		profile.UserNameAttribute = "uid"
+		profile.PlaceholderMember = bindDN
+		if spec != nil && spec.PlaceholderMember != "" {
+			profile.PlaceholderMember = spec.PlaceholderMember
+		}
+		if profile.PlaceholderMember == "" {
+			return nil, fmt.Errorf("ldapSchema profile GroupOfNames requires placeholderMember when the bind identity is not a DN")
+		}
+		if _, err := ldap.ParseDN(profile.PlaceholderMember); err != nil {
+			return nil, fmt.Errorf("ldapSchema placeholderMember %q is not a valid DN: %w", profile.PlaceholderMember, err)
+		}
+	case LdapSchemaPosixGroup:
+		profile.ObjectClasses = []string{"top", "posixGroup"}
+		profile.Attributes = map[string]string{
+			"cn":          "{{ .GroupName }}",
+			"description": "{{ .Description }}",
+			"gidNumber":   "{{ .GidNumber }}",
+		}
+		profile.MemberAttribute = "memberUid"
+		profile.UserNameAttribute = "uid"
+		profile.AllocateGidNumber = true
+	case LdapSchemaCustom:
+		if len(spec.ObjectClasses) == 0 || len(spec.Attributes) == 0 {
+			return nil, fmt.Errorf("ldapSchema profile Custom requires objectClasses and attributes")
+		}
+		profile.ObjectClasses = spec.ObjectClasses
+		profile.Attributes = spec.Attributes
+		profile.MemberAttribute = "member"
+		profile.MemberIsDN = true
+		profile.UserNameAttribute = "uid"
+		for _, value := range spec.Attributes {
+			if strings.Contains(value, ".GidNumber") {
+				profile.AllocateGidNumber = true
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown ldapSchema profile %q", name)
+	}
+
+	if spec != nil {
+		if spec.MemberAttribute != "" {
+			profile.MemberAttribute = spec.MemberAttribute
+			profile.MemberIsDN = !strings.EqualFold(spec.MemberAttribute, "memberUid")
+		}
+		if spec.UserNameAttribute != "" {
+			profile.UserNameAttribute = spec.UserNameAttribute
+		}
+		if spec.GidNumberMin != nil {
+			profile.GidNumberMin = *spec.GidNumberMin
+		}
+		if spec.GidNumberMax != nil {
+			profile.GidNumberMax = *spec.GidNumberMax
+		}
+		profile.GidSearchBaseDN = spec.GidSearchBaseDN
+	}
+	if profile.AllocateGidNumber && profile.GidNumberMin > profile.GidNumberMax {
+		return nil, fmt.Errorf("ldapSchema gidNumberMin (%d) is greater than gidNumberMax (%d)", profile.GidNumberMin, profile.GidNumberMax)
+	}
+
+	// Fail early on broken templates instead of on the first group creation
+	for attr, value := range profile.Attributes {
+		if _, err := template.New(attr).Option("missingkey=error").Parse(value); err != nil {
+			return nil, fmt.Errorf("invalid ldapSchema attribute template %q: %w", attr, err)
+		}
+	}
+
+	return profile, nil
+}
+
+// NewGroupAddRequest builds the LDAP Add request for a new group
+func (p *LdapSchemaProfile) NewGroupAddRequest(dn string, data LdapGroupTemplateData) (*ldap.AddRequest, error) {
+	addRequest := ldap.NewAddRequest(dn, nil)
+	addRequest.Attribute("objectClass", p.ObjectClasses)
+
+	// Sorted for deterministic requests
+	attrs := make([]string, 0, len(p.Attributes))
+	for attr := range p.Attributes {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	for _, attr := range attrs {
+		tmpl, err := template.New(attr).Option("missingkey=error").Parse(p.Attributes[attr])
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute template %q: %w", attr, err)
+		}
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			return nil, fmt.Errorf("failed to render attribute template %q: %w", attr, err)
+		}
+		var values []string
+		for _, line := range strings.Split(rendered.String(), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				values = append(values, line)
+			}
+		}
+		if len(values) > 0 {
+			addRequest.Attribute(attr, values)
+		}
+	}
+	return addRequest, nil
+}
+
+// AllocateGidNumberFor returns the next free gidNumber in the profile range: one
+// above the highest gidNumber in use below GidSearchBaseDN, or baseDN when it is
+// not set (or GidNumberMin). The search is paged so that directories with more
+// entries than the server size limit are scanned completely.
+func (p *LdapSchemaProfile) AllocateGidNumberFor(conn ldap.Client, baseDN string) (int64, error) {
+	if p.GidSearchBaseDN != "" {
+		baseDN = p.GidSearchBaseDN
+	}
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0, 0, false,
+		"(gidNumber=*)",
+		[]string{"gidNumber"},
+		nil,
+	)
+	sr, err := conn.SearchWithPaging(searchRequest, ldapGidSearchPageSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search gidNumbers below %s: %w", baseDN, err)
+	}
+
+	next := p.GidNumberMin
+	for _, entry := range sr.Entries {
+		gid, err := strconv.ParseInt(entry.GetAttributeValue("gidNumber"), 10, 64)
+		if err != nil || gid < p.GidNumberMin || gid > p.GidNumberMax {
+			continue
+		}
+		if gid >= next {
+			next = gid + 1
+		}
+	}
+	if next > p.GidNumberMax {
+		return 0, fmt.Errorf("gidNumber range %d-%d is exhausted", p.GidNumberMin, p.GidNumberMax)
+	}
+	return next, nil
+}
@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+// addRequestAttributes flattens an AddRequest into attribute -> values
+func addRequestAttributes(req *ldap.AddRequest) map[string][]string {
+	attrs := make(map[string][]string)
+	for _, attr := range req.Attributes {
+		attrs[attr.Type] = attr.Vals
+	}
+	return attrs
+}
+
+func TestLdapSchemaProfile_NewGroupAddRequest(t *testing.T) {
+	const bindDN = "cn=admin,dc=example,dc=com"
+	data := LdapGroupTemplateData{GroupName: "G1", Description: "desc", ClusterName: "prod", BindDN: bindDN,
+		PlaceholderMember: bindDN, GidNumber: 10005}
+
+	tests := []struct {
+		name string
+		spec *permissionv1.LdapSchemaSpec
+		want map[string][]string
+	}{
+		{
+			name: "default is ActiveDirectory",
+			spec: nil,
+			want: map[string][]string{
+				"objectClass":    {"top", "group"},
+				"cn":             {"G1"},
+				"sAMAccountName": {"G1"},
+				"description":    {"desc"},
+			},
+		},
+		{
+			name: "groupOfNames keeps the bind DN as mandatory member",
+			spec: &permissionv1.LdapSchemaSpec{Profile: LdapSchemaGroupOfNames},
+			want: map[string][]string{
+				"objectClass": {"top", "groupOfNames"},
+				"cn":          {"G1"},
+				"description": {"desc"},
+				"member":      {bindDN},
+			},
+		},
+		{
+			name: "posixGroup gets a gidNumber",
+			spec: &permissionv1.LdapSchemaSpec{Profile: LdapSchemaPosixGroup},
+			want: map[string][]string{
+				"objectClass": {"top", "posixGroup"},
+				"cn":          {"G1"},
+				"description": {"desc"},
+				"gidNumber":   {"10005"},
+			},
+		},
+		{
+			name: "custom template with multi-valued attribute",
+			spec: &permissionv1.LdapSchemaSpec{
+				Profile:       LdapSchemaCustom,
+				ObjectClasses: []string{"top", "groupOfUniqueNames"},
+				Attributes: map[string]string{
+					"cn":               "{{ .GroupName }}",
+					"uniqueMember":     "{{ .BindDN }}\ncn=auditor,dc=example,dc=com",
+					"businessCategory": "k8s-{{ .ClusterName }}",
+				},
+			},
+			want: map[string][]string{
+				"objectClass":      {"top", "groupOfUniqueNames"},
+				"cn":               {"G1"},
+				"uniqueMember":     {bindDN, "cn=auditor,dc=example,dc=com"},
+				"businessCategory": {"k8s-prod"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := ResolveLdapSchemaProfile(tt.spec, bindDN)
+			if err != nil {
+				t.Fatalf("ResolveLdapSchemaProfile() error: %v", err)
+			}
+			req, err := profile.NewGroupAddRequest("CN=G1,OU=K8S,DC=example,DC=com", data)
+			if err != nil {
+				t.Fatalf("NewGroupAddRequest() error: %v", err)
+			}
+			if got := addRequestAttributes(req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attributes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLdapSchemaProfile_Invalid(t *testing.T) {
+	gidMin, gidMax := int64(500), int64(100)
+	tests := []struct {
+		name string
+		spec *permissionv1.LdapSchemaSpec
+	}{
+		{"custom without attributes", &permissionv1.LdapSchemaSpec{Profile: LdapSchemaCustom, ObjectClasses: []string{"top"}}},
+		{"broken template", &permissionv1.LdapSchemaSpec{Profile: LdapSchemaCustom, ObjectClasses: []string{"top"}, Attributes: map[string]string{"cn": "{{ .GroupName"}}},
+		{"inverted gid range", &permissionv1.LdapSchemaSpec{Profile: LdapSchemaPosixGroup, GidNumberMin: &gidMin, GidNumberMax: &gidMax}},
+		{"groupOfNames without placeholder member", &permissionv1.LdapSchemaSpec{Profile: LdapSchemaGroupOfNames}},
+		{"groupOfNames with invalid placeholder member", &permissionv1.LdapSchemaSpec{Profile: LdapSchemaGroupOfNames, PlaceholderMember: "nobody"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ResolveLdapSchemaProfile(tt.spec, ""); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestResolveLdapSchemaProfile_PlaceholderMember(t *testing.T) {
+	const placeholder = "cn=placeholder,ou=system,dc=example,dc=com"
+
+	// Without a bind DN (sasl_external, gssapi) the configured placeholder is used
+	profile, err := ResolveLdapSchemaProfile(&permissionv1.LdapSchemaSpec{
+		Profile: LdapSchemaGroupOfNames, PlaceholderMember: placeholder}, "")
+	if err != nil {
+		t.Fatalf("ResolveLdapSchemaProfile() error: %v", err)
+	}
+	if profile.PlaceholderMember != placeholder {
+		t.Errorf("PlaceholderMember = %q, want %q", profile.PlaceholderMember, placeholder)
+	}
+
+	// It also takes precedence over the bind DN of a simple bind
+	profile, err = ResolveLdapSchemaProfile(&permissionv1.LdapSchemaSpec{
+		Profile: LdapSchemaGroupOfNames, PlaceholderMember: placeholder}, "cn=admin,dc=example,dc=com")
+	if err != nil {
+		t.Fatalf("ResolveLdapSchemaProfile() error: %v", err)
+	}
+	req, err := profile.NewGroupAddRequest("cn=G1,dc=example,dc=com",
+		LdapGroupTemplateData{GroupName: "G1", PlaceholderMember: profile.PlaceholderMember})
+	if err != nil {
+		t.Fatalf("NewGroupAddRequest() error: %v", err)
+	}
+	if got := addRequestAttributes(req)["member"]; !reflect.DeepEqual(got, []string{placeholder}) {
+		t.Errorf("member = %v, want [%s]", got, placeholder)
+	}
+}
+
+func TestCreateLdapGroup_PosixGroupAllocatesGidNumber(t *testing.T) {
+	conn := newFakeLdapClient()
+	conn.addEntry("CN=existing,OU=K8S,DC=example,DC=com", map[string][]string{"gidNumber": {"10041"}})
+	conn.addEntry("CN=system,OU=K8S,DC=example,DC=com", map[string][]string{"gidNumber": {"100"}})
+
+	profile, err := ResolveLdapSchemaProfile(&permissionv1.LdapSchemaSpec{Profile: LdapSchemaPosixGroup}, "")
+	if err != nil {
+		t.Fatalf("ResolveLdapSchemaProfile() error: %v", err)
+	}
+	groupDN := "CN=G1,OU=K8S,DC=example,DC=com"
+	if _, err := CreateLdapGroup(context.Background(), conn, &LdapGroupInfo{GroupName: "G1", FullDN: groupDN}, "prod", profile); err != nil {
+		t.Fatalf("CreateLdapGroup() error: %v", err)
+	}
+	if got := conn.entries[strings.ToLower(groupDN)]["gidNumber"]; !reflect.DeepEqual(got, []string{"10042"}) {
+		t.Errorf("gidNumber = %v, want [10042]", got)
+	}
+	if !reflect.DeepEqual(conn.pagingSizes, []uint32{ldapGidSearchPageSize}) {
+		t.Errorf("gidNumber search paging sizes = %v, want a paged search", conn.pagingSizes)
+	}
+}
+
+func TestAllocateGidNumberFor_GidSearchBaseDN(t *testing.T) {
+	conn := newFakeLdapClient()
+	conn.addEntry("CN=G1,OU=K8S,DC=example,DC=com", map[string][]string{"gidNumber": {"10007"}})
+	conn.addEntry("CN=legacy,OU=Groups,DC=corp,DC=example", map[string][]string{"gidNumber": {"10500"}})
+
+	profile, err := ResolveLdapSchemaProfile(&permissionv1.LdapSchemaSpec{
+		Profile: LdapSchemaPosixGroup, GidSearchBaseDN: "DC=corp,DC=example"}, "")
+	if err != nil {
+		t.Fatalf("ResolveLdapSchemaProfile() error: %v", err)
+	}
+	gid, err := profile.AllocateGidNumberFor(conn, "DC=example,DC=com")
+	if err != nil {
+		t.Fatalf("AllocateGidNumberFor() error: %v", err)
+	}
+	if gid != 10501 {
+		t.Errorf("gidNumber = %d, want 10501 (above the highest in the configured search base)", gid)
+	}
+}
+
+func TestReconcileLdapGroupMembers_PosixGroup(t *testing.T) {
+	const groupDN = "CN=G1,OU=K8S,DC=example,DC=com"
+	conn := newFakeLdapClient()
+	conn.addEntry(groupDN, map[string][]string{"memberUid": {"alice", "carol"}})
+	conn.addEntry("uid=bob,OU=Users,DC=example,DC=com", map[string][]string{"uid": {"bob"}})
+
+	profile, _ := ResolveLdapSchemaProfile(&permissionv1.LdapSchemaSpec{Profile: LdapSchemaPosixGroup}, "")
+	budget := 10
+	result, err := ReconcileLdapGroupMembers(context.Background(), conn, profile,
+		&LdapGroupInfo{GroupName: "G1", FullDN: groupDN},
+		[]string{"ALICE", "uid=bob,OU=Users,DC=example,DC=com"}, &budget, "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Added != 1 || result.Removed != 1 {
+		t.Errorf("result = %+v, want 1 added (bob), 1 removed (carol)", result)
+	}
+	if got := conn.entries[strings.ToLower(groupDN)]["memberUid"]; !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+		t.Errorf("memberUid = %v, want [alice bob]", got)
+	}
+}
+
+func TestReconcileLdapGroupMembers_GroupOfNamesKeepsPlaceholder(t *testing.T) {
+	const groupDN = "cn=G1,ou=K8S,dc=example,dc=com"
+	const bindDN = "cn=admin,dc=example,dc=com"
+	conn := newFakeLdapClient()
+	conn.addEntry(groupDN, map[string][]string{"member": {bindDN, "cn=carol,dc=example,dc=com"}})
+
+	profile, _ := ResolveLdapSchemaProfile(&permissionv1.LdapSchemaSpec{Profile: LdapSchemaGroupOfNames}, bindDN)
+	budget := 10
+	if _, err := ReconcileLdapGroupMembers(context.Background(), conn, profile,
+		&LdapGroupInfo{GroupName: "G1", FullDN: groupDN}, []string{}, &budget, "prod"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := conn.entries[strings.ToLower(groupDN)]["member"]; !reflect.DeepEqual(got, []string{bindDN}) {
+		t.Errorf("member = %v, want only the placeholder %s", got, bindDN)
+	}
+}
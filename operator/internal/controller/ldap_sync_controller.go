@@ -0,0 +1,481 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// LdapSyncedCondition reports the directory work of the LDAP controller
+	LdapSyncedCondition = "LdapSynced"
+
+	// maxReportedLdapGroupSyncStatuses bounds status.ldapSync.groups
+	maxReportedLdapGroupSyncStatuses = 1000
+
+	// Backoff of failed directory syncs, independent of the RBAC reconciliation
+	ldapSyncBaseBackoff = 5 * time.Second
+	ldapSyncMaxBackoff  = 10 * time.Minute
+)
+
+// LdapSyncReconciler runs the LDAP directory work of PermissionBinders (group
+// verification, creation, lifecycle and members) in its own controller and
+// work queue, so that a slow or unreachable directory never delays RBAC
+// reconciliation. It shares configuration and helpers with the RBAC reconciler.
+type LdapSyncReconciler struct {
+	*PermissionBinderReconciler
+}
+
+// ldapSyncEnabled reports whether a PermissionBinder needs directory work
+func ldapSyncEnabled(pb *permissionv1.PermissionBinder) bool {
+	return pb.Spec.CreateLdapGroups ||
+		ldapGroupVerificationEnabled(pb) ||
+		(pb.Spec.LdapGroupMembers != nil && pb.Spec.LdapGroupMembers.Enabled)
+}
+
+// ldapMissingGroups returns the lower-cased whitelist DNs the LDAP controller
+// found missing, nil when ldapGroupVerification is disabled
+func ldapMissingGroups(pb *permissionv1.PermissionBinder) map[string]bool {
+	if !ldapGroupVerificationEnabled(pb) || pb.Status.LdapSync == nil {
+		return nil
+	}
+	missing := make(map[string]bool)
+	for _, group := range pb.Status.LdapSync.Groups {
+		if group.State == LdapGroupMissing {
+			missing[strings.ToLower(group.DN)] = true
+		}
+	}
+	return missing
+}
+
+// ldapSkipMissingGroupsHash returns the missing groups hash the RoleBindings depend
+// on: only missingGroupPolicy Skip changes which entries are bound
+func ldapSkipMissingGroupsHash(pb *permissionv1.PermissionBinder) string {
+	if !ldapGroupVerificationEnabled(pb) || ldapMissingGroupPolicy(pb) != LdapMissingGroupSkip || pb.Status.LdapSync == nil {
+		return ""
+	}
+	return pb.Status.LdapSync.MissingGroupsHash
+}
+
+// hashLdapMissingGroups returns a stable hash of the missing groups, "" when none is missing
+func hashLdapMissingGroups(groups []permissionv1.LdapGroupSyncStatus) string {
+	var missing []string
+	for _, group := range groups {
+		if group.State == LdapGroupMissing {
+			missing = append(missing, strings.ToLower(group.DN))
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	sort.Strings(missing)
+	sum := sha256.Sum256([]byte(strings.Join(missing, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ldapGroupStateOrder lists groups needing attention first in status.ldapSync.groups
+var ldapGroupStateOrder = map[string]int{
+	LdapGroupError:      0,
+	LdapGroupMissing:    1,
+	LdapGroupUnverified: 2,
+	LdapGroupCreated:    3,
+	LdapGroupPresent:    4,
+}
+
+// boundedLdapGroupSyncStatuses sorts the group states (missing and failed first)
+// and bounds them to maxReportedLdapGroupSyncStatuses
+func boundedLdapGroupSyncStatuses(groups []permissionv1.LdapGroupSyncStatus) []permissionv1.LdapGroupSyncStatus {
+	sorted := append([]permissionv1.LdapGroupSyncStatus(nil), groups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ldapGroupStateOrder[sorted[i].State] != ldapGroupStateOrder[sorted[j].State] {
+			return ldapGroupStateOrder[sorted[i].State] < ldapGroupStateOrder[sorted[j].State]
+		}
+		return sorted[i].DN < sorted[j].DN
+	})
+	if len(sorted) > maxReportedLdapGroupSyncStatuses {
+		sorted = sorted[:maxReportedLdapGroupSyncStatuses]
+	}
+	return sorted
+}
+
+// ldapSyncUpToDate reports whether the last sync covered the current spec,
+// whitelist and members, with nothing due in between for the tracked groups
+func ldapSyncUpToDate(pb *permissionv1.PermissionBinder, groups []permissionv1.LdapGroupStatus, whitelistVersion, membersVersion string, now time.Time) bool {
+	sync := pb.Status.LdapSync
+	return sync != nil &&
+		sync.ObservedGeneration == pb.Generation &&
+		sync.WhitelistVersion == whitelistVersion &&
+		pb.Status.LastProcessedLdapMembersVersion == membersVersion &&
+		pb.Status.PendingLdapMemberRemovals == 0 &&
+		!ldapGroupDeletionDue(pb, groups, now) &&
+		!ldapGroupVerificationDue(pb, now)
+}
+
+// nextLdapSync returns how long until directory work is due again, 0 if nothing is scheduled
+func nextLdapSync(pb *permissionv1.PermissionBinder, groups []permissionv1.LdapGroupStatus, whitelistSource *permissionv1.WhitelistSourceStatus, now time.Time) time.Duration {
+	requeueAfter := minRequeueAfter(
+		nextLdapGroupDeletion(pb, groups, now),
+		nextLdapGroupVerification(pb, pb.Status.LdapGroupVerification, now),
+		nextLdapWhitelistSearch(pb, whitelistSource, now))
+	if pb.Status.PendingLdapMemberRemovals > 0 {
+		// Continue deferred LDAP member removals in the next batch
+		requeueAfter = minRequeueAfter(requeueAfter, time.Minute)
+	}
+	return requeueAfter
+}
+
+// Reconcile syncs the directory with the valid whitelist entries of a PermissionBinder
+// and reports the result in status.ldapSync and the LdapSynced condition. Failed
+// syncs are retried with this controller's own backoff.
+func (r *LdapSyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if !r.reconcilesNamespace(req.Namespace) {
+		return ctrl.Result{}, nil
+	}
+
+	var pb permissionv1.PermissionBinder
+	if err := r.Get(ctx, req.NamespacedName, &pb); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// The RBAC reconciler adds the finalizer first and handles deletion
+	if !pb.DeletionTimestamp.IsZero() || !containsString(pb.Finalizers, PermissionBinderFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if !ldapSyncEnabled(&pb) {
+		return ctrl.Result{}, r.clearLdapSyncStatus(ctx, &pb)
+	}
+
+	// No directory changes while frozen or paused; lifting the freeze enqueues again
+	if freeze, err := r.freezeFor(ctx, &pb); err != nil {
+		return ctrl.Result{}, err
+	} else if freeze != nil {
+		logger.Info("LDAP sync frozen", "reason", freeze.Message)
+		return ctrl.Result{RequeueAfter: frozenRequeueInterval}, nil
+	}
+
+	// Directory work uses the same cluster name as the RBAC reconciliation; it is
+	// not part of the status patch (the RBAC reconciler reports it)
+	pb.Status.ClusterIdentity = r.ResolveClusterIdentity(ctx, &pb)
+	base := pb.DeepCopy()
+	now := time.Now()
+
+	whitelist, whitelistSource, err := r.getLdapSyncWhitelist(ctx, &pb, now)
+	if err != nil {
+		logger.Error(err, "LDAP sync skipped - whitelist unavailable")
+		setLdapSyncedCondition(&pb, err)
+		if patchErr := r.patchLdapSyncStatus(ctx, &pb, base); patchErr != nil {
+			return ctrl.Result{}, patchErr
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	whitelistContent, found := whitelist.Data["whitelist.txt"]
+	if !found {
+		logger.V(1).Info("No whitelist.txt found, skipping LDAP sync")
+		return ctrl.Result{}, nil
+	}
+
+	ldapMembersConfigMap, err := r.getLdapMembersConfigMap(ctx, &pb)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	ldapMembersVersion := ""
+	if ldapMembersConfigMap != nil {
+		ldapMembersVersion = ldapMembersConfigMap.ResourceVersion
+	}
+
+	ldapGroups, err := r.trackedLdapGroups(ctx, &pb)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if ldapSyncUpToDate(&pb, ldapGroups, whitelist.ResourceVersion, ldapMembersVersion, now) {
+		return ctrl.Result{RequeueAfter: nextLdapSync(&pb, ldapGroups, whitelistSource, now)}, nil
+	}
+
+	var validEntries []string
+	whitelistNamespaces := make(map[string]string)
+	for _, entry := range r.parseWhitelist(&pb, whitelistContent) {
+		if entry.Skip == whitelistSkipInvalidDN {
+			continue
+		}
+		if _, found := whitelistNamespaces[strings.ToLower(entry.DN)]; !found {
+			whitelistNamespaces[strings.ToLower(entry.DN)] = entry.Namespace
+		}
+		if entry.Skip == "" {
+			validEntries = append(validEntries, entry.DN)
+		}
+	}
+
+	logger.Info("🔐 Syncing LDAP directory",
+		"validEntries", len(validEntries),
+		"whitelistVersion", whitelist.ResourceVersion)
+	ldapGroups, syncErr := r.syncLdap(ctx, &pb, whitelist, ldapMembersConfigMap, ldapGroups, validEntries, whitelistNamespaces, now)
+	pb.Status.LastProcessedLdapMembersVersion = ldapMembersVersion
+	setLdapSyncedCondition(&pb, syncErr)
+
+	if err := r.patchLdapSyncStatus(ctx, &pb, base); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Let the RBAC reconciliation bind or skip entries whose group appeared or vanished
+	if ldapSkipMissingGroupsHash(&pb) != pb.Status.LastProcessedLdapMissingGroupsHash {
+		r.notifyLdapSynced(ctx, &pb)
+	}
+
+	if syncErr != nil {
+		// Retried with this controller's backoff; RBAC reconciliation is not affected
+		return ctrl.Result{}, syncErr
+	}
+	return ctrl.Result{RequeueAfter: nextLdapSync(&pb, ldapGroups, whitelistSource, now)}, nil
+}
+
+// getLdapSyncWhitelist returns the whitelist ConfigMap (or the LDAP search result),
+// or the whitelist snapshot the RBAC reconciliation applies instead
+func (r *LdapSyncReconciler) getLdapSyncWhitelist(ctx context.Context, pb *permissionv1.PermissionBinder, now time.Time) (*corev1.ConfigMap, *permissionv1.WhitelistSourceStatus, error) {
+	rolledBack, err := r.rolledBackWhitelist(ctx, pb)
+	if err != nil || rolledBack != nil {
+		return rolledBack, pb.Status.WhitelistSource, err
+	}
+	if ldapWhitelistSearchEnabled(pb) {
+		return r.getLdapWhitelistConfigMap(ctx, pb, now)
+	}
+	var configMap corev1.ConfigMap
+	key := types.NamespacedName{Name: pb.Spec.ConfigMapName, Namespace: pb.Spec.ConfigMapNamespace}
+	if err := r.Get(ctx, key, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			if fallback, fallbackErr := r.fallbackWhitelist(ctx, pb); fallbackErr == nil && fallback != nil {
+				return fallback, nil, nil
+			}
+		}
+		return nil, nil, fmt.Errorf("failed to get ConfigMap %s: %w", key, err)
+	}
+	return &configMap, nil, nil
+}
+
+// syncLdap verifies, creates and retires the groups of the valid whitelist entries
+// and reconciles their members, recording the results in pb.Status. The tracked
+// operator-created groups (ldapGroups) are stored in the PermissionBinderReports;
+// it returns them updated. whitelistNamespaces maps the lowercased DNs of the
+// whitelist entries to their target namespace.
+func (r *LdapSyncReconciler) syncLdap(
+	ctx context.Context,
+	pb *permissionv1.PermissionBinder,
+	whitelist *corev1.ConfigMap,
+	ldapMembersConfigMap *corev1.ConfigMap,
+	ldapGroups []permissionv1.LdapGroupStatus,
+	validEntries []string,
+	whitelistNamespaces map[string]string,
+	now time.Time,
+) ([]permissionv1.LdapGroupStatus, error) {
+	logger := log.FromContext(ctx)
+	var errs []error
+	var groups []permissionv1.LdapGroupSyncStatus
+	var ownedLdapGroups []string
+
+	// Look up whitelist groups in LDAP (ldapGroupVerification) - with policy
+	// Create the verifier also creates the missing groups
+	verifier := r.newLdapGroupVerifier(ctx, pb)
+	if verifier != nil {
+		defer verifier.Close()
+		for _, dn := range validEntries {
+			group := permissionv1.LdapGroupSyncStatus{DN: dn, State: verifier.Verify(ctx, dn)}
+			switch group.State {
+			case "":
+				group.State = LdapGroupUnverified
+				if verifier.err != nil {
+					group.Message = verifier.err.Error()
+				}
+			case LdapGroupMissing:
+				group.Message = "group does not exist in LDAP"
+			case LdapGroupCreated:
+				r.recordEvent(pb, nil, corev1.EventTypeNormal, EventReasonLdapGroupCreated, "Created LDAP group %s", dn)
+			}
+			groups = append(groups, group)
+		}
+		pb.Status.LdapGroupVerification = verifier.Status(now)
+		if verifier.err != nil {
+			errs = append(errs, verifier.err)
+		}
+		ownedLdapGroups = verifier.owned
+	} else {
+		pb.Status.LdapGroupVerification = nil
+	}
+
+	if ldapGroupCreationEnabled(pb) {
+		if verifier == nil && len(validEntries) > 0 {
+			owned, created, err := r.ProcessLdapGroupCreation(ctx, pb, validEntries, whitelist.ResourceVersion)
+			if err != nil {
+				logger.Error(err, "⚠️  LDAP group creation failed")
+				errs = append(errs, err)
+			}
+			ownedLdapGroups, groups = owned, created
+		}
+
+		// Track operator-created groups and retire those that left the whitelist
+		ldapGroups = r.ProcessLdapGroupLifecycle(ctx, pb, ldapGroups, whitelistNamespaces, ownedLdapGroups, now)
+		if reports, err := r.storeLdapGroups(ctx, pb, ldapGroups); err != nil {
+			// The status written by earlier versions is kept until the reports track the groups
+			logger.Error(err, "⚠️  Failed to store the LDAP groups in PermissionBinderReports")
+			errs = append(errs, err)
+		} else {
+			pb.Status.Reports = reports
+			pb.Status.LdapGroups = nil
+		}
+	}
+
+	pb.Status.PendingLdapMemberRemovals = 0
+	if pb.Spec.LdapGroupMembers != nil && pb.Spec.LdapGroupMembers.Enabled && len(validEntries) > 0 {
+		membersData := whitelist.Data
+		if ldapMembersConfigMap != nil {
+			membersData = ldapMembersConfigMap.Data
+		}
+		membership, err := r.ProcessLdapGroupMembers(ctx, pb, validEntries, membersData)
+		if err != nil {
+			logger.Error(err, "⚠️  LDAP group membership management failed")
+			errs = append(errs, err)
+		}
+		pb.Status.PendingLdapMemberRemovals = membership.Deferred
+	}
+
+	sync := &permissionv1.LdapSyncStatus{
+		ObservedGeneration: pb.Generation,
+		LastSyncTime:       &metav1.Time{Time: now},
+		MissingGroupsHash:  hashLdapMissingGroups(groups),
+		TotalGroups:        len(groups),
+		Groups:             boundedLdapGroupSyncStatuses(groups),
+	}
+	syncErr := errors.Join(errs...)
+	if syncErr == nil {
+		sync.WhitelistVersion = whitelist.ResourceVersion
+	} else if pb.Status.LdapSync != nil {
+		// Keep the last successfully synced version so the sync is retried
+		sync.WhitelistVersion = pb.Status.LdapSync.WhitelistVersion
+	}
+	pb.Status.LdapSync = sync
+	return ldapGroups, syncErr
+}
+
+// setLdapSyncedCondition sets the LdapSynced condition from the sync result
+func setLdapSyncedCondition(pb *permissionv1.PermissionBinder, syncErr error) {
+	condition := metav1.Condition{
+		Type:               LdapSyncedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Synced",
+		ObservedGeneration: pb.Generation,
+	}
+	if pb.Status.LdapSync != nil {
+		condition.Message = fmt.Sprintf("%d LDAP groups synced", pb.Status.LdapSync.TotalGroups)
+	}
+	if syncErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SyncFailed"
+		condition.Message = syncErr.Error()
+	}
+	meta.SetStatusCondition(&pb.Status.Conditions, condition)
+	setSummaryConditions(pb)
+}
+
+// clearLdapSyncStatus removes the LDAP sync results once no LDAP feature is enabled
+func (r *LdapSyncReconciler) clearLdapSyncStatus(ctx context.Context, pb *permissionv1.PermissionBinder) error {
+	if pb.Status.LdapSync == nil && pb.Status.LdapGroupVerification == nil &&
+		meta.FindStatusCondition(pb.Status.Conditions, LdapSyncedCondition) == nil {
+		return nil
+	}
+	base := pb.DeepCopy()
+	pb.Status.LdapSync = nil
+	pb.Status.LdapGroupVerification = nil
+	pb.Status.PendingLdapMemberRemovals = 0
+	meta.RemoveStatusCondition(&pb.Status.Conditions, LdapSyncedCondition)
+	setSummaryConditions(pb)
+	if err := r.patchLdapSyncStatus(ctx, pb, base); err != nil {
+		return err
+	}
+	if ldapSkipMissingGroupsHash(pb) != pb.Status.LastProcessedLdapMissingGroupsHash {
+		r.notifyLdapSynced(ctx, pb)
+	}
+	return nil
+}
+
+// patchLdapSyncStatus writes the LDAP-owned status fields. The optimistic lock
+// turns a concurrent RBAC status update into a conflict that is retried.
+func (r *LdapSyncReconciler) patchLdapSyncStatus(ctx context.Context, pb, base *permissionv1.PermissionBinder) error {
+	pb.Status.ClusterIdentity = base.Status.ClusterIdentity
+	patch := client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})
+	if err := r.Status().Patch(ctx, pb, patch); err != nil {
+		return fmt.Errorf("failed to update LDAP sync status: %w", err)
+	}
+	return nil
+}
+
+// notifyLdapSynced enqueues the PermissionBinder in the RBAC controller
+func (r *LdapSyncReconciler) notifyLdapSynced(ctx context.Context, pb *permissionv1.PermissionBinder) {
+	if r.ldapSyncEvents == nil {
+		return
+	}
+	select {
+	case r.ldapSyncEvents <- event.GenericEvent{Object: pb.DeepCopy()}:
+	case <-ctx.Done():
+	}
+}
+
+// SetupWithManager registers the LDAP controller. It watches the same objects
+// as the RBAC controller but has its own work queue and failure backoff.
+func (r *LdapSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("permissionbinder-ldap").
+		For(&permissionv1.PermissionBinder{}, builder.WithPredicates(r.permissionBinderPredicate())).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				configMap, ok := obj.(*corev1.ConfigMap)
+				if !ok {
+					return []reconcile.Request{}
+				}
+				return r.mapConfigMapToPermissionBinder(ctx, configMap)
+			}),
+			builder.WithPredicates(r.configMapPredicate(mgr)),
+		).
+		WithOptions(controller.Options{
+			RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](ldapSyncBaseBackoff, ldapSyncMaxBackoff),
+		}).
+		Complete(r)
+}
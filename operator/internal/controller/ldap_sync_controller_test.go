@@ -0,0 +1,258 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestParseWhitelist(t *testing.T) {
+	r := &PermissionBinderReconciler{}
+	pb := &permissionv1.PermissionBinder{
+		Spec: permissionv1.PermissionBinderSpec{
+			Prefixes:    []string{"COMPANY-K8S"},
+			RoleMapping: map[string]string{"admin": "admin"},
+			ExcludeList: []string{"COMPANY-K8S-legacy-admin"},
+		},
+	}
+	content := `# comment
+
+CN=COMPANY-K8S-app-admin,OU=K8S,DC=example,DC=com
+not-a-dn
+CN=COMPANY-K8S-legacy-admin,OU=K8S,DC=example,DC=com
+CN=COMPANY-K8S-app-unknown,OU=K8S,DC=example,DC=com`
+
+	entries := r.parseWhitelist(pb, content)
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4 (empty lines and comments skipped)", len(entries))
+	}
+	want := []struct {
+		line int
+		skip string
+	}{
+		{3, ""},
+		{4, whitelistSkipInvalidDN},
+		{5, whitelistSkipExcluded},
+		{6, whitelistSkipInvalidPermission},
+	}
+	for i, w := range want {
+		if entries[i].Line != w.line || entries[i].Skip != w.skip {
+			t.Errorf("entry %d = line %d skip %q, want line %d skip %q", i, entries[i].Line, entries[i].Skip, w.line, w.skip)
+		}
+	}
+	if entries[0].Namespace != "app" || entries[0].Role != "admin" || entries[0].Prefix != "COMPANY-K8S" {
+		t.Errorf("valid entry = %+v, want namespace app, role admin", entries[0])
+	}
+}
+
+func TestLdapMissingGroups(t *testing.T) {
+	pb := &permissionv1.PermissionBinder{}
+	pb.Status.LdapSync = &permissionv1.LdapSyncStatus{
+		Groups: []permissionv1.LdapGroupSyncStatus{
+			{DN: "CN=App-Admin,DC=example,DC=com", State: LdapGroupMissing},
+			{DN: "CN=App-View,DC=example,DC=com", State: LdapGroupPresent},
+		},
+	}
+	pb.Status.LdapSync.MissingGroupsHash = hashLdapMissingGroups(pb.Status.LdapSync.Groups)
+
+	if got := ldapMissingGroups(pb); got != nil {
+		t.Errorf("missing groups without verification = %v, want nil", got)
+	}
+
+	pb.Spec.LdapGroupVerification = &permissionv1.LdapGroupVerificationSpec{Enabled: true, MissingGroupPolicy: LdapMissingGroupWarn}
+	missing := ldapMissingGroups(pb)
+	if len(missing) != 1 || !missing["cn=app-admin,dc=example,dc=com"] {
+		t.Errorf("missing groups = %v, want the lower-cased missing DN", missing)
+	}
+	if got := ldapSkipMissingGroupsHash(pb); got != "" {
+		t.Errorf("hash with policy Warn = %q, want empty (bindings do not depend on it)", got)
+	}
+
+	pb.Spec.LdapGroupVerification.MissingGroupPolicy = LdapMissingGroupSkip
+	if got := ldapSkipMissingGroupsHash(pb); got == "" || got != pb.Status.LdapSync.MissingGroupsHash {
+		t.Errorf("hash with policy Skip = %q, want %q", got, pb.Status.LdapSync.MissingGroupsHash)
+	}
+}
+
+func TestHashLdapMissingGroups(t *testing.T) {
+	a := []permissionv1.LdapGroupSyncStatus{
+		{DN: "CN=A,DC=example,DC=com", State: LdapGroupMissing},
+		{DN: "CN=B,DC=example,DC=com", State: LdapGroupMissing},
+		{DN: "CN=C,DC=example,DC=com", State: LdapGroupPresent},
+	}
+	b := []permissionv1.LdapGroupSyncStatus{
+		{DN: "cn=b,dc=example,dc=com", State: LdapGroupMissing},
+		{DN: "CN=A,DC=example,DC=com", State: LdapGroupMissing},
+	}
+	if hashLdapMissingGroups(a) != hashLdapMissingGroups(b) {
+		t.Error("hash must not depend on order, case or present groups")
+	}
+	if got := hashLdapMissingGroups(a[2:]); got != "" {
+		t.Errorf("hash without missing groups = %q, want empty", got)
+	}
+}
+
+func TestBoundedLdapGroupSyncStatuses(t *testing.T) {
+	var groups []permissionv1.LdapGroupSyncStatus
+	for i := 0; i < maxReportedLdapGroupSyncStatuses+10; i++ {
+		groups = append(groups, permissionv1.LdapGroupSyncStatus{DN: fmt.Sprintf("CN=G%04d,DC=example,DC=com", i), State: LdapGroupPresent})
+	}
+	groups = append(groups,
+		permissionv1.LdapGroupSyncStatus{DN: "CN=Z-Missing,DC=example,DC=com", State: LdapGroupMissing},
+		permissionv1.LdapGroupSyncStatus{DN: "CN=Z-Error,DC=example,DC=com", State: LdapGroupError})
+
+	bounded := boundedLdapGroupSyncStatuses(groups)
+	if len(bounded) != maxReportedLdapGroupSyncStatuses {
+		t.Fatalf("got %d groups, want %d", len(bounded), maxReportedLdapGroupSyncStatuses)
+	}
+	if bounded[0].State != LdapGroupError || bounded[1].State != LdapGroupMissing {
+		t.Errorf("first groups = %+v, want failed and missing groups first", bounded[:2])
+	}
+	if groups[0].DN != "CN=G0000,DC=example,DC=com" {
+		t.Error("input slice was reordered")
+	}
+}
+
+func TestLdapSyncUpToDate(t *testing.T) {
+	now := time.Now()
+	pb := &permissionv1.PermissionBinder{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	if ldapSyncUpToDate(pb, nil, "10", "", now) {
+		t.Error("never synced must not be up to date")
+	}
+	pb.Status.LdapSync = &permissionv1.LdapSyncStatus{ObservedGeneration: 2, WhitelistVersion: "10"}
+	if !ldapSyncUpToDate(pb, nil, "10", "", now) {
+		t.Error("same generation and versions must be up to date")
+	}
+	if ldapSyncUpToDate(pb, nil, "11", "", now) {
+		t.Error("whitelist change must trigger a sync")
+	}
+	if ldapSyncUpToDate(pb, nil, "10", "7", now) {
+		t.Error("members ConfigMap change must trigger a sync")
+	}
+	pb.Generation = 3
+	if ldapSyncUpToDate(pb, nil, "10", "", now) {
+		t.Error("spec change must trigger a sync")
+	}
+	pb.Generation = 2
+	pb.Status.PendingLdapMemberRemovals = 4
+	if ldapSyncUpToDate(pb, nil, "10", "", now) {
+		t.Error("pending member removals must trigger a sync")
+	}
+	if got := nextLdapSync(pb, nil, nil, now); got != time.Minute {
+		t.Errorf("nextLdapSync() with pending removals = %v, want 1m", got)
+	}
+}
+
+func TestLdapSyncReconciler_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	newPermissionBinder := func() *permissionv1.PermissionBinder {
+		return &permissionv1.PermissionBinder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ldap", Namespace: "operators", Generation: 1,
+				Finalizers: []string{PermissionBinderFinalizer},
+			},
+			Spec: permissionv1.PermissionBinderSpec{
+				ConfigMapName:      "permission-config",
+				ConfigMapNamespace: "operators",
+				Prefixes:           []string{"COMPANY-K8S"},
+				RoleMapping:        map[string]string{"admin": "admin"},
+				ClusterName:        "prod",
+			},
+		}
+	}
+	whitelist := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data:       map[string]string{"whitelist.txt": "CN=COMPANY-K8S-app-admin,OU=K8S,DC=example,DC=com"},
+	}
+	key := types.NamespacedName{Name: "ldap", Namespace: "operators"}
+	newReconciler := func(pb *permissionv1.PermissionBinder) *LdapSyncReconciler {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(pb, whitelist.DeepCopy()).
+			WithStatusSubresource(&permissionv1.PermissionBinder{}).
+			Build()
+		return &LdapSyncReconciler{PermissionBinderReconciler: &PermissionBinderReconciler{
+			Client:         k8sClient,
+			Scheme:         scheme,
+			ldapSyncEvents: make(chan event.GenericEvent, 1),
+		}}
+	}
+
+	t.Run("unreachable LDAP fails the sync without advancing the whitelist version", func(t *testing.T) {
+		pb := newPermissionBinder()
+		// No ldapSecretRef - the verifier cannot connect
+		pb.Spec.LdapGroupVerification = &permissionv1.LdapGroupVerificationSpec{Enabled: true}
+		r := newReconciler(pb)
+
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err == nil {
+			t.Fatal("expected an error so the LDAP work queue backs off")
+		}
+
+		var got permissionv1.PermissionBinder
+		if err := r.Get(context.Background(), key, &got); err != nil {
+			t.Fatal(err)
+		}
+		sync := got.Status.LdapSync
+		if sync == nil || sync.WhitelistVersion != "" || sync.TotalGroups != 1 {
+			t.Fatalf("ldapSync = %+v, want one group and no synced version", sync)
+		}
+		if sync.Groups[0].State != LdapGroupUnverified || sync.Groups[0].Message == "" {
+			t.Errorf("group = %+v, want unverified with the error", sync.Groups[0])
+		}
+		condition := meta.FindStatusCondition(got.Status.Conditions, LdapSyncedCondition)
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			t.Errorf("LdapSynced condition = %+v, want False", condition)
+		}
+		if got.Status.ClusterIdentity != nil {
+			t.Error("the cluster identity is reported by the RBAC reconciler only")
+		}
+	})
+
+	t.Run("LDAP status is cleared and RBAC notified once LDAP is disabled", func(t *testing.T) {
+		pb := newPermissionBinder()
+		pb.Status.LdapSync = &permissionv1.LdapSyncStatus{MissingGroupsHash: "abc"}
+		pb.Status.LastProcessedLdapMissingGroupsHash = "abc"
+		pb.Status.Conditions = []metav1.Condition{
+			{Type: "Processed", Status: metav1.ConditionTrue, Reason: "ConfigMapProcessed", LastTransitionTime: metav1.Now()},
+			{Type: LdapSyncedCondition, Status: metav1.ConditionTrue, Reason: "Synced", LastTransitionTime: metav1.Now()},
+		}
+		r := newReconciler(pb)
+
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got permissionv1.PermissionBinder
+		if err := r.Get(context.Background(), key, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Status.LdapSync != nil {
+			t.Errorf("ldapSync = %+v, want cleared", got.Status.LdapSync)
+		}
+		if meta.FindStatusCondition(got.Status.Conditions, LdapSyncedCondition) != nil {
+			t.Error("LdapSynced condition was not removed")
+		}
+		if meta.FindStatusCondition(got.Status.Conditions, "Processed") == nil {
+			t.Error("conditions of the RBAC reconciler must be kept")
+		}
+		select {
+		case <-r.ldapSyncEvents:
+		default:
+			t.Error("RBAC reconciler was not notified of the missing groups change")
+		}
+	})
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// Classification of whitelist groups by ldapGroupVerification (and
+	// status.ldapSync.groups[].state)
+	LdapGroupPresent    = "present"
+	LdapGroupMissing    = "missing"
+	LdapGroupCreated    = "created"
+	LdapGroupUnverified = "unverified"
+	LdapGroupError      = "error"
+
+	// ldapGroupVerification.missingGroupPolicy values
+	LdapMissingGroupSkip   = "Skip"
+	LdapMissingGroupWarn   = "Warn"
+	LdapMissingGroupCreate = "Create"
+
+	defaultLdapVerificationRecheckInterval = 10 * time.Minute
+
+	// maxReportedMissingLdapGroups bounds status.ldapGroupVerification.missingGroups
+	maxReportedMissingLdapGroups = 50
+)
+
+// ldapGroupVerificationEnabled reports whether whitelist groups are looked up in LDAP
+func ldapGroupVerificationEnabled(pb *permissionv1.PermissionBinder) bool {
+	return pb.Spec.LdapGroupVerification != nil && pb.Spec.LdapGroupVerification.Enabled
+}
+
+// ldapMissingGroupPolicy returns the effective missingGroupPolicy: Create when
+// createLdapGroups is set and no policy is given, otherwise Warn
+func ldapMissingGroupPolicy(pb *permissionv1.PermissionBinder) string {
+	if pb.Spec.LdapGroupVerification != nil && pb.Spec.LdapGroupVerification.MissingGroupPolicy != "" {
+		return pb.Spec.LdapGroupVerification.MissingGroupPolicy
+	}
+	if pb.Spec.CreateLdapGroups {
+		return LdapMissingGroupCreate
+	}
+	return LdapMissingGroupWarn
+}
+
+// ldapGroupCreationEnabled reports whether the operator creates LDAP groups,
+// either for every entry (createLdapGroups) or for missing groups found by the verification
+func ldapGroupCreationEnabled(pb *permissionv1.PermissionBinder) bool {
+	if ldapGroupVerificationEnabled(pb) {
+		return ldapMissingGroupPolicy(pb) == LdapMissingGroupCreate
+	}
+	return pb.Spec.CreateLdapGroups
+}
+
+// ldapVerificationRecheckInterval parses ldapGroupVerification.recheckInterval (default 10m)
+func ldapVerificationRecheckInterval(pb *permissionv1.PermissionBinder) time.Duration {
+	if pb.Spec.LdapGroupVerification == nil || pb.Spec.LdapGroupVerification.RecheckInterval == "" {
+		return defaultLdapVerificationRecheckInterval
+	}
+	interval, err := time.ParseDuration(pb.Spec.LdapGroupVerification.RecheckInterval)
+	if err != nil || interval <= 0 {
+		return defaultLdapVerificationRecheckInterval
+	}
+	return interval
+}
+
+// nextLdapGroupVerification returns how long until missing groups (or a failed
+// lookup) are verified again, 0 when nothing is to be rechecked
+func nextLdapGroupVerification(pb *permissionv1.PermissionBinder, status *permissionv1.LdapGroupVerificationStatus, now time.Time) time.Duration {
+	if !ldapGroupVerificationEnabled(pb) || status == nil || (status.Missing == 0 && status.Error == "") {
+		return 0
+	}
+	if status.VerifiedAt == nil {
+		return time.Second
+	}
+	wait := status.VerifiedAt.Add(ldapVerificationRecheckInterval(pb)).Sub(now)
+	if wait < time.Second {
+		return time.Second
+	}
+	return wait
+}
+
+// ldapGroupVerificationDue reports whether the whitelist has to be processed
+// again for the verification: it was just enabled or disabled, or missing groups are due for a recheck
+func ldapGroupVerificationDue(pb *permissionv1.PermissionBinder, now time.Time) bool {
+	status := pb.Status.LdapGroupVerification
+	if ldapGroupVerificationEnabled(pb) != (status != nil) {
+		return true
+	}
+	wait := nextLdapGroupVerification(pb, status, now)
+	return wait > 0 && wait <= time.Second
+}
+
+// ldapGroupVerifier looks up whitelist groups in LDAP during one processing of
+// the whitelist and classifies them as present, missing or created
+type ldapGroupVerifier struct {
+	conn        ldap.Client
+	profile     *LdapSchemaProfile
+	clusterName string
+	policy      string
+	err         error
+
+	states map[string]string // lower-cased DN -> classification
+	// owned are the operator-created groups among the verified entries
+	owned   []string
+	missing []string
+	created int
+}
+
+// newLdapGroupVerifier connects to LDAP for the verification. It returns nil when
+// the verification is disabled. A connection failure is recorded and every entry
+// is left unverified (bound as usual) - an LDAP outage must not drop RoleBindings.
+func (r *PermissionBinderReconciler) newLdapGroupVerifier(ctx context.Context, pb *permissionv1.PermissionBinder) *ldapGroupVerifier {
+	if !ldapGroupVerificationEnabled(pb) {
+		return nil
+	}
+	v := &ldapGroupVerifier{policy: ldapMissingGroupPolicy(pb), states: make(map[string]string)}
+	if pb.Spec.LdapSecretRef == nil {
+		v.err = fmt.Errorf("ldapGroupVerification requires ldapSecretRef")
+		return v
+	}
+	if v.policy == LdapMissingGroupCreate {
+		v.clusterName = r.GetClusterName(ctx, pb)
+	}
+	v.conn, v.profile, v.err = r.connectLdapForBinder(ctx, pb)
+	return v
+}
+
+// Close releases the LDAP connection of the verifier
+func (v *ldapGroupVerifier) Close() {
+	if v != nil && v.conn != nil {
+		v.conn.Close()
+	}
+}
+
+// Verify classifies the group of a whitelist DN. It returns "" when the group
+// could not be verified (LDAP unavailable or lookup error).
+func (v *ldapGroupVerifier) Verify(ctx context.Context, dn string) string {
+	logger := log.FromContext(ctx)
+	if v.err != nil {
+		return ""
+	}
+	key := strings.ToLower(dn)
+	if state, seen := v.states[key]; seen {
+		return state
+	}
+
+	groupInfo, err := ParseCN(dn)
+	if err != nil {
+		return ""
+	}
+	sr, err := v.conn.Search(ldap.NewSearchRequest(
+		groupInfo.FullDN,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0, 0, false,
+		"(objectClass=*)",
+		[]string{"description"},
+		nil,
+	))
+	state := LdapGroupPresent
+	switch {
+	case err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject):
+		logger.Error(err, "Failed to look up LDAP group, binding it unverified", "dn", dn)
+		if ldap.IsErrorWithCode(err, ldap.ErrorNetwork) {
+			// Stop querying a dead connection; the remaining entries stay unverified
+			v.err = err
+		}
+		return ""
+	case err == nil && len(sr.Entries) > 0:
+		if v.policy == LdapMissingGroupCreate &&
+			IsOperatorCreatedLdapGroup(sr.Entries[0].GetAttributeValue("description"), v.clusterName) {
+			v.owned = append(v.owned, groupInfo.FullDN)
+		}
+	case v.policy == LdapMissingGroupCreate:
+		owned, err := CreateLdapGroup(ctx, v.conn, groupInfo, v.clusterName, v.profile)
+		if err != nil {
+			logger.Error(err, "Failed to create missing LDAP group", "dn", dn)
+			state = LdapGroupMissing
+			break
+		}
+		if owned {
+			v.owned = append(v.owned, groupInfo.FullDN)
+		}
+		state = LdapGroupCreated
+	default:
+		state = LdapGroupMissing
+	}
+
+	switch state {
+	case LdapGroupMissing:
+		v.missing = append(v.missing, dn)
+	case LdapGroupCreated:
+		v.created++
+	}
+	v.states[key] = state
+	return state
+}
+
+// Status summarizes the verification for status.ldapGroupVerification and
+// updates the whitelist group gauges
+func (v *ldapGroupVerifier) Status(now time.Time) *permissionv1.LdapGroupVerificationStatus {
+	status := &permissionv1.LdapGroupVerificationStatus{
+		Missing:    len(v.missing),
+		Created:    v.created,
+		Present:    len(v.states) - len(v.missing) - v.created,
+		VerifiedAt: &metav1.Time{Time: now},
+	}
+	if v.err != nil {
+		status.Error = v.err.Error()
+	}
+	missing := v.missing
+	if len(missing) > maxReportedMissingLdapGroups {
+		missing = missing[:maxReportedMissingLdapGroups]
+	}
+	status.MissingGroups = append([]string(nil), missing...)
+
+	ldapWhitelistGroups.WithLabelValues(LdapGroupPresent).Set(float64(status.Present))
+	ldapWhitelistGroups.WithLabelValues(LdapGroupMissing).Set(float64(status.Missing))
+	ldapWhitelistGroups.WithLabelValues(LdapGroupCreated).Set(float64(status.Created))
+	return status
+}
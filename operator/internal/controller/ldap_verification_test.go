@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestLdapMissingGroupPolicy(t *testing.T) {
+	pb := &permissionv1.PermissionBinder{}
+	if got := ldapMissingGroupPolicy(pb); got != LdapMissingGroupWarn {
+		t.Errorf("default policy = %q, want Warn", got)
+	}
+	pb.Spec.CreateLdapGroups = true
+	if got := ldapMissingGroupPolicy(pb); got != LdapMissingGroupCreate {
+		t.Errorf("policy with createLdapGroups = %q, want Create", got)
+	}
+	pb.Spec.LdapGroupVerification = &permissionv1.LdapGroupVerificationSpec{Enabled: true, MissingGroupPolicy: LdapMissingGroupSkip}
+	if got := ldapMissingGroupPolicy(pb); got != LdapMissingGroupSkip {
+		t.Errorf("explicit policy = %q, want Skip", got)
+	}
+	if ldapGroupCreationEnabled(pb) {
+		t.Error("explicit Skip policy must not create groups even with createLdapGroups")
+	}
+}
+
+func TestLdapGroupVerifier_Verify(t *testing.T) {
+	const presentDN = "CN=MT-K8S-app-admin,OU=K8S,DC=example,DC=com"
+	const typoDN = "CN=MT-K8S-app-admn,OU=K8S,DC=example,DC=com"
+
+	newVerifier := func(policy string) (*ldapGroupVerifier, *fakeLdapClient) {
+		conn := newFakeLdapClient()
+		conn.addEntry(presentDN, map[string][]string{"description": {"Managed by the identity team"}})
+		profile, _ := ResolveLdapSchemaProfile(nil, "CN=svc,DC=example,DC=com")
+		return &ldapGroupVerifier{conn: conn, profile: profile, policy: policy, clusterName: "prod",
+			states: make(map[string]string)}, conn
+	}
+
+	t.Run("present and missing groups are classified", func(t *testing.T) {
+		v, _ := newVerifier(LdapMissingGroupSkip)
+		if got := v.Verify(context.Background(), presentDN); got != LdapGroupPresent {
+			t.Errorf("present group = %q", got)
+		}
+		if got := v.Verify(context.Background(), typoDN); got != LdapGroupMissing {
+			t.Errorf("missing group = %q", got)
+		}
+		// Repeated entries (several roles of one group) are looked up once
+		v.Verify(context.Background(), typoDN)
+
+		status := v.Status(time.Now())
+		if status.Present != 1 || status.Missing != 1 || status.Created != 0 {
+			t.Errorf("status = %+v, want 1 present, 1 missing", status)
+		}
+		if len(status.MissingGroups) != 1 || status.MissingGroups[0] != typoDN {
+			t.Errorf("missingGroups = %v", status.MissingGroups)
+		}
+	})
+
+	t.Run("policy Create creates missing groups", func(t *testing.T) {
+		v, conn := newVerifier(LdapMissingGroupCreate)
+		if got := v.Verify(context.Background(), typoDN); got != LdapGroupCreated {
+			t.Errorf("missing group with policy Create = %q, want created", got)
+		}
+		if _, exists := conn.entries[strings.ToLower(typoDN)]; !exists {
+			t.Error("group was not created")
+		}
+		if len(v.owned) != 1 || v.owned[0] != typoDN {
+			t.Errorf("owned = %v, want the created group", v.owned)
+		}
+		if status := v.Status(time.Now()); status.Created != 1 || status.Missing != 0 {
+			t.Errorf("status = %+v, want 1 created", status)
+		}
+	})
+
+	t.Run("unreachable LDAP leaves entries unverified", func(t *testing.T) {
+		v := &ldapGroupVerifier{err: ldap.NewError(ldap.ErrorNetwork, errors.New("connection refused")), states: make(map[string]string)}
+		if got := v.Verify(context.Background(), typoDN); got != "" {
+			t.Errorf("Verify() = %q, want unverified", got)
+		}
+		if status := v.Status(time.Now()); status.Error == "" || status.Missing != 0 {
+			t.Errorf("status = %+v, want error and no missing groups", status)
+		}
+	})
+}
+
+func TestLdapGroupVerificationDue(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	verifiedAt := metav1.NewTime(now.Add(-5 * time.Minute))
+	pb := &permissionv1.PermissionBinder{
+		Spec: permissionv1.PermissionBinderSpec{
+			LdapGroupVerification: &permissionv1.LdapGroupVerificationSpec{Enabled: true, RecheckInterval: "10m"},
+		},
+	}
+
+	if !ldapGroupVerificationDue(pb, now) {
+		t.Error("just enabled verification must be due")
+	}
+	pb.Status.LdapGroupVerification = &permissionv1.LdapGroupVerificationStatus{Present: 3, VerifiedAt: &verifiedAt}
+	if ldapGroupVerificationDue(pb, now) {
+		t.Error("no missing groups: nothing to recheck")
+	}
+	pb.Status.LdapGroupVerification.Missing = 1
+	if got := nextLdapGroupVerification(pb, pb.Status.LdapGroupVerification, now); got != 5*time.Minute {
+		t.Errorf("nextLdapGroupVerification() = %v, want 5m", got)
+	}
+	if ldapGroupVerificationDue(pb, now) || !ldapGroupVerificationDue(pb, now.Add(6*time.Minute)) {
+		t.Error("missing groups must be rechecked after the recheck interval")
+	}
+	pb.Spec.LdapGroupVerification.Enabled = false
+	if !ldapGroupVerificationDue(pb, now) {
+		t.Error("disabled verification with a stale status must be due (to clear it)")
+	}
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// whitelistSource.type values
+	WhitelistSourceConfigMap  = "ConfigMap"
+	WhitelistSourceLdapSearch = "LdapSearch"
+
+	// ldapSearch.scope values
+	LdapSearchScopeSubtree  = "Subtree"
+	LdapSearchScopeOneLevel = "OneLevel"
+
+	defaultLdapWhitelistPageSize       = 500
+	defaultLdapWhitelistSearchInterval = 15 * time.Minute
+
+	// ldapWhitelistVersionPrefix marks lastProcessedConfigMapVersion values of the LdapSearch source
+	ldapWhitelistVersionPrefix = "ldap-"
+)
+
+// ldapWhitelistSearchEnabled reports whether whitelist entries come from an LDAP search
+func ldapWhitelistSearchEnabled(pb *permissionv1.PermissionBinder) bool {
+	return pb.Spec.WhitelistSource != nil && pb.Spec.WhitelistSource.Type == WhitelistSourceLdapSearch
+}
+
+// ldapWhitelistSearchInterval parses ldapSearch.interval (default 15m)
+func ldapWhitelistSearchInterval(spec *permissionv1.LdapWhitelistSearchSpec) time.Duration {
+	if spec == nil || spec.Interval == "" {
+		return defaultLdapWhitelistSearchInterval
+	}
+	interval, err := time.ParseDuration(spec.Interval)
+	if err != nil || interval <= 0 {
+		return defaultLdapWhitelistSearchInterval
+	}
+	return interval
+}
+
+// ldapWhitelistCacheEntry is the cached result of the whitelist search of one PermissionBinder
+type ldapWhitelistCacheEntry struct {
+	// spec is the search configuration the result belongs to
+	spec       string
+	dns        []string
+	hash       string
+	searchedAt time.Time
+}
+
+// ldapWhitelistCache caches search results between intervals so that
+// reconciles triggered by other changes do not query LDAP
+type ldapWhitelistCache struct {
+	mu      sync.Mutex
+	entries map[string]ldapWhitelistCacheEntry
+}
+
+var defaultLdapWhitelistCache = &ldapWhitelistCache{entries: make(map[string]ldapWhitelistCacheEntry)}
+
+func (c *ldapWhitelistCache) get(key string) (ldapWhitelistCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *ldapWhitelistCache) set(key string, entry ldapWhitelistCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+}
+
+// searchLdapWhitelist runs the paged whitelist search and returns the sorted,
+// de-duplicated DNs of the matching entries
+func searchLdapWhitelist(conn ldap.Client, spec *permissionv1.LdapWhitelistSearchSpec) ([]string, error) {
+	scope := ldap.ScopeWholeSubtree
+	if spec.Scope == LdapSearchScopeOneLevel {
+		scope = ldap.ScopeSingleLevel
+	}
+	pageSize := uint32(defaultLdapWhitelistPageSize)
+	if spec.PageSize != nil && *spec.PageSize > 0 {
+		pageSize = uint32(*spec.PageSize)
+	}
+
+	// "1.1" requests no attributes - only the DNs are needed
+	sr, err := conn.SearchWithPaging(ldap.NewSearchRequest(
+		spec.BaseDN,
+		scope,
+		ldap.NeverDerefAliases,
+		0, 0, false,
+		spec.Filter,
+		[]string{"1.1"},
+		nil,
+	), pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("LDAP whitelist search in %s with filter %s failed: %w", spec.BaseDN, spec.Filter, err)
+	}
+
+	seen := make(map[string]bool)
+	var dns []string
+	for _, entry := range sr.Entries {
+		dn := normalizeWhitelistDN(entry.DN)
+		if dn == "" || seen[strings.ToLower(dn)] {
+			continue
+		}
+		seen[strings.ToLower(dn)] = true
+		dns = append(dns, dn)
+	}
+	sort.Strings(dns)
+	return dns, nil
+}
+
+// normalizeWhitelistDN upper-cases a leading "cn=" (as returned by OpenLDAP)
+// since whitelist parsing expects the Active Directory "CN=" form
+func normalizeWhitelistDN(dn string) string {
+	dn = strings.TrimSpace(dn)
+	if len(dn) > 3 && strings.EqualFold(dn[:3], "cn=") {
+		return "CN=" + dn[3:]
+	}
+	return dn
+}
+
+// hashWhitelistDNs returns a stable hash of a sorted DN list
+func hashWhitelistDNs(dns []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(dns, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// diffWhitelistDNs counts the DNs added to and removed from a sorted DN list
+func diffWhitelistDNs(previous, current []string) (added, removed int) {
+	old := make(map[string]bool, len(previous))
+	for _, dn := range previous {
+		old[dn] = true
+	}
+	for _, dn := range current {
+		if !old[dn] {
+			added++
+		}
+		delete(old, dn)
+	}
+	return added, len(old)
+}
+
+// ldapWhitelistSearchSpecKey identifies the search configuration of a cached result
+func ldapWhitelistSearchSpecKey(pb *permissionv1.PermissionBinder) string {
+	spec := pb.Spec.WhitelistSource.LdapSearch
+	pageSize := int32(0)
+	if spec.PageSize != nil {
+		pageSize = *spec.PageSize
+	}
+	ref := ""
+	if pb.Spec.LdapSecretRef != nil {
+		ref = pb.Spec.LdapSecretRef.Namespace + "/" + pb.Spec.LdapSecretRef.Name
+	}
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%d\x00%s", spec.BaseDN, spec.Filter, spec.Scope, pageSize, ref)
+}
+
+// nextLdapWhitelistSearch returns how long until the next whitelist search is due, 0 if not applicable
+func nextLdapWhitelistSearch(pb *permissionv1.PermissionBinder, status *permissionv1.WhitelistSourceStatus, now time.Time) time.Duration {
+	if !ldapWhitelistSearchEnabled(pb) || status == nil {
+		return 0
+	}
+	interval := ldapWhitelistSearchInterval(pb.Spec.WhitelistSource.LdapSearch)
+	if status.Error != "" || status.LastSearchTime == nil {
+		// Retry failed searches sooner than the regular interval
+		return minRequeueAfter(interval, time.Minute)
+	}
+	wait := status.LastSearchTime.Add(interval).Sub(now)
+	if wait < time.Second {
+		return time.Second
+	}
+	return wait
+}
+
+// getLdapWhitelistConfigMap runs (or reuses the cached result of) the whitelist
+// search and returns it as a ConfigMap with a whitelist.txt key, so that it goes
+// through the same processing as the ConfigMap source. Its ResourceVersion is a
+// hash of the result: an unchanged result does not trigger RBAC work. The data of
+// configMapName (if it exists) is carried over for LDAP group member declarations.
+func (r *PermissionBinderReconciler) getLdapWhitelistConfigMap(ctx context.Context, pb *permissionv1.PermissionBinder, now time.Time) (*corev1.ConfigMap, *permissionv1.WhitelistSourceStatus, error) {
+	logger := log.FromContext(ctx)
+	spec := pb.Spec.WhitelistSource.LdapSearch
+	if spec == nil {
+		return nil, nil, fmt.Errorf("whitelistSource.type %s requires whitelistSource.ldapSearch", WhitelistSourceLdapSearch)
+	}
+	if pb.Spec.LdapSecretRef == nil {
+		return nil, nil, fmt.Errorf("whitelistSource.type %s requires ldapSecretRef", WhitelistSourceLdapSearch)
+	}
+
+	cacheKey := types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}.String()
+	specKey := ldapWhitelistSearchSpecKey(pb)
+	cached, found := defaultLdapWhitelistCache.get(cacheKey)
+	status := &permissionv1.WhitelistSourceStatus{Type: WhitelistSourceLdapSearch}
+	if previous := pb.Status.WhitelistSource; previous != nil {
+		status.Added, status.Removed = previous.Added, previous.Removed
+	}
+
+	fresh := found && cached.spec == specKey && now.Sub(cached.searchedAt) < ldapWhitelistSearchInterval(spec)
+	if !fresh {
+		dns, err := r.runLdapWhitelistSearch(ctx, pb, spec)
+		switch {
+		case err != nil && !found:
+			// Never process an empty whitelist because LDAP is unavailable
+			return nil, nil, err
+		case err != nil:
+			logger.Error(err, "LDAP whitelist search failed, using the cached result",
+				"entries", len(cached.dns),
+				"searchedAt", cached.searchedAt)
+			status.Error = err.Error()
+		default:
+			entry := ldapWhitelistCacheEntry{spec: specKey, dns: dns, hash: hashWhitelistDNs(dns), searchedAt: now}
+			if found && entry.hash != cached.hash {
+				status.Added, status.Removed = diffWhitelistDNs(cached.dns, dns)
+				logger.Info("LDAP whitelist search result changed",
+					"entries", len(dns),
+					"added", status.Added,
+					"removed", status.Removed)
+			}
+			cached, found = entry, true
+			defaultLdapWhitelistCache.set(cacheKey, entry)
+		}
+	}
+	status.Entries = len(cached.dns)
+	status.LastSearchTime = &metav1.Time{Time: cached.searchedAt}
+
+	whitelist := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: pb.Spec.ConfigMapName, Namespace: pb.Spec.ConfigMapNamespace},
+		Data:       map[string]string{},
+	}
+	version := ldapWhitelistVersionPrefix + cached.hash[:16]
+	var configMap corev1.ConfigMap
+	err := r.Get(ctx, types.NamespacedName{Name: pb.Spec.ConfigMapName, Namespace: pb.Spec.ConfigMapNamespace}, &configMap)
+	switch {
+	case err == nil:
+		for key, value := range configMap.Data {
+			whitelist.Data[key] = value
+		}
+		version += "/" + configMap.ResourceVersion
+	case !errors.IsNotFound(err):
+		return nil, nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", pb.Spec.ConfigMapNamespace, pb.Spec.ConfigMapName, err)
+	}
+	whitelist.Data["whitelist.txt"] = strings.Join(cached.dns, "\n")
+	whitelist.ResourceVersion = version
+
+	return whitelist, status, nil
+}
+
+// runLdapWhitelistSearch connects with the PermissionBinder's ldapSecretRef and runs the whitelist search
+func (r *PermissionBinderReconciler) runLdapWhitelistSearch(ctx context.Context, pb *permissionv1.PermissionBinder, spec *permissionv1.LdapWhitelistSearchSpec) ([]string, error) {
+	conn, _, err := r.connectLdapForBinder(ctx, pb)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	dns, err := searchLdapWhitelist(conn, spec)
+	if err != nil {
+		return nil, err
+	}
+	log.FromContext(ctx).Info("🔎 LDAP whitelist search completed",
+		"baseDn", spec.BaseDN,
+		"filter", spec.Filter,
+		"entries", len(dns))
+	return dns, nil
+}
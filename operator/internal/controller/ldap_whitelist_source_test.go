@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestSearchLdapWhitelist(t *testing.T) {
+	conn := newFakeLdapClient()
+	conn.addEntry("cn=COMPANY-K8S-app-admin,ou=K8S,dc=example,dc=com", map[string][]string{"cn": {"COMPANY-K8S-app-admin"}})
+	conn.addEntry("CN=COMPANY-K8S-app-viewer,OU=K8S,DC=example,DC=com", map[string][]string{"cn": {"COMPANY-K8S-app-viewer"}})
+	conn.addEntry("CN=Domain Users,OU=K8S,DC=example,DC=com", map[string][]string{"cn": {"Domain Users"}})
+
+	pageSize := int32(100)
+	dns, err := searchLdapWhitelist(conn, &permissionv1.LdapWhitelistSearchSpec{
+		BaseDN:   "OU=K8S,DC=example,DC=com",
+		Filter:   "(cn=COMPANY-K8S-*)",
+		PageSize: &pageSize,
+	})
+	if err != nil {
+		t.Fatalf("searchLdapWhitelist() error: %v", err)
+	}
+	want := []string{
+		"CN=COMPANY-K8S-app-admin,ou=K8S,dc=example,dc=com",
+		"CN=COMPANY-K8S-app-viewer,OU=K8S,DC=example,DC=com",
+	}
+	if !reflect.DeepEqual(dns, want) {
+		t.Errorf("dns = %v, want %v", dns, want)
+	}
+	if !reflect.DeepEqual(conn.pagingSizes, []uint32{100}) {
+		t.Errorf("paging sizes = %v, want [100]", conn.pagingSizes)
+	}
+}
+
+func TestDiffWhitelistDNs(t *testing.T) {
+	added, removed := diffWhitelistDNs([]string{"CN=A,DC=x", "CN=B,DC=x"}, []string{"CN=B,DC=x", "CN=C,DC=x", "CN=D,DC=x"})
+	if added != 2 || removed != 1 {
+		t.Errorf("diff = +%d -%d, want +2 -1", added, removed)
+	}
+}
+
+func TestGetLdapWhitelistConfigMap(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-source", Namespace: "operators"},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			LdapSecretRef:      &permissionv1.LdapSecretReference{Name: "ldap", Namespace: "operators"},
+			WhitelistSource: &permissionv1.WhitelistSourceSpec{
+				Type:       WhitelistSourceLdapSearch,
+				LdapSearch: &permissionv1.LdapWhitelistSearchSpec{BaseDN: "OU=K8S,DC=example,DC=com", Filter: "(cn=COMPANY-K8S-*)"},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	members := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data: map[string]string{
+			"whitelist.txt":                 "CN=ignored,DC=example,DC=com",
+			"COMPANY-K8S-app-admin.members": "alice",
+		},
+	}
+	r := &PermissionBinderReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(members).Build()}
+	cacheKey := "operators/ldap-source"
+	t.Cleanup(func() { delete(defaultLdapWhitelistCache.entries, cacheKey) })
+
+	t.Run("no cached result and LDAP unavailable is an error", func(t *testing.T) {
+		// The LDAP Secret does not exist
+		if _, _, err := r.getLdapWhitelistConfigMap(context.Background(), pb, now); err == nil {
+			t.Fatal("expected an error instead of an empty whitelist")
+		}
+	})
+
+	dns := []string{"CN=COMPANY-K8S-app-admin,OU=K8S,DC=example,DC=com"}
+	defaultLdapWhitelistCache.set(cacheKey, ldapWhitelistCacheEntry{
+		spec: ldapWhitelistSearchSpecKey(pb), dns: dns, hash: hashWhitelistDNs(dns), searchedAt: now.Add(-time.Minute),
+	})
+
+	t.Run("fresh cached result is used without searching", func(t *testing.T) {
+		configMap, status, err := r.getLdapWhitelistConfigMap(context.Background(), pb, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := configMap.Data["whitelist.txt"]; got != dns[0] {
+			t.Errorf("whitelist.txt = %q, want the search result", got)
+		}
+		if configMap.Data["COMPANY-K8S-app-admin.members"] != "alice" {
+			t.Error("member declarations of configMapName were not carried over")
+		}
+		if !strings.HasPrefix(configMap.ResourceVersion, ldapWhitelistVersionPrefix) {
+			t.Errorf("ResourceVersion = %q, want a search result hash", configMap.ResourceVersion)
+		}
+		if status.Entries != 1 || status.Error != "" {
+			t.Errorf("status = %+v", status)
+		}
+		if got := nextLdapWhitelistSearch(pb, status, now); got != 14*time.Minute {
+			t.Errorf("nextLdapWhitelistSearch() = %v, want 14m", got)
+		}
+	})
+
+	t.Run("failed search falls back to the stale cached result", func(t *testing.T) {
+		configMap, status, err := r.getLdapWhitelistConfigMap(context.Background(), pb, now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if configMap.Data["whitelist.txt"] != dns[0] || status.Error == "" {
+			t.Errorf("whitelist.txt = %q, status = %+v, want cached result with error", configMap.Data["whitelist.txt"], status)
+		}
+		if got := nextLdapWhitelistSearch(pb, status, now.Add(time.Hour)); got != time.Minute {
+			t.Errorf("retry after failed search = %v, want 1m", got)
+		}
+	})
+}
@@ -29,6 +29,7 @@ import (
 
 	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
 	networkpolicy "github.com/permission-binder-operator/operator/internal/controller/networkpolicy"
+	"github.com/permission-binder-operator/operator/internal/controller/reportstore"
 )
 
 // Prometheus metrics for production environment monitoring
@@ -62,7 +63,7 @@ var (
 	// Counter for refused ownership takeovers (issue #43): a resource carrying
 	// a live claim by ANOTHER PermissionBinder was skipped by the write path
 	// instead of being stolen. resource_type: namespace | rolebinding |
-	// serviceaccount_rolebinding.
+	// serviceaccount_rolebinding | serviceaccount_token_secret.
 	ownershipConflictsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "permission_binder_ownership_conflicts_total",
@@ -71,6 +72,18 @@ var (
 		[]string{"resource_type"},
 	)
 
+	// Counter for RoleBinding replacements after a RoleRef change
+	// (make-before-break). resource_type: rolebinding | serviceaccount_rolebinding.
+	// result: success | rolled_back (previous binding kept) | failed (rollback
+	// failed, transition binding kept).
+	roleBindingReplacementsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "permission_binder_rolebinding_replacements_total",
+			Help: "Total number of RoleBinding replacements after a RoleRef change",
+		},
+		[]string{"resource_type", "result"},
+	)
+
 	// Gauge for managed RoleBindings
 	managedRoleBindingsTotal = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -93,25 +106,109 @@ var (
 			Name: "permission_binder_configmap_entries_processed_total",
 			Help: "Total number of ConfigMap entries processed",
 		},
-		[]string{"status"}, // success, error, excluded
+		[]string{"status"}, // success, error, excluded, ownership_conflict, ldap_group_missing
 	)
 
 	// Counter for LDAP group operations
 	ldapGroupOperationsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "permission_binder_ldap_group_operations_total",
-			Help: "Total number of LDAP group operations (create, exists, error)",
+			Help: "Total number of LDAP group operations (create, exists, retire, delete, error)",
 		},
-		[]string{"operation"}, // created, exists, error
+		[]string{"operation"}, // created, exists, cached, error, retired, restored, deleted, skipped_foreign
 	)
 
 	// Counter for LDAP connection attempts
 	ldapConnectionsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "permission_binder_ldap_connections_total",
-			Help: "Total number of LDAP connection attempts",
+			Help: "Total number of LDAP connection attempts and pooled connection reuses",
+		},
+		[]string{"status"}, // success, error, reused (pooled connection)
+	)
+
+	// Counter for LDAP operations delayed by the ldapRateLimit token bucket
+	ldapOperationsThrottledTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "permission_binder_ldap_operations_throttled_total",
+			Help: "Total number of LDAP operations that waited for the rate limiter",
+		},
+	)
+
+	// Counter for LDAP group membership changes (ldapGroupMembers).
+	// action: add | remove | deferred (removal over the per-reconcile cap) | error.
+	ldapGroupMembershipChangesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "permission_binder_ldap_group_membership_changes_total",
+			Help: "Total number of LDAP group membership changes",
+		},
+		[]string{"action"},
+	)
+
+	// Gauge for the LDAP lookup of whitelist groups (ldapGroupVerification).
+	// state: present | missing | created.
+	ldapWhitelistGroups = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "permission_binder_ldap_whitelist_groups",
+			Help: "Number of whitelist groups by LDAP verification result",
+		},
+		[]string{"state"},
+	)
+
+	// Info gauge (always 1) for the cluster name of each PermissionBinder.
+	// source: spec | operator | gitRepository | infrastructure | cluster-info | default.
+	clusterInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "permission_binder_cluster_info",
+			Help: "Cluster name used by a PermissionBinder and where it came from",
 		},
-		[]string{"status"}, // success, error
+		[]string{"permissionbinder", "cluster", "source"},
+	)
+
+	// Gauge for configured cluster name sources that disagree with the one in use
+	clusterNameConflicts = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "permission_binder_cluster_name_conflicts",
+			Help: "Number of configured cluster name sources that disagree with the cluster name in use",
+		},
+		[]string{"permissionbinder"},
+	)
+
+	// Gauge (1 or 0) for PermissionBinders that compute but do not apply changes
+	// (--freeze, freeze ConfigMap or spec.paused)
+	frozenPermissionBinders = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "permission_binder_frozen",
+			Help: "Whether a PermissionBinder is frozen or paused (1) or applies changes (0)",
+		},
+		[]string{"permissionbinder"},
+	)
+
+	// Gauge for the changes withheld by the last frozen reconciliation
+	withheldChangesTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "permission_binder_withheld_changes",
+			Help: "Number of changes computed but not applied because the PermissionBinder is frozen or paused",
+		},
+		[]string{"permissionbinder"},
+	)
+
+	// Gauge for RoleBindings left alone because of the break-glass annotation
+	breakGlassRoleBindingsTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "permission_binder_break_glass_rolebindings",
+			Help: "Number of RoleBindings of a PermissionBinder with an active break-glass annotation",
+		},
+		[]string{"permissionbinder"},
+	)
+
+	// Gauge for deletions held back by spec.deletionProtection
+	blockedDeletionsTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "permission_binder_blocked_deletions",
+			Help: "Number of deletions of a PermissionBinder halted by deletionProtection until acknowledged",
+		},
+		[]string{"permissionbinder"},
 	)
 
 	// Counter for ServiceAccount creations
@@ -123,6 +220,26 @@ var (
 		[]string{"namespace", "sa_type"}, // namespace, deploy/runtime/etc
 	)
 
+	// Counter for pruned ServiceAccounts (key removed from serviceAccountMapping
+	// or namespace left the whitelist). action: deleted | orphaned.
+	serviceAccountsPruned = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "permission_binder_service_accounts_pruned_total",
+			Help: "Total number of ServiceAccounts pruned after leaving the desired set",
+		},
+		[]string{"namespace", "sa_type", "action"},
+	)
+
+	// Counter for managed ServiceAccount token rotations (token Secret created
+	// or TokenRequest token refreshed). result: success | error.
+	serviceAccountTokenRotations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "permission_binder_service_account_token_rotations_total",
+			Help: "Total number of managed ServiceAccount token rotations",
+		},
+		[]string{"namespace", "mode", "result"},
+	)
+
 	// Gauge for managed ServiceAccounts
 	managedServiceAccountsTotal = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -139,15 +256,28 @@ func init() {
 		orphanedResourcesTotal,
 		adoptionEventsTotal,
 		ownershipConflictsTotal,
+		roleBindingReplacementsTotal,
 		ldapGroupOperationsTotal,
 		ldapConnectionsTotal,
+		ldapOperationsThrottledTotal,
+		ldapGroupMembershipChangesTotal,
+		ldapWhitelistGroups,
+		clusterInfo,
+		clusterNameConflicts,
+		frozenPermissionBinders,
+		withheldChangesTotal,
+		breakGlassRoleBindingsTotal,
+		blockedDeletionsTotal,
 		managedRoleBindingsTotal,
 		managedNamespacesTotal,
 		managedServiceAccountsTotal,
 		serviceAccountsCreated,
+		serviceAccountsPruned,
+		serviceAccountTokenRotations,
 		configMapEntriesProcessed,
 		// NetworkPolicy metrics (from network_policy_helper.go)
 		networkpolicy.NetworkPolicyPRsCreatedTotal,
+		networkpolicy.NetworkPolicyPRsPending,
 		networkpolicy.NetworkPolicyPRCreationErrorsTotal,
 		networkpolicy.NetworkPolicyTemplateValidationErrorsTotal,
 		networkpolicy.NetworkPolicyMultipleCRsWarningTotal,
@@ -197,5 +327,14 @@ func (r *PermissionBinderReconciler) updateMetrics(ctx context.Context, permissi
 	}
 	orphanedResourcesTotal.WithLabelValues("namespace").Set(float64(orphanedNS))
 
+	// Update the ServiceAccount and pending NetworkPolicy PR counts from the reports
+	reports, err := reportstore.List(ctx, r, permissionBinder)
+	if err != nil {
+		return err
+	}
+	summary := reportstore.Summarize(reports)
+	managedServiceAccountsTotal.Set(float64(summary.ServiceAccounts))
+	networkpolicy.UpdatePendingPRsMetric(permissionBinder, summary.NetworkPolicies)
+
 	return nil
 }
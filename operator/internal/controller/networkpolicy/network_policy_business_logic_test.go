@@ -29,6 +29,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/yaml"
@@ -40,16 +41,27 @@ import (
 // Business Logic Tests (using fake K8s client)
 // ============================================================================
 
+// fakeReconciler is a ReconcilerInterface backed by a fake client
+type fakeReconciler struct {
+	client.Client
+	recorder record.EventRecorder
+}
+
+func (f *fakeReconciler) EventRecorder() record.EventRecorder {
+	return f.recorder
+}
+
 func setupFakeClient(objs ...client.Object) ReconcilerInterface {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
 	_ = networkingv1.AddToScheme(scheme)
 	_ = permissionv1.AddToScheme(scheme)
 
-	return fake.NewClientBuilder().
+	return &fakeReconciler{Client: fake.NewClientBuilder().
 		WithScheme(scheme).
 		WithObjects(objs...).
-		Build()
+		WithStatusSubresource(&permissionv1.PermissionBinderReport{}).
+		Build()}
 }
 
 func TestCheckMultiplePermissionBinders(t *testing.T) {
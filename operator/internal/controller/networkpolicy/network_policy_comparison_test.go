@@ -17,9 +17,12 @@ limitations under the License.
 package networkpolicy
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+	"github.com/permission-binder-operator/operator/internal/controller/reportstore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -158,38 +161,90 @@ func TestCompareNetworkPolicyRules(t *testing.T) {
 // Status Tracking Tests
 // ============================================================================
 
+func newReportedPermissionBinder(t *testing.T, statusEntry permissionv1.NetworkPolicyStatus) (ReconcilerInterface, *permissionv1.PermissionBinder) {
+	t.Helper()
+	permissionBinder := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{Name: "binder", Namespace: "operators"},
+	}
+	r := setupFakeClient(permissionBinder)
+	_, err := reportstore.Update(context.Background(), r, permissionBinder, statusEntry.Namespace,
+		func(status *permissionv1.PermissionBinderReportStatus) {
+			status.NetworkPolicy = &statusEntry
+		})
+	require.NoError(t, err)
+	return r, permissionBinder
+}
+
 func TestGetNetworkPolicyStatus(t *testing.T) {
-	statusEntry := permissionv1.NetworkPolicyStatus{
+	ctx := context.Background()
+	r, permissionBinder := newReportedPermissionBinder(t, permissionv1.NetworkPolicyStatus{
 		Namespace: "my-namespace",
 		State:     "pr-created",
-	}
-
-	permissionBinder := &permissionv1.PermissionBinder{
-		Status: permissionv1.PermissionBinderStatus{
-			NetworkPolicies: []permissionv1.NetworkPolicyStatus{statusEntry},
-		},
-	}
+	})
 
 	// Test existing status
-	result := getNetworkPolicyStatus(permissionBinder, "my-namespace")
+	result, err := getNetworkPolicyStatus(ctx, r, permissionBinder, "my-namespace")
+	require.NoError(t, err)
 	require.NotNil(t, result)
 	assert.Equal(t, "my-namespace", result.Namespace)
 	assert.Equal(t, "pr-created", result.State)
 
 	// Test non-existing status
-	result = getNetworkPolicyStatus(permissionBinder, "other-namespace")
+	result, err = getNetworkPolicyStatus(ctx, r, permissionBinder, "other-namespace")
+	require.NoError(t, err)
 	assert.Nil(t, result)
 }
 
 func TestHasNetworkPolicyStatus(t *testing.T) {
+	ctx := context.Background()
+	r, permissionBinder := newReportedPermissionBinder(t, permissionv1.NetworkPolicyStatus{
+		Namespace: "my-namespace",
+		State:     "pr-created",
+	})
+
+	has, err := hasNetworkPolicyStatus(ctx, r, permissionBinder, "my-namespace")
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = hasNetworkPolicyStatus(ctx, r, permissionBinder, "other-namespace")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestCleanupStatus(t *testing.T) {
+	ctx := context.Background()
 	permissionBinder := &permissionv1.PermissionBinder{
-		Status: permissionv1.PermissionBinderStatus{
-			NetworkPolicies: []permissionv1.NetworkPolicyStatus{
-				{Namespace: "my-namespace", State: "pr-created"},
-			},
+		ObjectMeta: metav1.ObjectMeta{Name: "binder", Namespace: "operators"},
+		Spec: permissionv1.PermissionBinderSpec{
+			NetworkPolicy: &permissionv1.NetworkPolicySpec{StatusRetentionDays: 30},
 		},
 	}
+	r := setupFakeClient(permissionBinder)
+	expired := time.Now().AddDate(0, 0, -31).Format(time.RFC3339)
+	for _, statusEntry := range []permissionv1.NetworkPolicyStatus{
+		{Namespace: "active", State: "pr-merged"},
+		{Namespace: "dropped", State: "pr-merged"},
+		{Namespace: "expired", State: "removed", RemovedAt: expired},
+	} {
+		_, err := reportstore.Update(ctx, r, permissionBinder, statusEntry.Namespace,
+			func(status *permissionv1.PermissionBinderReportStatus) {
+				status.NetworkPolicy = &statusEntry
+			})
+		require.NoError(t, err)
+	}
 
-	assert.True(t, hasNetworkPolicyStatus(permissionBinder, "my-namespace"))
-	assert.False(t, hasNetworkPolicyStatus(permissionBinder, "other-namespace"))
+	require.NoError(t, CleanupStatus(ctx, r, permissionBinder, map[string]bool{"active": true}))
+
+	statuses, err := listNetworkPolicyStatuses(ctx, r, permissionBinder)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "active", statuses[0].Namespace)
+	assert.Equal(t, "pr-merged", statuses[0].State)
+	assert.Equal(t, "dropped", statuses[1].Namespace)
+	assert.Equal(t, "removed", statuses[1].State)
+	assert.NotEmpty(t, statuses[1].RemovedAt)
+
+	// The report of the expired namespace had nothing else to report
+	report, err := reportstore.Get(ctx, r, permissionBinder, "expired")
+	require.NoError(t, err)
+	assert.Nil(t, report)
 }
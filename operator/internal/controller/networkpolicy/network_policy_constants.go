@@ -89,7 +89,10 @@ var (
 		[]string{"cluster", "namespace", "variant"},
 	)
 
-	networkPolicyPRsPending = prometheus.NewGaugeVec(
+	// NetworkPolicyPRsPending is the number of open NetworkPolicy Pull Requests,
+	// counted from the PermissionBinderReports (see UpdatePendingPRsMetric).
+	// Labels: cluster, state (pr-created/pr-pending/pr-stale/pr-removal)
+	NetworkPolicyPRsPending = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "permission_binder_networkpolicy_prs_pending",
 			Help: "Current number of pending NetworkPolicy PRs",
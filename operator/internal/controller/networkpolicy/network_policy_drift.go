@@ -194,7 +194,7 @@ func checkDriftForNamespace(r ReconcilerInterface,
 	logger := log.FromContext(ctx)
 
 	gitRepo := permissionBinder.Spec.NetworkPolicy.GitRepository
-	clusterName := gitRepo.ClusterName
+	clusterName := clusterNameFor(permissionBinder)
 
 	// Get TLS verify setting (default: true for security)
 	tlsVerify := true
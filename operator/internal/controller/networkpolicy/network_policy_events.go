@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicy
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// EventReasonPullRequestCreated is the reason of the Event of a created NetworkPolicy PR
+	EventReasonPullRequestCreated = "PullRequestCreated"
+
+	// EventReasonPullRequestMerged is the reason of the Event of an auto-merged NetworkPolicy PR
+	EventReasonPullRequestMerged = "PullRequestMerged"
+)
+
+// recordEvent emits the same Event on the PermissionBinder and on the namespace the
+// Pull Request is about. The namespace is skipped when it does not exist (anymore).
+func recordEvent(ctx context.Context, r ReconcilerInterface, permissionBinder *permissionv1.PermissionBinder, namespace, eventType, reason, messageFmt string, args ...interface{}) {
+	recorder := r.EventRecorder()
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(permissionBinder, eventType, reason, messageFmt, args...)
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err == nil {
+		recorder.Eventf(&ns, eventType, reason, messageFmt, args...)
+	}
+}
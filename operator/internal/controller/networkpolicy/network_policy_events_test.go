@@ -0,0 +1,36 @@
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestRecordEvent(t *testing.T) {
+	pb := &permissionv1.PermissionBinder{ObjectMeta: metav1.ObjectMeta{Name: "binder-1", Namespace: "default"}}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}
+	r := setupFakeClient(pb, namespace).(*fakeReconciler)
+	ctx := context.Background()
+
+	// Without a recorder nothing is emitted
+	recordEvent(ctx, r, pb, "payments", corev1.EventTypeNormal, EventReasonPullRequestCreated, "Created PR #%d", 1)
+
+	recorder := record.NewFakeRecorder(10)
+	r.recorder = recorder
+	recordEvent(ctx, r, pb, "payments", corev1.EventTypeNormal, EventReasonPullRequestCreated, "Created PR #%d", 1)
+	assert.Len(t, recorder.Events, 2, "the PermissionBinder and the namespace get the Event")
+	assert.Equal(t, "Normal PullRequestCreated Created PR #1", <-recorder.Events)
+
+	// A removed namespace only leaves the Event on the PermissionBinder
+	for len(recorder.Events) > 0 {
+		<-recorder.Events
+	}
+	recordEvent(ctx, r, pb, "removed", corev1.EventTypeNormal, EventReasonPullRequestCreated, "Created removal PR #%d", 2)
+	assert.Len(t, recorder.Events, 1)
+}
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
 )
 
 // ============================================================================
@@ -106,3 +108,18 @@ func TestIsPolicyFromTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterNameFor(t *testing.T) {
+	permissionBinder := &permissionv1.PermissionBinder{
+		Spec: permissionv1.PermissionBinderSpec{
+			NetworkPolicy: &permissionv1.NetworkPolicySpec{
+				GitRepository: &permissionv1.GitRepositorySpec{ClusterName: "legacy-cluster"},
+			},
+		},
+	}
+	assert.Equal(t, "legacy-cluster", clusterNameFor(permissionBinder), "falls back to gitRepository.clusterName")
+
+	permissionBinder.Status.ClusterIdentity = &permissionv1.ClusterIdentityStatus{Name: "DEV-cluster", Source: "spec"}
+	assert.Equal(t, "DEV-cluster", clusterNameFor(permissionBinder), "resolved cluster identity wins")
+	assert.Equal(t, "networkpolicy/DEV-cluster/team-a", generateBranchName(clusterNameFor(permissionBinder), "team-a"))
+}
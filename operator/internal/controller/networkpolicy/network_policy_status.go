@@ -21,24 +21,36 @@ import (
 	"fmt"
 	"time"
 
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+	"github.com/permission-binder-operator/operator/internal/controller/reportstore"
 )
 
-func getNetworkPolicyStatus(permissionBinder *permissionv1.PermissionBinder, namespace string) *permissionv1.NetworkPolicyStatus {
-	for i := range permissionBinder.Status.NetworkPolicies {
-		if permissionBinder.Status.NetworkPolicies[i].Namespace == namespace {
-			return &permissionBinder.Status.NetworkPolicies[i]
-		}
+// getNetworkPolicyStatus returns the NetworkPolicy state of a namespace from its
+// PermissionBinderReport, nil when there is none
+func getNetworkPolicyStatus(ctx context.Context, r ReconcilerInterface, permissionBinder *permissionv1.PermissionBinder, namespace string) (*permissionv1.NetworkPolicyStatus, error) {
+	report, err := reportstore.Get(ctx, r, permissionBinder, namespace)
+	if err != nil || report == nil {
+		return nil, err
 	}
-	return nil
+	return report.Status.NetworkPolicy, nil
 }
 
 // hasNetworkPolicyStatus checks if namespace has a status entry
-func hasNetworkPolicyStatus(permissionBinder *permissionv1.PermissionBinder, namespace string) bool {
-	return getNetworkPolicyStatus(permissionBinder, namespace) != nil
+func hasNetworkPolicyStatus(ctx context.Context, r ReconcilerInterface, permissionBinder *permissionv1.PermissionBinder, namespace string) (bool, error) {
+	status, err := getNetworkPolicyStatus(ctx, r, permissionBinder, namespace)
+	return status != nil, err
+}
+
+// listNetworkPolicyStatuses returns the NetworkPolicy state of all namespaces of the
+// PermissionBinder, sorted by namespace
+func listNetworkPolicyStatuses(ctx context.Context, r ReconcilerInterface, permissionBinder *permissionv1.PermissionBinder) ([]permissionv1.NetworkPolicyStatus, error) {
+	reports, err := reportstore.List(ctx, r, permissionBinder)
+	if err != nil {
+		return nil, err
+	}
+	return reportstore.NetworkPolicies(reports), nil
 }
 
 // updateNetworkPolicyStatus updates or creates NetworkPolicy status for a namespace
@@ -51,33 +63,27 @@ func updateNetworkPolicyStatus(r ReconcilerInterface,
 ) error {
 	logger := log.FromContext(ctx)
 
-	// Find existing status or create new
-	status := getNetworkPolicyStatus(permissionBinder, namespace)
-	if status == nil {
-		// Create new status entry
-		status = &permissionv1.NetworkPolicyStatus{
-			Namespace: namespace,
-			State:     state,
+	_, err := reportstore.Update(ctx, r, permissionBinder, namespace, func(report *permissionv1.PermissionBinderReportStatus) {
+		// Find existing status or create new
+		status := report.NetworkPolicy
+		if status == nil {
+			status = &permissionv1.NetworkPolicyStatus{Namespace: namespace}
+			report.NetworkPolicy = status
 		}
-		permissionBinder.Status.NetworkPolicies = append(permissionBinder.Status.NetworkPolicies, *status)
-	} else {
-		// Update existing status
 		status.State = state
-	}
-
-	// Update fields
-	if errorMessage != "" {
-		status.ErrorMessage = errorMessage
-	}
 
-	if state == "pr-created" || state == "pr-pending" {
-		if status.CreatedAt == "" {
-			status.CreatedAt = time.Now().Format(time.RFC3339)
+		// Update fields
+		if errorMessage != "" {
+			status.ErrorMessage = errorMessage
 		}
-	}
 
-	// Update status in cluster
-	if err := r.Status().Update(ctx, permissionBinder); err != nil {
+		if state == "pr-created" || state == "pr-pending" {
+			if status.CreatedAt == "" {
+				status.CreatedAt = time.Now().Format(time.RFC3339)
+			}
+		}
+	})
+	if err != nil {
 		logger.Error(err, "Failed to update NetworkPolicy status",
 			"namespace", namespace,
 			"state", state)
@@ -91,8 +97,8 @@ func updateNetworkPolicyStatus(r ReconcilerInterface,
 	return nil
 }
 
-// updateNetworkPolicyStatusWithPR updates NetworkPolicy status with PR information
-// Uses retry logic to handle race conditions with concurrent status updates
+// updateNetworkPolicyStatusWithPR updates NetworkPolicy status with PR information.
+// Concurrent writes to the report are retried on its latest version.
 func updateNetworkPolicyStatusWithPR(r ReconcilerInterface,
 	ctx context.Context,
 	permissionBinder *permissionv1.PermissionBinder,
@@ -104,33 +110,11 @@ func updateNetworkPolicyStatusWithPR(r ReconcilerInterface,
 ) error {
 	logger := log.FromContext(ctx)
 
-	// Retry logic to handle race conditions (max 3 attempts)
-	maxRetries := 3
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		// Get fresh copy of PermissionBinder to avoid stale data
-		key := client.ObjectKeyFromObject(permissionBinder)
-		var freshBinder permissionv1.PermissionBinder
-		if err := r.Get(ctx, key, &freshBinder); err != nil {
-			if attempt < maxRetries-1 {
-				time.Sleep(100 * time.Millisecond)
-				continue
-			}
-			return fmt.Errorf("failed to get fresh PermissionBinder: %w", err)
-		}
-
-		status := getNetworkPolicyStatus(&freshBinder, namespace)
+	_, err := reportstore.Update(ctx, r, permissionBinder, namespace, func(report *permissionv1.PermissionBinderReportStatus) {
+		status := report.NetworkPolicy
 		if status == nil {
-			// Create new status entry
-			newStatus := permissionv1.NetworkPolicyStatus{
-				Namespace: namespace,
-				State:     state,
-			}
-			freshBinder.Status.NetworkPolicies = append(freshBinder.Status.NetworkPolicies, newStatus)
-			// Get pointer to the newly added status
-			status = getNetworkPolicyStatus(&freshBinder, namespace)
-			if status == nil {
-				return fmt.Errorf("failed to get newly created status")
-			}
+			status = &permissionv1.NetworkPolicyStatus{Namespace: namespace}
+			report.NetworkPolicy = status
 		}
 
 		// Update status fields (preserve existing PR info if updating state only)
@@ -141,24 +125,12 @@ func updateNetworkPolicyStatusWithPR(r ReconcilerInterface,
 		if status.CreatedAt == "" {
 			status.CreatedAt = time.Now().Format(time.RFC3339)
 		}
-
-		// Update status in cluster
-		if err := r.Status().Update(ctx, &freshBinder); err != nil {
-			if attempt < maxRetries-1 {
-				logger.V(1).Info("Status update conflict, retrying", "attempt", attempt+1, "namespace", namespace)
-				time.Sleep(200 * time.Millisecond)
-				continue
-			}
-			logger.Error(err, "Failed to update NetworkPolicy status with PR after retries", "namespace", namespace)
-			return fmt.Errorf("failed to update status: %w", err)
-		}
-
-		// Success - update the passed-in permissionBinder to reflect changes
-		*permissionBinder = freshBinder
-		return nil
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update NetworkPolicy status with PR", "namespace", namespace)
+		return fmt.Errorf("failed to update status: %w", err)
 	}
-
-	return fmt.Errorf("failed to update status after %d attempts", maxRetries)
+	return nil
 }
 
 // CleanupStatus removes old status entries for namespaces that are no longer in the whitelist.
@@ -202,62 +174,49 @@ func CleanupStatus(
 
 	cutoffTime := time.Now().AddDate(0, 0, -int(retentionDays))
 
-	// Retry logic to handle race conditions (max 3 attempts)
-	maxRetries := 3
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		// Get fresh copy to avoid race conditions and preserve PR info
-		key := client.ObjectKeyFromObject(permissionBinder)
-		var freshBinder permissionv1.PermissionBinder
-		if err := r.Get(ctx, key, &freshBinder); err != nil {
-			if attempt < maxRetries-1 {
-				time.Sleep(100 * time.Millisecond)
-				continue
-			}
-			return fmt.Errorf("failed to get fresh PermissionBinder for cleanup: %w", err)
+	statuses, err := listNetworkPolicyStatuses(ctx, r, permissionBinder)
+	if err != nil {
+		return fmt.Errorf("failed to list NetworkPolicy status for cleanup: %w", err)
+	}
+
+	var failed int
+	for _, listed := range statuses {
+		// If namespace is in current namespaces - keep it (preserve all fields including PR info)
+		if currentNamespaces[listed.Namespace] {
+			continue
 		}
 
-		var cleanedStatus []permissionv1.NetworkPolicyStatus
-		for _, statusEntry := range freshBinder.Status.NetworkPolicies {
-			// If namespace is in current namespaces - keep it (preserve all fields including PR info)
-			if currentNamespaces[statusEntry.Namespace] {
-				cleanedStatus = append(cleanedStatus, statusEntry)
-				continue
+		_, err := reportstore.Update(ctx, r, permissionBinder, listed.Namespace, func(report *permissionv1.PermissionBinderReportStatus) {
+			statusEntry := report.NetworkPolicy
+			if statusEntry == nil {
+				return
 			}
 
 			// Namespace removed - check retention
 			if statusEntry.State == "removed" {
 				removedTime, err := time.Parse(time.RFC3339, statusEntry.RemovedAt)
-				if err == nil && removedTime.After(cutoffTime) {
-					// Still in retention period - keep it (preserve all fields)
-					cleanedStatus = append(cleanedStatus, statusEntry)
+				if err != nil || !removedTime.After(cutoffTime) {
+					// Outside retention period - remove it
+					report.NetworkPolicy = nil
 				}
-				// Outside retention period - remove it
-			} else {
-				// Namespace removed but not marked - mark as removed (preserve PR info)
-				statusEntry.State = "removed"
-				statusEntry.RemovedAt = time.Now().Format(time.RFC3339)
-				// Preserve PR info even when marking as removed
-				cleanedStatus = append(cleanedStatus, statusEntry)
+				// Still in retention period - keep it (preserve all fields)
+				return
 			}
-		}
 
-		freshBinder.Status.NetworkPolicies = cleanedStatus
-		if err := r.Status().Update(ctx, &freshBinder); err != nil {
-			if attempt < maxRetries-1 {
-				logger.V(1).Info("Cleanup status conflict, retrying", "attempt", attempt+1)
-				time.Sleep(200 * time.Millisecond)
-				continue
-			}
-			logger.Error(err, "Failed to cleanup status after retries")
-			return fmt.Errorf("failed to cleanup status: %w", err)
+			// Namespace removed but not marked - mark as removed (preserve PR info)
+			statusEntry.State = "removed"
+			statusEntry.RemovedAt = time.Now().Format(time.RFC3339)
+		})
+		if err != nil {
+			logger.Error(err, "Failed to cleanup NetworkPolicy status", "namespace", listed.Namespace)
+			failed++
 		}
-
-		// Success - update the passed-in permissionBinder to reflect changes
-		*permissionBinder = freshBinder
-		return nil
 	}
 
-	return fmt.Errorf("failed to cleanup status after %d attempts", maxRetries)
+	if failed > 0 {
+		return fmt.Errorf("failed to cleanup status of %d namespaces", failed)
+	}
+	return nil
 }
 
 // checkStalePRs checks for PRs that have been open for too long
@@ -280,8 +239,12 @@ func checkStalePRs(r ReconcilerInterface,
 
 	cutoffTime := time.Now().Add(-threshold)
 
-	for i := range permissionBinder.Status.NetworkPolicies {
-		statusEntry := &permissionBinder.Status.NetworkPolicies[i]
+	statuses, err := listNetworkPolicyStatuses(ctx, r, permissionBinder)
+	if err != nil {
+		return err
+	}
+
+	for _, statusEntry := range statuses {
 		if statusEntry.State == "pr-created" || statusEntry.State == "pr-pending" {
 			createdAt, err := time.Parse(time.RFC3339, statusEntry.CreatedAt)
 			if err == nil && createdAt.Before(cutoffTime) {
@@ -295,8 +258,12 @@ func checkStalePRs(r ReconcilerInterface,
 					"audit_trail", true)
 
 				// Update status
-				statusEntry.State = "pr-stale"
-				if err := r.Status().Update(ctx, permissionBinder); err != nil {
+				_, err := reportstore.Update(ctx, r, permissionBinder, statusEntry.Namespace, func(report *permissionv1.PermissionBinderReportStatus) {
+					if report.NetworkPolicy != nil {
+						report.NetworkPolicy.State = "pr-stale"
+					}
+				})
+				if err != nil {
 					logger.Error(err, "Failed to update status to stale",
 						"namespace", statusEntry.Namespace)
 				}
@@ -306,3 +273,19 @@ func checkStalePRs(r ReconcilerInterface,
 
 	return nil
 }
+
+// pendingPRStates are the NetworkPolicy states of namespaces with an open Pull Request
+var pendingPRStates = []string{"pr-created", "pr-pending", "pr-stale", "pr-removal"}
+
+// UpdatePendingPRsMetric sets NetworkPolicyPRsPending for the cluster of the
+// PermissionBinder from the NetworkPolicy state counts of its reports
+// (ReportsSummary.NetworkPolicies)
+func UpdatePendingPRsMetric(permissionBinder *permissionv1.PermissionBinder, states map[string]int) {
+	if permissionBinder.Spec.NetworkPolicy == nil || permissionBinder.Spec.NetworkPolicy.GitRepository == nil {
+		return
+	}
+	cluster := clusterNameFor(permissionBinder)
+	for _, state := range pendingPRStates {
+		NetworkPolicyPRsPending.WithLabelValues(cluster, state).Set(float64(states[state]))
+	}
+}
@@ -247,6 +247,16 @@ func isPolicyFromTemplate(policyName string, namespace string, templateName stri
 	return policyName == expectedName
 }
 
+// clusterNameFor returns the cluster name used in Git paths, branch names and
+// metrics labels: the cluster identity resolved by the reconciler, falling back
+// to gitRepository.clusterName
+func clusterNameFor(permissionBinder *permissionv1.PermissionBinder) string {
+	if identity := permissionBinder.Status.ClusterIdentity; identity != nil && identity.Name != "" {
+		return identity.Name
+	}
+	return permissionBinder.Spec.NetworkPolicy.GitRepository.ClusterName
+}
+
 // generateBranchName generates a unique branch name for NetworkPolicy PR
 func generateBranchName(clusterName string, namespace string) string {
 	return fmt.Sprintf("networkpolicy/%s/%s", clusterName, namespace)
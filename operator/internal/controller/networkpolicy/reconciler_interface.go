@@ -28,12 +28,14 @@ limitations under the License.
 package networkpolicy
 
 import (
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ReconcilerInterface defines the interface needed by NetworkPolicy functions.
 // It embeds client.Reader, client.Writer, and client.StatusClient to provide
-// a minimal interface for Kubernetes API operations.
+// a minimal interface for Kubernetes API operations, and exposes the
+// EventRecorder used to report Pull Request actions.
 //
 // This interface allows NetworkPolicy functions to work with any reconciler
 // that implements these methods, enabling better testability and separation
@@ -42,4 +44,8 @@ type ReconcilerInterface interface {
 	client.Reader
 	client.Writer
 	client.StatusClient
+
+	// EventRecorder returns the recorder for Kubernetes Events, nil when Events
+	// are not recorded
+	EventRecorder() record.EventRecorder
 }
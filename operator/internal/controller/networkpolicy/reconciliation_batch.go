@@ -85,7 +85,11 @@ func ProcessNetworkPoliciesForNamespaces(
 	// Filter namespaces - skip those that already have status (optimization)
 	var namespaceList []string
 	for _, ns := range namespaces {
-		if !hasNetworkPolicyStatus(permissionBinder, ns) {
+		hasStatus, err := hasNetworkPolicyStatus(ctx, r, permissionBinder, ns)
+		if err != nil {
+			return err
+		}
+		if !hasStatus {
 			namespaceList = append(namespaceList, ns)
 		} else {
 			logger.V(1).Info("Skipping namespace - already processed",
@@ -22,11 +22,34 @@ import (
 	"os"
 	"path/filepath"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
 )
 
+// RemovedNamespaces returns the namespaces ProcessRemovedNamespaces opens a removal
+// Pull Request for: they have NetworkPolicy status, are not in currentNamespaces and
+// are not marked "removed" yet.
+func RemovedNamespaces(
+	ctx context.Context,
+	r ReconcilerInterface,
+	permissionBinder *permissionv1.PermissionBinder,
+	currentNamespaces map[string]bool,
+) ([]string, error) {
+	statuses, err := listNetworkPolicyStatuses(ctx, r, permissionBinder)
+	if err != nil {
+		return nil, err
+	}
+	var removedNamespaces []string
+	for _, status := range statuses {
+		if !currentNamespaces[status.Namespace] && status.State != "removed" {
+			removedNamespaces = append(removedNamespaces, status.Namespace)
+		}
+	}
+	return removedNamespaces, nil
+}
+
 // ProcessRemovedNamespaces handles cleanup for namespaces that were removed from the whitelist.
 //
 // When a namespace is removed from the PermissionBinder whitelist, this function:
@@ -63,11 +86,9 @@ func ProcessRemovedNamespaces(
 	logger := log.FromContext(ctx)
 
 	// Find namespaces that were removed
-	removedNamespaces := make([]string, 0)
-	for _, status := range permissionBinder.Status.NetworkPolicies {
-		if !currentNamespaces[status.Namespace] && status.State != "removed" {
-			removedNamespaces = append(removedNamespaces, status.Namespace)
-		}
+	removedNamespaces, err := RemovedNamespaces(ctx, r, permissionBinder, currentNamespaces)
+	if err != nil {
+		return err
 	}
 
 	if len(removedNamespaces) == 0 {
@@ -78,7 +99,7 @@ func ProcessRemovedNamespaces(
 		"count", len(removedNamespaces))
 
 	gitRepo := permissionBinder.Spec.NetworkPolicy.GitRepository
-	clusterName := gitRepo.ClusterName
+	clusterName := clusterNameFor(permissionBinder)
 	baseBranch := gitRepo.BaseBranch
 
 	// Get TLS verify setting (default: true for security)
@@ -177,6 +198,9 @@ func ProcessRemovedNamespaces(
 			continue
 		}
 
+		recordEvent(ctx, r, permissionBinder, namespace, corev1.EventTypeNormal, EventReasonPullRequestCreated,
+			"Created NetworkPolicy removal Pull Request #%d for namespace %s: %s", pr.Number, namespace, pr.URL)
+
 		// Update status
 		if err := updateNetworkPolicyStatusWithPR(r, ctx, permissionBinder, namespace, pr.Number, branchName, pr.URL, "pr-removal"); err != nil {
 			logger.Error(err, "Failed to update status", "namespace", namespace)
@@ -62,9 +62,13 @@ func PeriodicNetworkPolicyReconciliation(
 ) error {
 	logger := log.FromContext(ctx)
 
-	// Get all managed namespaces from status
+	// Get all managed namespaces from the reports
+	statuses, err := listNetworkPolicyStatuses(ctx, r, permissionBinder)
+	if err != nil {
+		return err
+	}
 	managedNamespaces := make([]string, 0)
-	for _, status := range permissionBinder.Status.NetworkPolicies {
+	for _, status := range statuses {
 		if status.State == "pr-merged" {
 			managedNamespaces = append(managedNamespaces, status.Namespace)
 		}
@@ -134,8 +138,12 @@ func checkTemplateChanges(r ReconcilerInterface, ctx context.Context, permission
 	logger := log.FromContext(ctx)
 
 	// Get all managed namespaces
+	statuses, err := listNetworkPolicyStatuses(ctx, r, permissionBinder)
+	if err != nil {
+		return err
+	}
 	managedNamespaces := make([]string, 0)
-	for _, status := range permissionBinder.Status.NetworkPolicies {
+	for _, status := range statuses {
 		if status.State == "pr-merged" {
 			managedNamespaces = append(managedNamespaces, status.Namespace)
 		}
@@ -29,6 +29,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -75,7 +76,7 @@ func ProcessNetworkPolicyForNamespace(
 	logger := log.FromContext(ctx)
 
 	gitRepo := permissionBinder.Spec.NetworkPolicy.GitRepository
-	clusterName := gitRepo.ClusterName
+	clusterName := clusterNameFor(permissionBinder)
 	templateDir := permissionBinder.Spec.NetworkPolicy.TemplateDir
 	baseBranch := gitRepo.BaseBranch
 	backupExisting := permissionBinder.Spec.NetworkPolicy.BackupExisting
@@ -412,6 +413,8 @@ func ProcessNetworkPolicyForNamespace(
 			"repoURL", sanitizedRepoURL)
 		return fmt.Errorf("failed to create PR: %w", sanitizedErr)
 	}
+	recordEvent(ctx, r, permissionBinder, namespace, corev1.EventTypeNormal, EventReasonPullRequestCreated,
+		"Created NetworkPolicy Pull Request #%d (%s) for namespace %s: %s", pr.Number, variant, namespace, pr.URL)
 
 	// Auto-merge PR if enabled
 	if autoMerge && permissionBinder.Spec.NetworkPolicy.AutoMerge != nil && permissionBinder.Spec.NetworkPolicy.AutoMerge.Enabled {
@@ -424,6 +427,8 @@ func ProcessNetworkPolicyForNamespace(
 			// Continue - PR is still created, just not merged
 		} else {
 			logger.Info("Auto-merged NetworkPolicy PR", "prNumber", pr.Number, "prURL", pr.URL)
+			recordEvent(ctx, r, permissionBinder, namespace, corev1.EventTypeNormal, EventReasonPullRequestMerged,
+				"Auto-merged NetworkPolicy Pull Request #%d for namespace %s: %s", pr.Number, namespace, pr.URL)
 		}
 	}
 
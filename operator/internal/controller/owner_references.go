@@ -0,0 +1,364 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// BindingLedgerFinalizer keeps a BindingLedger until the operator has removed the
+	// ownerReferences to it, so that deleting a ledger never garbage collects the
+	// RoleBindings and ServiceAccounts it owns
+	BindingLedgerFinalizer = "permission-binder.io/ledger-protection"
+)
+
+// bindingLedgerName returns the name of the BindingLedger of a PermissionBinder.
+// Namespace names cannot contain dots, so the name is unambiguous. Names longer
+// than an object name allows are cut and get a hash of the full name as suffix.
+func bindingLedgerName(pb *permissionv1.PermissionBinder) string {
+	name := pb.Namespace + "." + pb.Name
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	suffix := "-" + hex.EncodeToString(sum[:])[:10]
+	// A label of a DNS subdomain must not end in a dash or a dot
+	return strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(suffix)], ".-") + suffix
+}
+
+// isOwnerReferenceOf reports whether ref points to the PermissionBinder or its
+// BindingLedger. Matching by name rather than UID also catches references to a
+// recreated owner, which garbage collection would otherwise act on.
+func isOwnerReferenceOf(ref metav1.OwnerReference, pb *permissionv1.PermissionBinder, objectNamespace string) bool {
+	if ref.APIVersion != permissionv1.GroupVersion.String() {
+		return false
+	}
+	switch ref.Kind {
+	case "PermissionBinder":
+		return ref.Name == pb.Name && objectNamespace == pb.Namespace
+	case "BindingLedger":
+		return ref.Name == bindingLedgerName(pb)
+	}
+	return false
+}
+
+// setOwnerReference makes desired the only ownerReference of obj to the
+// PermissionBinder or its BindingLedger (none for a nil desired), keeping all
+// other references; obj is patched only when its references change
+func (r *PermissionBinderReconciler) setOwnerReference(ctx context.Context, obj client.Object, pb *permissionv1.PermissionBinder, desired *metav1.OwnerReference) error {
+	refs := obj.GetOwnerReferences()
+	kept := make([]metav1.OwnerReference, 0, len(refs)+1)
+	found, changed := false, false
+	for _, ref := range refs {
+		if desired != nil && ref.UID == desired.UID && !found {
+			found = true
+			if !reflect.DeepEqual(ref, *desired) {
+				// e.g. blockOwnerDeletion not set explicitly by earlier versions
+				ref, changed = *desired, true
+			}
+			kept = append(kept, ref)
+			continue
+		}
+		if isOwnerReferenceOf(ref, pb, obj.GetNamespace()) {
+			continue
+		}
+		kept = append(kept, ref)
+	}
+	if desired != nil && !found {
+		kept = append(kept, *desired)
+	}
+	if !changed && len(kept) == len(refs) && (desired == nil || found) {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	obj.SetOwnerReferences(kept)
+	if err := r.Patch(ctx, obj, patch); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to set ownerReferences of %s %s/%s: %w",
+			objectKind(r.Client, obj), obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// getBindingLedger returns the BindingLedger of the PermissionBinder, nil when it does not exist
+func (r *PermissionBinderReconciler) getBindingLedger(ctx context.Context, pb *permissionv1.PermissionBinder) (*permissionv1.BindingLedger, error) {
+	var ledger permissionv1.BindingLedger
+	if err := r.Get(ctx, types.NamespacedName{Name: bindingLedgerName(pb)}, &ledger); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get BindingLedger %s: %w", bindingLedgerName(pb), err)
+	}
+	return &ledger, nil
+}
+
+// ensureBindingLedger returns the BindingLedger of the PermissionBinder, creating it
+// if needed. A ledger being deleted is released (its ownerReferences removed, then
+// its finalizer) and nil is returned; the next reconciliation creates a new one.
+func (r *PermissionBinderReconciler) ensureBindingLedger(ctx context.Context, pb *permissionv1.PermissionBinder) (*permissionv1.BindingLedger, error) {
+	logger := log.FromContext(ctx)
+	ledger, err := r.getBindingLedger(ctx, pb)
+	if err != nil {
+		return nil, err
+	}
+	if ledger == nil {
+		ledger = &permissionv1.BindingLedger{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       bindingLedgerName(pb),
+				Labels:     map[string]string{LabelManagedBy: ManagedByValue},
+				Finalizers: []string{BindingLedgerFinalizer},
+			},
+			Spec: permissionv1.BindingLedgerSpec{
+				PermissionBinder: permissionv1.PermissionBinderReference{Name: pb.Name, Namespace: pb.Namespace},
+			},
+		}
+		if err := r.Create(ctx, ledger); err != nil {
+			return nil, fmt.Errorf("failed to create BindingLedger %s: %w", ledger.Name, err)
+		}
+		logger.Info("Created BindingLedger", "bindingLedger", ledger.Name)
+		return ledger, nil
+	}
+	if !ledger.DeletionTimestamp.IsZero() {
+		logger.Info("BindingLedger is being deleted - releasing the resources it owns", "bindingLedger", ledger.Name)
+		if err := r.releaseBindingLedger(ctx, pb, ledger); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return ledger, nil
+}
+
+// releaseBindingLedger removes the ownerReferences to the ledger, then its finalizer
+func (r *PermissionBinderReconciler) releaseBindingLedger(ctx context.Context, pb *permissionv1.PermissionBinder, ledger *permissionv1.BindingLedger) error {
+	if err := r.releaseBindingLedgerReferences(ctx, pb); err != nil {
+		return err
+	}
+	if containsString(ledger.Finalizers, BindingLedgerFinalizer) {
+		ledger.Finalizers = removeString(ledger.Finalizers, BindingLedgerFinalizer)
+		if err := r.Update(ctx, ledger); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove the finalizer of BindingLedger %s: %w", ledger.Name, err)
+		}
+	}
+	return nil
+}
+
+// releaseBindingLedgerReferences removes the ownerReferences to the ledger, keeping
+// those to the PermissionBinder itself
+func (r *PermissionBinderReconciler) releaseBindingLedgerReferences(ctx context.Context, pb *permissionv1.PermissionBinder) error {
+	return r.forEachOwnedCandidate(ctx, func(obj client.Object) error {
+		refs := obj.GetOwnerReferences()
+		for _, ref := range refs {
+			if ref.Kind == "BindingLedger" && isOwnerReferenceOf(ref, pb, obj.GetNamespace()) {
+				// Keep a reference to the PermissionBinder itself
+				var keep *metav1.OwnerReference
+				for i := range refs {
+					if refs[i].Kind == "PermissionBinder" && isOwnerReferenceOf(refs[i], pb, obj.GetNamespace()) {
+						keep = &refs[i]
+					}
+				}
+				return r.setOwnerReference(ctx, obj, pb, keep)
+			}
+		}
+		return nil
+	})
+}
+
+// forEachOwnedCandidate calls fn for every RoleBinding and ServiceAccount - the
+// kinds that get ownerReferences
+func (r *PermissionBinderReconciler) forEachOwnedCandidate(ctx context.Context, fn func(client.Object) error) error {
+	var roleBindings rbacv1.RoleBindingList
+	if err := r.List(ctx, &roleBindings); err != nil {
+		return fmt.Errorf("failed to list RoleBindings: %w", err)
+	}
+	for i := range roleBindings.Items {
+		if err := fn(&roleBindings.Items[i]); err != nil {
+			return err
+		}
+	}
+	var serviceAccounts corev1.ServiceAccountList
+	if err := r.List(ctx, &serviceAccounts); err != nil {
+		return fmt.Errorf("failed to list ServiceAccounts: %w", err)
+	}
+	for i := range serviceAccounts.Items {
+		if err := fn(&serviceAccounts.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileOwnerReferences sets the ownerReferences of spec.ownerReferences: the
+// PermissionBinder owns its RoleBindings and ServiceAccounts in its own namespace,
+// the BindingLedger those in other namespaces. Resources that are no longer owned
+// (handed over, or the mode is disabled) lose the references.
+func (r *PermissionBinderReconciler) reconcileOwnerReferences(ctx context.Context, pb *permissionv1.PermissionBinder) (*permissionv1.OwnerReferencesStatus, error) {
+	if !pb.Spec.OwnerReferences {
+		if pb.Status.OwnerReferences != nil {
+			log.FromContext(ctx).Info("ownerReferences disabled - removing them")
+			if err := r.releaseOwnerReferences(ctx, pb); err != nil {
+				return pb.Status.OwnerReferences, err
+			}
+		}
+		return nil, nil
+	}
+
+	ledger, err := r.ensureBindingLedger(ctx, pb)
+	if err != nil {
+		return nil, err
+	}
+	apiVersion := permissionv1.GroupVersion.String()
+	// Never blockOwnerDeletion: a foreground deletion of the owner must not wait for
+	// (and so must not make garbage collection prioritise) the dependents
+	blockOwnerDeletion := false
+	pbRef := metav1.OwnerReference{APIVersion: apiVersion, Kind: "PermissionBinder", Name: pb.Name, UID: pb.UID,
+		BlockOwnerDeletion: &blockOwnerDeletion}
+	var ledgerRef *metav1.OwnerReference
+	if ledger != nil {
+		ledgerRef = &metav1.OwnerReference{APIVersion: apiVersion, Kind: "BindingLedger", Name: ledger.Name, UID: ledger.UID,
+			BlockOwnerDeletion: &blockOwnerDeletion}
+	}
+
+	status := &permissionv1.OwnerReferencesStatus{Ledger: bindingLedgerName(pb)}
+	var ledgerStatus permissionv1.BindingLedgerStatus
+	err = r.forEachOwnedCandidate(ctx, func(obj client.Object) error {
+		var desired *metav1.OwnerReference
+		if isOwnedByPermissionBinder(obj.GetAnnotations(), pb) {
+			switch {
+			case obj.GetNamespace() == pb.Namespace:
+				desired = &pbRef
+				status.OwnedByPermissionBinder++
+			case ledgerRef != nil:
+				desired = ledgerRef
+				status.OwnedByLedger++
+				if _, ok := obj.(*rbacv1.RoleBinding); ok {
+					ledgerStatus.RoleBindings++
+				} else {
+					ledgerStatus.ServiceAccounts++
+				}
+			}
+		}
+		return r.setOwnerReference(ctx, obj, pb, desired)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ledger != nil && ledger.Status != ledgerStatus {
+		ledger.Status = ledgerStatus
+		if err := r.Status().Update(ctx, ledger); err != nil {
+			return nil, fmt.Errorf("failed to update BindingLedger %s status: %w", ledger.Name, err)
+		}
+	}
+	return status, nil
+}
+
+// releaseOwnerReferences removes all ownerReferences to the PermissionBinder and its
+// BindingLedger and deletes the ledger. Called before the PermissionBinder is deleted
+// so that garbage collection never removes what SAFE MODE preserves.
+func (r *PermissionBinderReconciler) releaseOwnerReferences(ctx context.Context, pb *permissionv1.PermissionBinder) error {
+	if err := r.forEachOwnedCandidate(ctx, func(obj client.Object) error {
+		return r.setOwnerReference(ctx, obj, pb, nil)
+	}); err != nil {
+		return err
+	}
+
+	ledger, err := r.getBindingLedger(ctx, pb)
+	if err != nil || ledger == nil {
+		return err
+	}
+	if err := r.releaseBindingLedger(ctx, pb, ledger); err != nil {
+		return err
+	}
+	if ledger.DeletionTimestamp.IsZero() {
+		if err := r.Delete(ctx, ledger); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete BindingLedger %s: %w", ledger.Name, err)
+		}
+	}
+	log.FromContext(ctx).Info("Released ownerReferences", "bindingLedger", ledger.Name)
+	return nil
+}
+
+// releaseDeletingOwners removes the ownerReferences to a PermissionBinder or
+// BindingLedger that is being deleted. It runs before anything else in a
+// reconciliation, frozen or not: with foreground deletion (the foregroundDeletion
+// finalizer) garbage collection starts deleting the dependents right away, so the
+// references must go before the regular cleanup gets to them.
+func (r *PermissionBinderReconciler) releaseDeletingOwners(ctx context.Context, pb *permissionv1.PermissionBinder) error {
+	logger := log.FromContext(ctx)
+	if !pb.DeletionTimestamp.IsZero() {
+		if containsString(pb.Finalizers, metav1.FinalizerDeleteDependents) {
+			logger.Info("⚠️  PermissionBinder is deleted in the foreground - releasing ownerReferences first")
+		}
+		return r.releaseOwnerReferences(ctx, pb)
+	}
+	if !pb.Spec.OwnerReferences && pb.Status.OwnerReferences == nil {
+		return nil
+	}
+	ledger, err := r.getBindingLedger(ctx, pb)
+	if err != nil || ledger == nil || ledger.DeletionTimestamp.IsZero() {
+		return err
+	}
+	if containsString(ledger.Finalizers, metav1.FinalizerDeleteDependents) {
+		logger.Info("⚠️  BindingLedger is deleted in the foreground - releasing ownerReferences first",
+			"bindingLedger", ledger.Name)
+	}
+	// The finalizer goes in ensureBindingLedger, which then skips a ledger for this pass
+	return r.releaseBindingLedgerReferences(ctx, pb)
+}
+
+// ownerReferencesUpToDate reports whether spec.ownerReferences needs no work
+// without a whitelist change: the mode was not toggled and the ledger is intact
+func (r *PermissionBinderReconciler) ownerReferencesUpToDate(ctx context.Context, pb *permissionv1.PermissionBinder) bool {
+	if pb.Spec.OwnerReferences != (pb.Status.OwnerReferences != nil) {
+		return false
+	}
+	if !pb.Spec.OwnerReferences {
+		return true
+	}
+	ledger, err := r.getBindingLedger(ctx, pb)
+	return err == nil && ledger != nil && ledger.DeletionTimestamp.IsZero()
+}
+
+// mapBindingLedgerToPermissionBinder enqueues the PermissionBinder of a BindingLedger
+func (r *PermissionBinderReconciler) mapBindingLedgerToPermissionBinder(_ context.Context, obj client.Object) []reconcile.Request {
+	ledger, ok := obj.(*permissionv1.BindingLedger)
+	if !ok || !r.reconcilesNamespace(ledger.Spec.PermissionBinder.Namespace) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      ledger.Spec.PermissionBinder.Name,
+		Namespace: ledger.Spec.PermissionBinder.Namespace,
+	}}}
+}
@@ -0,0 +1,291 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestReconcileOwnerReferences(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 1, UID: "pb-uid",
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin"},
+			ClusterName:        "prod",
+			OwnerReferences:    true,
+		},
+	}
+	whitelist := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data: map[string]string{"whitelist.txt": "CN=COMPANY-K8S-operators-admin,OU=K8S,DC=example,DC=com\n" +
+			"CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, whitelist).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.BindingLedger{}, &permissionv1.PermissionBinderReport{}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(pb)
+	ledgerKey := types.NamespacedName{Name: "operators.team-a"}
+
+	reconcile := func() permissionv1.PermissionBinder {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got permissionv1.PermissionBinder
+		if err := k8sClient.Get(ctx, key, &got); err != nil && !errors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return got
+	}
+	ownerKinds := func(namespace, name string) []string {
+		t.Helper()
+		var roleBinding rbacv1.RoleBinding
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &roleBinding); err != nil {
+			t.Fatal(err)
+		}
+		var kinds []string
+		for _, ref := range roleBinding.OwnerReferences {
+			if ref.Controller != nil || ref.BlockOwnerDeletion == nil || *ref.BlockOwnerDeletion {
+				t.Errorf("ownerReference %+v must be neither controller nor block owner deletion", ref)
+			}
+			kinds = append(kinds, ref.Kind)
+		}
+		return kinds
+	}
+	update := func(mutate func(*permissionv1.PermissionBinder)) {
+		t.Helper()
+		var current permissionv1.PermissionBinder
+		if err := k8sClient.Get(ctx, key, &current); err != nil {
+			t.Fatal(err)
+		}
+		mutate(&current)
+		if err := k8sClient.Update(ctx, &current); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The PermissionBinder owns its own namespace, the ledger the others
+	got := reconcile()
+	want := permissionv1.OwnerReferencesStatus{Ledger: "operators.team-a", OwnedByPermissionBinder: 1, OwnedByLedger: 1}
+	if got.Status.OwnerReferences == nil || *got.Status.OwnerReferences != want {
+		t.Fatalf("ownerReferences = %+v, want %+v", got.Status.OwnerReferences, want)
+	}
+	if kinds := ownerKinds("operators", "operators-admin"); len(kinds) != 1 || kinds[0] != "PermissionBinder" {
+		t.Errorf("operators/operators-admin owners = %v, want the PermissionBinder", kinds)
+	}
+	if kinds := ownerKinds("payments", "payments-admin"); len(kinds) != 1 || kinds[0] != "BindingLedger" {
+		t.Errorf("payments/payments-admin owners = %v, want the BindingLedger", kinds)
+	}
+	var ledger permissionv1.BindingLedger
+	if err := k8sClient.Get(ctx, ledgerKey, &ledger); err != nil {
+		t.Fatal(err)
+	}
+	if !containsString(ledger.Finalizers, BindingLedgerFinalizer) || ledger.Status.RoleBindings != 1 ||
+		ledger.Spec.PermissionBinder.Name != "team-a" {
+		t.Errorf("ledger = %+v, want the finalizer, the PermissionBinder and 1 RoleBinding", ledger)
+	}
+
+	// Deleting the ledger releases what it owns instead of garbage collecting it
+	if err := k8sClient.Delete(ctx, &ledger); err != nil {
+		t.Fatal(err)
+	}
+	reconcile()
+	if kinds := ownerKinds("payments", "payments-admin"); len(kinds) != 0 {
+		t.Errorf("payments/payments-admin owners = %v, want none after the ledger was deleted", kinds)
+	}
+	if err := k8sClient.Get(ctx, ledgerKey, &ledger); !errors.IsNotFound(err) {
+		t.Errorf("the deleted ledger must be released, got %v", err)
+	}
+	reconcile()
+	if kinds := ownerKinds("payments", "payments-admin"); len(kinds) != 1 || kinds[0] != "BindingLedger" {
+		t.Errorf("payments/payments-admin owners = %v, want a new BindingLedger", kinds)
+	}
+
+	// Disabling removes the references and the ledger
+	update(func(pb *permissionv1.PermissionBinder) {
+		pb.Spec.OwnerReferences = false
+		pb.Generation++
+	})
+	if got := reconcile(); got.Status.OwnerReferences != nil {
+		t.Errorf("ownerReferences = %+v, want nil when disabled", got.Status.OwnerReferences)
+	}
+	if kinds := ownerKinds("operators", "operators-admin"); len(kinds) != 0 {
+		t.Errorf("operators/operators-admin owners = %v, want none when disabled", kinds)
+	}
+	if err := k8sClient.Get(ctx, ledgerKey, &ledger); !errors.IsNotFound(err) {
+		t.Errorf("ledger must be deleted when disabled, got %v", err)
+	}
+
+	// SAFE MODE: deleting the PermissionBinder releases the references first
+	update(func(pb *permissionv1.PermissionBinder) {
+		pb.Spec.OwnerReferences = true
+		pb.Generation++
+	})
+	reconcile()
+	var current permissionv1.PermissionBinder
+	if err := k8sClient.Get(ctx, key, &current); err != nil {
+		t.Fatal(err)
+	}
+	if err := k8sClient.Delete(ctx, &current); err != nil {
+		t.Fatal(err)
+	}
+	reconcile()
+	for _, roleBinding := range [][2]string{{"operators", "operators-admin"}, {"payments", "payments-admin"}} {
+		if kinds := ownerKinds(roleBinding[0], roleBinding[1]); len(kinds) != 0 {
+			t.Errorf("%s/%s owners = %v, want none after the PermissionBinder was deleted", roleBinding[0], roleBinding[1], kinds)
+		}
+	}
+	if err := k8sClient.Get(ctx, ledgerKey, &ledger); !errors.IsNotFound(err) {
+		t.Errorf("ledger must be deleted with the PermissionBinder, got %v", err)
+	}
+}
+
+func TestBindingLedgerName(t *testing.T) {
+	if got := bindingLedgerName(newPermissionBinder("operators", "team-a")); got != "operators.team-a" {
+		t.Errorf("bindingLedgerName() = %q, want operators.team-a", got)
+	}
+
+	namespace := strings.Repeat("n", 63)
+	long := bindingLedgerName(newPermissionBinder(namespace, strings.Repeat("a", 240)))
+	other := bindingLedgerName(newPermissionBinder(namespace, strings.Repeat("a", 239)+"b"))
+	if errs := validation.IsDNS1123Subdomain(long); len(errs) > 0 {
+		t.Errorf("bindingLedgerName() = %q is not a valid object name: %v", long, errs)
+	}
+	if long == other {
+		t.Errorf("PermissionBinders differing past the cut share the ledger name %q", long)
+	}
+}
+
+func TestSetOwnerReferenceKeepsForeignReferences(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := newPermissionBinder("operators", "team-a")
+	foreign := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", UID: "app-uid"}
+	stale := metav1.OwnerReference{APIVersion: permissionv1.GroupVersion.String(), Kind: "BindingLedger", Name: "operators.team-a", UID: "old-uid"}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name: "payments-admin", Namespace: "payments",
+		OwnerReferences: []metav1.OwnerReference{foreign, stale},
+	}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(roleBinding).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+
+	desired := metav1.OwnerReference{APIVersion: permissionv1.GroupVersion.String(), Kind: "BindingLedger", Name: "operators.team-a", UID: "new-uid"}
+	if err := r.setOwnerReference(context.Background(), roleBinding, pb, &desired); err != nil {
+		t.Fatal(err)
+	}
+	var got rbacv1.RoleBinding
+	if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(roleBinding), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.OwnerReferences) != 2 || got.OwnerReferences[0] != foreign || got.OwnerReferences[1] != desired {
+		t.Errorf("ownerReferences = %+v, want the foreign and the new ledger reference", got.OwnerReferences)
+	}
+}
+
+func TestForegroundDeletionReleasesOwnerReferences(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	apiVersion := permissionv1.GroupVersion.String()
+	deletedAt := metav1.Now()
+	roleBinding := func(namespace string, owner metav1.OwnerReference) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace + "-admin", Namespace: namespace,
+				Labels: map[string]string{LabelManagedBy: ManagedByValue},
+				Annotations: map[string]string{
+					AnnotationPermissionBinder:          "team-a",
+					AnnotationPermissionBinderNamespace: "operators",
+				},
+				OwnerReferences: []metav1.OwnerReference{owner},
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+		}
+	}
+	ownerReferences := func(k8sClient client.Client, namespace string) []metav1.OwnerReference {
+		t.Helper()
+		var got rbacv1.RoleBinding
+		if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: namespace + "-admin", Namespace: namespace}, &got); err != nil {
+			t.Fatal(err)
+		}
+		return got.OwnerReferences
+	}
+
+	t.Run("PermissionBinder deleted in the foreground while frozen", func(t *testing.T) {
+		pb := &permissionv1.PermissionBinder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "team-a", Namespace: "operators", UID: "pb-uid", DeletionTimestamp: &deletedAt,
+				Finalizers: []string{metav1.FinalizerDeleteDependents, PermissionBinderFinalizer},
+			},
+			Spec: permissionv1.PermissionBinderSpec{OwnerReferences: true, Paused: true},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb,
+			roleBinding("operators", metav1.OwnerReference{APIVersion: apiVersion, Kind: "PermissionBinder", Name: "team-a", UID: "pb-uid"}),
+		).WithStatusSubresource(&permissionv1.PermissionBinder{}).Build()
+		r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pb)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if refs := ownerReferences(k8sClient, "operators"); len(refs) != 0 {
+			t.Errorf("ownerReferences = %+v, want them released although the cleanup is deferred", refs)
+		}
+		var got permissionv1.PermissionBinder
+		if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pb), &got); err != nil {
+			t.Fatal(err)
+		}
+		if !containsString(got.Finalizers, PermissionBinderFinalizer) {
+			t.Error("the cleanup finalizer must be kept while frozen")
+		}
+	})
+
+	t.Run("BindingLedger deleted in the foreground", func(t *testing.T) {
+		pb := newPermissionBinder("operators", "team-a")
+		pb.Spec.OwnerReferences = true
+		pb.Status.OwnerReferences = &permissionv1.OwnerReferencesStatus{Ledger: "operators.team-a", OwnedByLedger: 1}
+		ledger := &permissionv1.BindingLedger{ObjectMeta: metav1.ObjectMeta{
+			Name: "operators.team-a", UID: "ledger-uid", DeletionTimestamp: &deletedAt,
+			Finalizers: []string{metav1.FinalizerDeleteDependents, BindingLedgerFinalizer},
+		}}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, ledger,
+			roleBinding("payments", metav1.OwnerReference{APIVersion: apiVersion, Kind: "BindingLedger", Name: "operators.team-a", UID: "ledger-uid"}),
+		).Build()
+		r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+
+		if err := r.releaseDeletingOwners(context.Background(), pb); err != nil {
+			t.Fatal(err)
+		}
+		if refs := ownerReferences(k8sClient, "payments"); len(refs) != 0 {
+			t.Errorf("ownerReferences = %+v, want the ledger reference released", refs)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// OwnershipConflictCondition is True while whitelist entries cannot take
+	// effect because their resources are claimed by other PermissionBinders
+	OwnershipConflictCondition = "OwnershipConflict"
+
+	// EventReasonOwnershipConflict is the reason of the Events of a refused takeover
+	EventReasonOwnershipConflict = "OwnershipConflict"
+
+	// maxReportedOwnershipConflicts bounds status.ownershipConflicts
+	maxReportedOwnershipConflicts = 100
+)
+
+// claimedBy returns the PermissionBinder claiming a resource, "namespace/name"
+// or the bare name for legacy name-only claims
+func claimedBy(annotations map[string]string) string {
+	if namespace := annotations[AnnotationPermissionBinderNamespace]; namespace != "" {
+		return namespace + "/" + annotations[AnnotationPermissionBinder]
+	}
+	return annotations[AnnotationPermissionBinder]
+}
+
+// recordOwnershipConflict reports a refused takeover of obj with a Warning Event on
+// both the PermissionBinder and the contested object, and returns its status entry
+func (r *PermissionBinderReconciler) recordOwnershipConflict(permissionBinder *permissionv1.PermissionBinder, kind string, obj client.Object) *permissionv1.OwnershipConflict {
+	conflict := &permissionv1.OwnershipConflict{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		ClaimedBy: claimedBy(obj.GetAnnotations()),
+	}
+	if r.Recorder != nil {
+		resource := conflict.Name
+		if conflict.Namespace != "" {
+			resource = conflict.Namespace + "/" + conflict.Name
+		}
+		claim := "is not managed by a PermissionBinder"
+		if conflict.ClaimedBy != "" {
+			claim = "is claimed by PermissionBinder " + conflict.ClaimedBy
+		}
+		r.Recorder.Eventf(permissionBinder, corev1.EventTypeWarning, EventReasonOwnershipConflict,
+			"%s %s %s", kind, resource, claim)
+		r.Recorder.Eventf(obj, corev1.EventTypeWarning, EventReasonOwnershipConflict,
+			"PermissionBinder %s/%s refused to take over this %s: it %s",
+			permissionBinder.Namespace, permissionBinder.Name, kind, claim)
+	}
+	return conflict
+}
+
+// boundedOwnershipConflicts sorts the conflicts and bounds them to maxReportedOwnershipConflicts
+func boundedOwnershipConflicts(conflicts []permissionv1.OwnershipConflict) []permissionv1.OwnershipConflict {
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Kind != conflicts[j].Kind {
+			return conflicts[i].Kind < conflicts[j].Kind
+		}
+		if conflicts[i].Namespace != conflicts[j].Namespace {
+			return conflicts[i].Namespace < conflicts[j].Namespace
+		}
+		return conflicts[i].Name < conflicts[j].Name
+	})
+	if len(conflicts) > maxReportedOwnershipConflicts {
+		conflicts = conflicts[:maxReportedOwnershipConflicts]
+	}
+	return conflicts
+}
+
+// ownershipConflictCondition returns the OwnershipConflict condition for the
+// conflicts found during a reconciliation
+func ownershipConflictCondition(generation int64, conflicts int) metav1.Condition {
+	if conflicts == 0 {
+		return metav1.Condition{
+			Type:               OwnershipConflictCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoConflicts",
+			Message:            "All whitelist resources are owned by this PermissionBinder",
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               OwnershipConflictCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ClaimedByAnotherPermissionBinder",
+		Message:            fmt.Sprintf("%d resources are claimed by other PermissionBinders, see status.ownershipConflicts", conflicts),
+		ObservedGeneration: generation,
+	}
+}
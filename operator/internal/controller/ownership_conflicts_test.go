@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestOwnershipConflictReport(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	claimed := map[string]string{
+		AnnotationPermissionBinder:          "team-b",
+		AnnotationPermissionBinderNamespace: "operators",
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Annotations: claimed}},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments-admin", Namespace: "payments", Annotations: claimed},
+			RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+		},
+	).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme, Recorder: recorder}
+	pb := newPermissionBinder("operators", "team-a")
+	ctx := context.Background()
+
+	conflict, err := r.ensureNamespace(ctx, "payments", pb)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := permissionv1.OwnershipConflict{Kind: "Namespace", Name: "payments", ClaimedBy: "operators/team-b"}
+	if conflict == nil || *conflict != want {
+		t.Errorf("ensureNamespace() conflict = %+v, want %+v", conflict, want)
+	}
+
+	conflict, err = r.createRoleBinding(ctx, "payments", "payments-admin", "admin", "COMPANY-K8S-payments-admin", "admin", pb)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = permissionv1.OwnershipConflict{Kind: "RoleBinding", Namespace: "payments", Name: "payments-admin", ClaimedBy: "operators/team-b"}
+	if conflict == nil || *conflict != want {
+		t.Errorf("createRoleBinding() conflict = %+v, want %+v", conflict, want)
+	}
+
+	// One Event on the PermissionBinder and one on the contested object per refusal
+	if len(recorder.Events) != 4 {
+		t.Fatalf("got %d events, want 4", len(recorder.Events))
+	}
+	for i := 0; i < 4; i++ {
+		event := <-recorder.Events
+		if !strings.HasPrefix(event, "Warning OwnershipConflict") || !strings.Contains(event, "operators/team-b") {
+			t.Errorf("event %q, want a Warning naming the claiming PermissionBinder", event)
+		}
+	}
+
+	t.Run("legacy name-only claim", func(t *testing.T) {
+		if got := claimedBy(map[string]string{AnnotationPermissionBinder: "team-b"}); got != "team-b" {
+			t.Errorf("claimedBy() = %q, want team-b", got)
+		}
+	})
+}
+
+func TestOwnershipConflictStatus(t *testing.T) {
+	var conflicts []permissionv1.OwnershipConflict
+	for i := maxReportedOwnershipConflicts + 4; i > 0; i-- {
+		conflicts = append(conflicts, permissionv1.OwnershipConflict{
+			Kind: "RoleBinding", Namespace: fmt.Sprintf("ns-%03d", i), Name: "admin", ClaimedBy: "operators/team-b",
+		})
+	}
+	conflicts = append(conflicts, permissionv1.OwnershipConflict{Kind: "Namespace", Name: "ns-999", ClaimedBy: "operators/team-b"})
+
+	bounded := boundedOwnershipConflicts(conflicts)
+	if len(bounded) != maxReportedOwnershipConflicts {
+		t.Fatalf("got %d conflicts, want %d", len(bounded), maxReportedOwnershipConflicts)
+	}
+	if bounded[0].Kind != "Namespace" || bounded[1].Namespace != "ns-001" {
+		t.Errorf("conflicts not sorted: %+v", bounded[:2])
+	}
+
+	condition := ownershipConflictCondition(3, len(conflicts))
+	if condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != 3 ||
+		!strings.Contains(condition.Message, fmt.Sprint(len(conflicts))) {
+		t.Errorf("condition = %+v, want True with the total count", condition)
+	}
+	if condition := ownershipConflictCondition(3, 0); condition.Status != metav1.ConditionFalse {
+		t.Errorf("condition without conflicts = %+v, want False", condition)
+	}
+}
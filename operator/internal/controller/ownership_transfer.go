@@ -0,0 +1,331 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// AnnotationTransferredFrom records the PermissionBinder ("namespace/name")
+	// that handed a resource over (spec.transfers)
+	AnnotationTransferredFrom = "permission-binder.io/transferred-from"
+
+	// status.transfers[].state values
+	OwnershipTransferPending   = "Pending"
+	OwnershipTransferCompleted = "Completed"
+)
+
+// transferTarget returns the receiving PermissionBinder of a transfer
+func transferTarget(pb *permissionv1.PermissionBinder, transfer permissionv1.OwnershipTransferSpec) types.NamespacedName {
+	namespace := transfer.To.Namespace
+	if namespace == "" {
+		namespace = pb.Namespace
+	}
+	return types.NamespacedName{Name: transfer.To.Name, Namespace: namespace}
+}
+
+// transferredNamespaces returns the namespaces this PermissionBinder has handed
+// over; their whitelist entries are no longer processed
+func transferredNamespaces(pb *permissionv1.PermissionBinder) map[string]bool {
+	namespaces := make(map[string]bool)
+	for _, transfer := range pb.Status.Transfers {
+		if transfer.State != OwnershipTransferCompleted {
+			continue
+		}
+		for _, namespace := range transfer.Namespaces {
+			namespaces[namespace] = true
+		}
+	}
+	return namespaces
+}
+
+// completedTransfer returns the completed status of a transfer, nil if it is not handed over yet
+func completedTransfer(pb *permissionv1.PermissionBinder, transfer permissionv1.OwnershipTransferSpec) *permissionv1.OwnershipTransferStatus {
+	to := transferTarget(pb, transfer).String()
+	for i := range pb.Status.Transfers {
+		status := &pb.Status.Transfers[i]
+		if status.To == to && status.State == OwnershipTransferCompleted && reflect.DeepEqual(status.Namespaces, transfer.Namespaces) {
+			return status
+		}
+	}
+	return nil
+}
+
+// transfersUpToDate reports whether every transfer of the spec is handed over
+// and status.transfers lists nothing else
+func transfersUpToDate(pb *permissionv1.PermissionBinder) bool {
+	if len(pb.Spec.Transfers) != len(pb.Status.Transfers) {
+		return false
+	}
+	for _, transfer := range pb.Spec.Transfers {
+		if completedTransfer(pb, transfer) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// processOwnershipTransfers hands over the namespaces of spec.transfers. Completed
+// transfers are not repeated; a transfer stays Pending (and is retried) while the
+// receiving PermissionBinder does not exist or a resource could not be re-stamped.
+func (r *PermissionBinderReconciler) processOwnershipTransfers(ctx context.Context, pb *permissionv1.PermissionBinder) []permissionv1.OwnershipTransferStatus {
+	logger := log.FromContext(ctx)
+	var statuses []permissionv1.OwnershipTransferStatus
+
+	for _, transfer := range pb.Spec.Transfers {
+		if completed := completedTransfer(pb, transfer); completed != nil {
+			statuses = append(statuses, *completed)
+			continue
+		}
+
+		target := transferTarget(pb, transfer)
+		status := permissionv1.OwnershipTransferStatus{
+			To:         target.String(),
+			Namespaces: transfer.Namespaces,
+			State:      OwnershipTransferPending,
+		}
+		var receiver permissionv1.PermissionBinder
+		if err := r.Get(ctx, target, &receiver); err != nil {
+			status.Message = fmt.Sprintf("receiving PermissionBinder %s not available: %v", target, err)
+			statuses = append(statuses, status)
+			continue
+		}
+		if !receiver.DeletionTimestamp.IsZero() {
+			status.Message = fmt.Sprintf("receiving PermissionBinder %s is being deleted", target)
+			statuses = append(statuses, status)
+			continue
+		}
+		if target.Name == pb.Name && target.Namespace == pb.Namespace {
+			status.Message = "a PermissionBinder cannot transfer namespaces to itself"
+			statuses = append(statuses, status)
+			continue
+		}
+
+		handedOver, err := r.handOverNamespaces(ctx, pb, &receiver, transfer.Namespaces)
+		status.RoleBindings = handedOver.roleBindings
+		status.ServiceAccounts = handedOver.serviceAccounts
+		if err != nil {
+			logger.Error(err, "Ownership transfer incomplete, will retry",
+				"to", target.String(),
+				"namespaces", transfer.Namespaces)
+			status.Message = err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+
+		status.State = OwnershipTransferCompleted
+		status.TransferredAt = &metav1.Time{Time: time.Now()}
+		logger.Info("🤝 Handed over namespaces to another PermissionBinder",
+			"to", target.String(),
+			"namespaces", transfer.Namespaces,
+			"roleBindings", handedOver.roleBindings,
+			"serviceAccounts", handedOver.serviceAccounts)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// handedOver counts the resources re-stamped by handOverNamespaces
+type handedOver struct {
+	roleBindings    int
+	serviceAccounts int
+}
+
+// handOverNamespaces re-stamps the namespace objects, the whitelist RoleBindings
+// and the ServiceAccounts (with their RoleBindings and token Secrets) owned by pb
+// in the given namespaces for the receiver. Resources owned by another
+// PermissionBinder (or already by the receiver) are left alone, so the handover
+// can be repeated after a partial failure.
+func (r *PermissionBinderReconciler) handOverNamespaces(ctx context.Context, pb, receiver *permissionv1.PermissionBinder, namespaces []string) (handedOver, error) {
+	from := types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}.String()
+	restamp := func(obj client.Object) error {
+		annotations := obj.GetAnnotations()
+		annotations[AnnotationPermissionBinder] = receiver.Name
+		annotations[AnnotationPermissionBinderNamespace] = receiver.Namespace
+		annotations[AnnotationTransferredFrom] = from
+		// ServiceAccount resources also carry the owner in app.kubernetes.io/name
+		if labels := obj.GetLabels(); labels["app.kubernetes.io/managed-by"] == ManagedByValue {
+			labels["app.kubernetes.io/name"] = receiver.Name
+		}
+		return r.Update(ctx, obj)
+	}
+
+	var count handedOver
+	for _, namespace := range namespaces {
+		var ns corev1.Namespace
+		if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return count, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+		}
+		if isOwnedByPermissionBinder(ns.Annotations, pb) {
+			if err := restamp(&ns); err != nil {
+				return count, fmt.Errorf("failed to hand over namespace %s: %w", namespace, err)
+			}
+		}
+
+		var list rbacv1.RoleBindingList
+		if err := r.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{LabelManagedBy: ManagedByValue}); err != nil {
+			return count, fmt.Errorf("failed to list RoleBindings in namespace %s: %w", namespace, err)
+		}
+		for i := range list.Items {
+			roleBinding := &list.Items[i]
+			if !isOwnedByPermissionBinder(roleBinding.Annotations, pb) {
+				continue
+			}
+			if err := restamp(roleBinding); err != nil {
+				return count, fmt.Errorf("failed to hand over RoleBinding %s/%s: %w", namespace, roleBinding.Name, err)
+			}
+			count.roleBindings++
+		}
+
+		serviceAccounts, err := r.handOverServiceAccounts(ctx, pb, namespace, restamp)
+		count.serviceAccounts += serviceAccounts
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// handOverServiceAccounts re-stamps the ServiceAccounts owned by pb in a namespace,
+// their RoleBindings and their token Secrets. Token Secrets are not listed (the
+// operator has no list access to Secrets) but looked up by the name the spec
+// gives them.
+func (r *PermissionBinderReconciler) handOverServiceAccounts(ctx context.Context, pb *permissionv1.PermissionBinder, namespace string, restamp func(client.Object) error) (int, error) {
+	managedLabels := client.MatchingLabels{"app.kubernetes.io/managed-by": ManagedByValue}
+
+	var roleBindings rbacv1.RoleBindingList
+	if err := r.List(ctx, &roleBindings, client.InNamespace(namespace), managedLabels,
+		client.MatchingLabels{"app.kubernetes.io/component": "service-account-binding"}); err != nil {
+		return 0, fmt.Errorf("failed to list ServiceAccount RoleBindings in namespace %s: %w", namespace, err)
+	}
+	for i := range roleBindings.Items {
+		roleBinding := &roleBindings.Items[i]
+		if !isOwnedByPermissionBinder(roleBinding.Annotations, pb) {
+			continue
+		}
+		if err := restamp(roleBinding); err != nil {
+			return 0, fmt.Errorf("failed to hand over RoleBinding %s/%s: %w", namespace, roleBinding.Name, err)
+		}
+	}
+
+	var serviceAccounts corev1.ServiceAccountList
+	if err := r.List(ctx, &serviceAccounts, client.InNamespace(namespace), managedLabels); err != nil {
+		return 0, fmt.Errorf("failed to list ServiceAccounts in namespace %s: %w", namespace, err)
+	}
+	saConfigs := ResolveServiceAccountConfigs(&pb.Spec, namespace, pb.Spec.Prefixes)
+	handedOver := 0
+	for i := range serviceAccounts.Items {
+		sa := &serviceAccounts.Items[i]
+		saType := sa.Annotations[AnnotationSAType]
+		if saType == "" || !isOwnedByPermissionBinder(sa.Annotations, pb) {
+			continue
+		}
+
+		tokenSpec := &permissionv1.ServiceAccountTokenSpec{}
+		if saConfig, ok := saConfigs[saType]; ok && saConfig.Token != nil {
+			tokenSpec = saConfig.Token
+		}
+		var secret corev1.Secret
+		err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ServiceAccountTokenSecretName(sa.Name, tokenSpec)}, &secret)
+		switch {
+		case errors.IsNotFound(err):
+		case err != nil:
+			return handedOver, fmt.Errorf("failed to get token Secret of ServiceAccount %s/%s: %w", namespace, sa.Name, err)
+		case isOwnedByPermissionBinder(secret.Annotations, pb):
+			if err := restamp(&secret); err != nil {
+				return handedOver, fmt.Errorf("failed to hand over Secret %s/%s: %w", namespace, secret.Name, err)
+			}
+		}
+
+		if err := restamp(sa); err != nil {
+			return handedOver, fmt.Errorf("failed to hand over ServiceAccount %s/%s: %w", namespace, sa.Name, err)
+		}
+		handedOver++
+	}
+	return handedOver, nil
+}
+
+// incomingTransfers collects the transfers other PermissionBinders declare to pb,
+// with the state the handing-over PermissionBinder reports
+func (r *PermissionBinderReconciler) incomingTransfers(ctx context.Context, pb *permissionv1.PermissionBinder) ([]permissionv1.OwnershipTransferStatus, error) {
+	var list permissionv1.PermissionBinderList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list PermissionBinders: %w", err)
+	}
+	self := types.NamespacedName{Name: pb.Name, Namespace: pb.Namespace}
+
+	var incoming []permissionv1.OwnershipTransferStatus
+	for i := range list.Items {
+		from := &list.Items[i]
+		if from.Name == pb.Name && from.Namespace == pb.Namespace {
+			continue
+		}
+		for _, transfer := range from.Spec.Transfers {
+			if transferTarget(from, transfer) != self {
+				continue
+			}
+			status := permissionv1.OwnershipTransferStatus{
+				Namespaces: transfer.Namespaces,
+				State:      OwnershipTransferPending,
+				Message:    "waiting for the handing-over PermissionBinder",
+			}
+			if completed := completedTransfer(from, transfer); completed != nil {
+				status = *completed.DeepCopy()
+			}
+			status.From = types.NamespacedName{Name: from.Name, Namespace: from.Namespace}.String()
+			status.To = ""
+			incoming = append(incoming, status)
+		}
+	}
+	sort.Slice(incoming, func(i, j int) bool {
+		return incoming[i].From < incoming[j].From
+	})
+	return incoming, nil
+}
+
+// mapTransferTargets enqueues the receiving PermissionBinders of a PermissionBinder's
+// transfers, so that they report (and adopt) the handover once it completes
+func (r *PermissionBinderReconciler) mapTransferTargets(_ context.Context, obj client.Object) []reconcile.Request {
+	pb, ok := obj.(*permissionv1.PermissionBinder)
+	if !ok {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, transfer := range pb.Spec.Transfers {
+		requests = append(requests, reconcile.Request{NamespacedName: transferTarget(pb, transfer)})
+	}
+	return requests
+}
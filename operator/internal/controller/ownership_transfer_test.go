@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestProcessOwnershipTransfers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	owned := func(name, namespace string) map[string]string {
+		return map[string]string{
+			AnnotationPermissionBinder:          name,
+			AnnotationPermissionBinderNamespace: namespace,
+		}
+	}
+	roleBinding := func(name string, labels, annotations map[string]string) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "payments", Labels: labels, Annotations: annotations},
+			RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+		}
+	}
+	managed := map[string]string{LabelManagedBy: ManagedByValue}
+	saManaged := func(component string) map[string]string {
+		return map[string]string{
+			"app.kubernetes.io/managed-by": ManagedByValue,
+			"app.kubernetes.io/component":  component,
+			"app.kubernetes.io/name":       "platform",
+		}
+	}
+	withSAType := func(annotations map[string]string, saType string) map[string]string {
+		annotations[AnnotationSAType] = saType
+		return annotations
+	}
+
+	source := newPermissionBinder("operators", "platform")
+	source.Spec.Transfers = []permissionv1.OwnershipTransferSpec{
+		{Namespaces: []string{"payments"}, To: permissionv1.PermissionBinderReference{Name: "payments-team"}},
+	}
+	receiver := newPermissionBinder("operators", "payments-team")
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		source, receiver,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Annotations: owned("platform", "operators")}},
+		roleBinding("payments-admin", managed, owned("platform", "operators")),
+		roleBinding("payments-view", managed, owned("other", "operators")),
+		// ServiceAccount RoleBindings are not whitelist RoleBindings
+		roleBinding("payments-sa-deploy", nil, owned("platform", "operators")),
+		// ServiceAccounts move with their RoleBindings and token Secrets
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Name: "payments-sa-deploy", Namespace: "payments", Labels: saManaged("deploy"),
+			Annotations: withSAType(owned("platform", "operators"), "deploy"),
+		}},
+		roleBinding("sa-payments-deploy", saManaged("service-account-binding"), owned("platform", "operators")),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name: "payments-sa-deploy-token", Namespace: "payments", Labels: saManaged("service-account-token"),
+			Annotations: owned("platform", "operators"),
+		}},
+	).WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+
+	t.Run("receiver missing keeps the transfer pending", func(t *testing.T) {
+		pb := source.DeepCopy()
+		pb.Spec.Transfers[0].To.Name = "missing"
+		statuses := r.processOwnershipTransfers(ctx, pb)
+		if len(statuses) != 1 || statuses[0].State != OwnershipTransferPending || statuses[0].Message == "" {
+			t.Errorf("statuses = %+v, want one pending transfer with a message", statuses)
+		}
+	})
+
+	pb := source.DeepCopy()
+	statuses := r.processOwnershipTransfers(ctx, pb)
+	if len(statuses) != 1 || statuses[0].State != OwnershipTransferCompleted {
+		t.Fatalf("statuses = %+v, want one completed transfer", statuses)
+	}
+	if statuses[0].To != "operators/payments-team" || statuses[0].RoleBindings != 1 || statuses[0].ServiceAccounts != 1 {
+		t.Errorf("status = %+v, want 1 RoleBinding and 1 ServiceAccount handed over to operators/payments-team", statuses[0])
+	}
+
+	var ns corev1.Namespace
+	_ = k8sClient.Get(ctx, types.NamespacedName{Name: "payments"}, &ns)
+	if !isOwnedBy(ns.Annotations, "payments-team", "operators") || ns.Annotations[AnnotationTransferredFrom] != "operators/platform" {
+		t.Errorf("namespace annotations = %v, want handed over to payments-team", ns.Annotations)
+	}
+	for name, wantOwner := range map[string]string{
+		"payments-admin":     "payments-team",
+		"payments-view":      "other",
+		"payments-sa-deploy": "platform",
+		"sa-payments-deploy": "payments-team",
+	} {
+		var rb rbacv1.RoleBinding
+		_ = k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "payments"}, &rb)
+		if got := rb.Annotations[AnnotationPermissionBinder]; got != wantOwner {
+			t.Errorf("RoleBinding %s owned by %q, want %q", name, got, wantOwner)
+		}
+	}
+
+	var sa corev1.ServiceAccount
+	_ = k8sClient.Get(ctx, types.NamespacedName{Name: "payments-sa-deploy", Namespace: "payments"}, &sa)
+	if !isOwnedBy(sa.Annotations, "payments-team", "operators") || sa.Labels["app.kubernetes.io/name"] != "payments-team" {
+		t.Errorf("ServiceAccount = %+v, want handed over to payments-team", sa.ObjectMeta)
+	}
+	var secret corev1.Secret
+	_ = k8sClient.Get(ctx, types.NamespacedName{Name: "payments-sa-deploy-token", Namespace: "payments"}, &secret)
+	if !isOwnedBy(secret.Annotations, "payments-team", "operators") {
+		t.Errorf("token Secret annotations = %v, want handed over to payments-team", secret.Annotations)
+	}
+
+	t.Run("completed transfers are not repeated", func(t *testing.T) {
+		pb.Status.Transfers = statuses
+		if !transfersUpToDate(pb) {
+			t.Error("transfers should be up to date")
+		}
+		if !transferredNamespaces(pb)["payments"] {
+			t.Error("payments should be reported as transferred")
+		}
+		again := r.processOwnershipTransfers(ctx, pb)
+		if again[0].RoleBindings != 1 || !again[0].TransferredAt.Equal(statuses[0].TransferredAt) {
+			t.Errorf("status = %+v, want the completed status unchanged", again[0])
+		}
+	})
+
+	t.Run("receiver reports the incoming transfer", func(t *testing.T) {
+		var stored permissionv1.PermissionBinder
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: "platform", Namespace: "operators"}, &stored); err != nil {
+			t.Fatal(err)
+		}
+		stored.Status.Transfers = statuses
+		if err := k8sClient.Status().Update(ctx, &stored); err != nil {
+			t.Fatal(err)
+		}
+		incoming, err := r.incomingTransfers(ctx, receiver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(incoming) != 1 || incoming[0].From != "operators/platform" || incoming[0].To != "" ||
+			incoming[0].State != OwnershipTransferCompleted {
+			t.Errorf("incoming = %+v, want the completed transfer from operators/platform", incoming)
+		}
+	})
+
+	t.Run("changed transfer is handed over again", func(t *testing.T) {
+		pb.Spec.Transfers[0].Namespaces = []string{"payments", "billing"}
+		if transfersUpToDate(pb) {
+			t.Error("a changed namespace list must trigger a new handover")
+		}
+		if got := r.mapTransferTargets(ctx, pb); len(got) != 1 || got[0].Name != "payments-team" || got[0].Namespace != "operators" {
+			t.Errorf("mapTransferTargets() = %v, want operators/payments-team", got)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// plannedChangesClient records writes instead of sending them to the API server,
+// for frozen and paused reconciliations. Reads go to the wrapped client, overlaid
+// with the recorded creates, updates and deletes, so that multi-step operations
+// (make-before-break replacements) plan the same steps they would apply.
+type plannedChangesClient struct {
+	client.Client
+
+	changes []string
+	seen    map[string]bool
+	written map[string]client.Object
+	deleted map[string]bool
+}
+
+func newPlannedChangesClient(c client.Client) *plannedChangesClient {
+	return &plannedChangesClient{
+		Client:  c,
+		seen:    make(map[string]bool),
+		written: make(map[string]client.Object),
+		deleted: make(map[string]bool),
+	}
+}
+
+// Changes returns the recorded changes in order, e.g. "create RoleBinding payments/payments-admin"
+func (c *plannedChangesClient) Changes() []string {
+	return c.changes
+}
+
+// objectKind returns the kind of obj for change descriptions
+func objectKind(c client.Client, obj client.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return strings.TrimPrefix(fmt.Sprintf("%T", obj), "*")
+	}
+	return gvk.Kind
+}
+
+// describeObject returns "Kind namespace/name" ("Kind name" for cluster-scoped objects)
+func describeObject(kind string, key client.ObjectKey) string {
+	if key.Namespace == "" {
+		return kind + " " + key.Name
+	}
+	return kind + " " + key.Namespace + "/" + key.Name
+}
+
+func (c *plannedChangesClient) record(verb string, obj client.Object, subResource string) string {
+	key := describeObject(objectKind(c.Client, obj), client.ObjectKeyFromObject(obj))
+	change := verb + " " + key
+	if subResource != "" {
+		change += " " + subResource
+	}
+	if !c.seen[change] {
+		c.seen[change] = true
+		c.changes = append(c.changes, change)
+	}
+	return key
+}
+
+func (c *plannedChangesClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	objectKey := describeObject(objectKind(c.Client, obj), key)
+	if c.deleted[objectKey] {
+		return errors.NewNotFound(schema.GroupResource{Resource: objectKind(c.Client, obj)}, key.Name)
+	}
+	if written, ok := c.written[objectKey]; ok {
+		reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(written.DeepCopyObject()).Elem())
+		return nil
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *plannedChangesClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	key := c.record("create", obj, "")
+	delete(c.deleted, key)
+	c.written[key] = obj.DeepCopyObject().(client.Object)
+	return nil
+}
+
+func (c *plannedChangesClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	key := c.record("update", obj, "")
+	c.written[key] = obj.DeepCopyObject().(client.Object)
+	return nil
+}
+
+func (c *plannedChangesClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.record("patch", obj, "")
+	return nil
+}
+
+func (c *plannedChangesClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	key := c.record("delete", obj, "")
+	delete(c.written, key)
+	c.deleted[key] = true
+	return nil
+}
+
+func (c *plannedChangesClient) DeleteAllOf(_ context.Context, obj client.Object, _ ...client.DeleteAllOfOption) error {
+	change := "delete all " + objectKind(c.Client, obj)
+	if !c.seen[change] {
+		c.seen[change] = true
+		c.changes = append(c.changes, change)
+	}
+	return nil
+}
+
+func (c *plannedChangesClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *plannedChangesClient) SubResource(subResource string) client.SubResourceClient {
+	return &plannedSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		planner:           c,
+		subResource:       subResource,
+	}
+}
+
+// plannedSubResourceClient records subresource writes (status, TokenRequests)
+type plannedSubResourceClient struct {
+	client.SubResourceClient
+
+	planner     *plannedChangesClient
+	subResource string
+}
+
+func (c *plannedSubResourceClient) Create(_ context.Context, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	c.planner.record("create", obj, c.subResource)
+	return nil
+}
+
+func (c *plannedSubResourceClient) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	c.planner.record("update", obj, c.subResource)
+	return nil
+}
+
+func (c *plannedSubResourceClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	c.planner.record("patch", obj, c.subResource)
+	return nil
+}
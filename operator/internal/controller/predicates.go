@@ -91,6 +91,16 @@ func (r *PermissionBinderReconciler) permissionBinderPredicate() predicate.Predi
 				return true
 			}
 
+			// Check if blocked deletions were acknowledged
+			if oldObj.Annotations[AnnotationApproveDeletions] != newObj.Annotations[AnnotationApproveDeletions] {
+				return true
+			}
+
+			// Check if the whitelist was rolled back or the rollback lifted
+			if oldObj.Annotations[AnnotationRollbackTo] != newObj.Annotations[AnnotationRollbackTo] {
+				return true
+			}
+
 			// Ignore status-only updates
 			return false
 		},
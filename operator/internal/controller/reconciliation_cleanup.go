@@ -109,6 +109,13 @@ func (r *PermissionBinderReconciler) cleanupManagedResources(ctx context.Context
 
 	logger.Info("PermissionBinder is being deleted - SAFE MODE: RoleBindings and namespaces will be preserved to prevent cascade failures")
 
+	// Remove the ownerReferences first - garbage collection would otherwise delete
+	// the preserved resources once the PermissionBinder is gone
+	if err := r.releaseOwnerReferences(ctx, permissionBinder); err != nil {
+		logger.Error(err, "Failed to release ownerReferences - keeping the finalizer")
+		return err
+	}
+
 	// Get all managed role bindings to add cleanup annotation
 	roleBindings, err := r.getManagedRoleBindings(ctx, permissionBinder)
 	if err != nil {
@@ -251,10 +258,14 @@ func (r *PermissionBinderReconciler) reconcileAllManagedResources(ctx context.Co
 		}
 
 		if role != "" && !r.roleExistsInMapping(role, permissionBinder.Spec.RoleMapping) {
+			if breakGlassActive(roleBinding.Annotations, time.Now()) {
+				logger.Info("Keeping break-glass RoleBinding for a removed role", "namespace", roleBinding.Namespace, "name", roleBinding.Name)
+				continue
+			}
 			if err := r.Delete(ctx, &roleBinding); err != nil {
 				logger.Error(err, "Failed to delete obsolete RoleBinding", "namespace", roleBinding.Namespace, "name", roleBinding.Name)
 			} else {
-				logger.Info("Deleted obsolete RoleBinding", "namespace", roleBinding.Namespace, "name", roleBinding.Name)
+				logger.Info("Planned deletion of obsolete RoleBinding", "namespace", roleBinding.Namespace, "name", roleBinding.Name)
 			}
 		}
 	}
@@ -271,10 +282,14 @@ func (r *PermissionBinderReconciler) reconcileAllManagedResources(ctx context.Co
 				}
 			}
 			if !matchesAnyPrefix {
+				if breakGlassActive(roleBinding.Annotations, time.Now()) {
+					logger.Info("Keeping break-glass RoleBinding with invalid prefix", "namespace", roleBinding.Namespace, "name", roleBinding.Name, "group", groupName)
+					continue
+				}
 				if err := r.Delete(ctx, &roleBinding); err != nil {
 					logger.Error(err, "Failed to delete RoleBinding with invalid prefix", "namespace", roleBinding.Namespace, "name", roleBinding.Name, "group", groupName)
 				} else {
-					logger.Info("Deleted RoleBinding with invalid prefix", "namespace", roleBinding.Namespace, "name", roleBinding.Name, "group", groupName)
+					logger.Info("Planned deletion of RoleBinding with invalid prefix", "namespace", roleBinding.Namespace, "name", roleBinding.Name, "group", groupName)
 				}
 			}
 		}
@@ -20,9 +20,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,158 +36,388 @@ import (
 type ProcessConfigMapResult struct {
 	ProcessedRoleBindings    []string
 	ProcessedServiceAccounts []string
+	PrunedServiceAccounts    []string
+	OrphanedServiceAccounts  []string
+	ServiceAccountTokens     []permissionv1.ServiceAccountTokenStatus
+	// OwnershipConflicts lists the resources claimed by other PermissionBinders
+	OwnershipConflicts []permissionv1.OwnershipConflict
+	// WhitelistFound is false when the ConfigMap has no whitelist.txt
+	WhitelistFound bool
+	// EntryResults reports the outcome of each whitelist line
+	EntryResults []permissionv1.WhitelistEntryResult
+	// Non-fatal failures, reported in the RBACReconciled and
+	// ServiceAccountsReconciled conditions
+	RoleBindingErrors    []error
+	ServiceAccountErrors []error
 }
 
-// processConfigMap processes the ConfigMap data and creates RoleBindings
-func (r *PermissionBinderReconciler) processConfigMap(ctx context.Context, permissionBinder *permissionv1.PermissionBinder, configMap *corev1.ConfigMap) (ProcessConfigMapResult, error) {
-	logger := log.FromContext(ctx)
-	result := ProcessConfigMapResult{}
-	var processedRoleBindings []string
-	var validWhitelistEntries []string // For LDAP group creation
+// Reasons a whitelist line is not bound (whitelistEntry.Skip)
+const (
+	whitelistSkipInvalidDN         = "invalid_dn"
+	whitelistSkipExcluded          = "excluded"
+	whitelistSkipInvalidPermission = "invalid_permission"
+)
 
-	// Look for whitelist.txt key in ConfigMap
-	whitelistContent, found := configMap.Data["whitelist.txt"]
-	if !found {
-		logger.Info("No whitelist.txt found in ConfigMap, skipping processing")
-		return result, nil
-	}
+// whitelistEntry is one parsed line of whitelist.txt
+type whitelistEntry struct {
+	// Line is the 1-based line number
+	Line      int
+	DN        string
+	CN        string
+	Namespace string
+	Role      string
+	Prefix    string
+	// Skip is the reason the entry is not bound, empty for valid entries
+	Skip string
+	Err  error
+}
 
-	// Parse whitelist.txt line by line
-	lines := strings.Split(whitelistContent, "\n")
-	for lineNum, line := range lines {
+// parseWhitelist parses whitelist.txt into entries, skipping empty lines and
+// comments. It is shared by the RBAC reconciliation and the LDAP controller so
+// that both work on the same set of valid entries.
+func (r *PermissionBinderReconciler) parseWhitelist(permissionBinder *permissionv1.PermissionBinder, whitelistContent string) []whitelistEntry {
+	var entries []whitelistEntry
+	for lineNum, line := range strings.Split(whitelistContent, "\n") {
 		line = strings.TrimSpace(line)
 
 		// Skip empty lines and comments
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
+		entry := whitelistEntry{Line: lineNum + 1, DN: line}
 
 		// Extract CN value from LDAP DN format
 		// Example: CN=DD_0000-K8S-123-Cluster-admin,OU=Openshift-123,...
 		cnValue, err := r.extractCNFromDN(line)
 		if err != nil {
-			configMapEntriesProcessed.WithLabelValues("error").Inc()
-			logger.Info("Skipping invalid LDAP DN entry - cannot extract CN",
-				"line", lineNum+1,
-				"content", line,
-				"reason", err.Error(),
-				"action", "skip")
+			entry.Skip, entry.Err = whitelistSkipInvalidDN, err
+			entries = append(entries, entry)
 			continue
 		}
+		entry.CN = cnValue
 
 		// Check if the CN value is in the exclude list
 		if r.isExcluded(cnValue, permissionBinder.Spec.ExcludeList) {
-			configMapEntriesProcessed.WithLabelValues("excluded").Inc()
-			logger.Info("Skipping excluded CN", "cn", cnValue)
+			entry.Skip = whitelistSkipExcluded
+			entries = append(entries, entry)
 			continue
 		}
 
 		// Parse the CN value to extract namespace and role (try all prefixes)
-		namespace, role, matchedPrefix, err := r.parsePermissionStringWithPrefixes(cnValue, permissionBinder.Spec.Prefixes, permissionBinder.Spec.RoleMapping)
+		entry.Namespace, entry.Role, entry.Prefix, err = r.parsePermissionStringWithPrefixes(cnValue, permissionBinder.Spec.Prefixes, permissionBinder.Spec.RoleMapping)
 		if err != nil {
+			entry.Skip, entry.Err = whitelistSkipInvalidPermission, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// processConfigMap processes the ConfigMap data and creates RoleBindings.
+// LDAP directory work runs in the LDAP controller; only its verification
+// result (missing groups) is consulted here.
+func (r *PermissionBinderReconciler) processConfigMap(ctx context.Context, permissionBinder *permissionv1.PermissionBinder, configMap *corev1.ConfigMap) (ProcessConfigMapResult, error) {
+	logger := log.FromContext(ctx)
+	result := ProcessConfigMapResult{}
+	var processedRoleBindings []string
+	// Whitelist prefixes that produced each namespace (for serviceAccountOverrides)
+	namespacePrefixes := make(map[string][]string)
+
+	// Look for whitelist.txt key in ConfigMap
+	whitelistContent, found := configMap.Data["whitelist.txt"]
+	if !found {
+		logger.Info("No whitelist.txt found in ConfigMap, skipping processing")
+		return result, nil
+	}
+	result.WhitelistFound = true
+
+	// Whitelist groups the LDAP controller found missing (ldapGroupVerification)
+	missingLdapGroups := ldapMissingGroups(permissionBinder)
+	missingGroupPolicy := ldapMissingGroupPolicy(permissionBinder)
+	// Namespaces handed over to another PermissionBinder (spec.transfers)
+	transferred := transferredNamespaces(permissionBinder)
+	// Namespaces whose ServiceAccounts are left alone by pruning below - a
+	// transient error, a refused takeover, a handover or an entry skipped for a
+	// missing LDAP group must not look like a shrunk mapping
+	keepSANamespaces := make(map[string]bool)
+	for namespace := range transferred {
+		keepSANamespaces[namespace] = true
+	}
+	// Refused takeovers, once per resource
+	seenConflicts := make(map[permissionv1.OwnershipConflict]bool)
+	addConflict := func(conflict *permissionv1.OwnershipConflict) {
+		if !seenConflicts[*conflict] {
+			seenConflicts[*conflict] = true
+			result.OwnershipConflicts = append(result.OwnershipConflicts, *conflict)
+		}
+	}
+
+	for _, entry := range r.parseWhitelist(permissionBinder, whitelistContent) {
+		line, cnValue, namespace, role, matchedPrefix := entry.DN, entry.CN, entry.Namespace, entry.Role, entry.Prefix
+		report := func(outcome, reason string) {
+			result.EntryResults = append(result.EntryResults, newEntryResult(entry, outcome, reason))
+		}
+		switch entry.Skip {
+		case whitelistSkipInvalidDN:
+			report(EntryOutcomeInvalid, entry.Err.Error())
+			configMapEntriesProcessed.WithLabelValues("error").Inc()
+			logger.Info("Skipping invalid LDAP DN entry - cannot extract CN",
+				"line", entry.Line,
+				"content", line,
+				"reason", entry.Err.Error(),
+				"action", "skip")
+			continue
+		case whitelistSkipExcluded:
+			report(EntryOutcomeExcluded, "CN is in excludeList")
+			configMapEntriesProcessed.WithLabelValues("excluded").Inc()
+			logger.Info("Skipping excluded CN", "cn", cnValue)
+			continue
+		case whitelistSkipInvalidPermission:
+			report(EntryOutcomeInvalid, entry.Err.Error())
 			configMapEntriesProcessed.WithLabelValues("error").Inc()
 			logger.Info("Skipping invalid permission string - cannot parse CN value",
-				"line", lineNum+1,
+				"line", entry.Line,
 				"cn", cnValue,
-				"reason", err.Error(),
+				"reason", entry.Err.Error(),
 				"action", "skip")
 			continue
 		}
 
 		logger.V(1).Info("Parsed permission string", "cn", cnValue, "prefix", matchedPrefix, "namespace", namespace, "role", role)
 
-		// Add to valid entries for LDAP processing (use original line with full DN)
-		validWhitelistEntries = append(validWhitelistEntries, line)
+		if missingLdapGroups[strings.ToLower(line)] {
+			if missingGroupPolicy == LdapMissingGroupSkip {
+				report(EntryOutcomeLdapGroupMissing, "LDAP group does not exist (missingGroupPolicy Skip)")
+				keepSANamespaces[namespace] = true
+				configMapEntriesProcessed.WithLabelValues("ldap_group_missing").Inc()
+				logger.Info("Skipping whitelist entry - LDAP group does not exist",
+					"line", entry.Line,
+					"dn", line,
+					"action", "skip")
+				continue
+			}
+			logger.Info("⚠️  LDAP group of whitelist entry does not exist, binding it anyway",
+				"line", entry.Line,
+				"dn", line,
+				"missingGroupPolicy", missingGroupPolicy)
+		}
+
+		if transferred[namespace] {
+			report(EntryOutcomeTransferred, "namespace handed over to another PermissionBinder")
+			configMapEntriesProcessed.WithLabelValues("transferred").Inc()
+			logger.V(1).Info("Skipping whitelist entry - namespace handed over to another PermissionBinder",
+				"line", entry.Line,
+				"namespace", namespace)
+			continue
+		}
 
 		// Ensure namespace exists
-		if err := r.ensureNamespace(ctx, namespace, permissionBinder); err != nil {
+		conflict, err := r.ensureNamespace(ctx, namespace, permissionBinder)
+		if err != nil {
 			logger.Error(err, "Failed to ensure namespace exists", "namespace", namespace)
+			result.RoleBindingErrors = append(result.RoleBindingErrors, fmt.Errorf("namespace %s: %w", namespace, err))
+			report(EntryOutcomeFailed, fmt.Sprintf("namespace %s: %v", namespace, err))
+			keepSANamespaces[namespace] = true
 			continue
 		}
+		if conflict != nil {
+			addConflict(conflict)
+		}
 
 		// Create RoleBinding (use the CN value as the group subject name)
 		// OpenShift LDAP syncer creates groups with CN value as name, not full DN
 		roleBindingName := fmt.Sprintf("%s-%s", namespace, role)
-		managed, err := r.createRoleBinding(ctx, namespace, roleBindingName, role, cnValue, permissionBinder.Spec.RoleMapping[role], permissionBinder)
+		conflict, err = r.createRoleBinding(ctx, namespace, roleBindingName, role, cnValue, permissionBinder.Spec.RoleMapping[role], permissionBinder)
 		if err != nil {
 			logger.Error(err, "Failed to create RoleBinding", "namespace", namespace, "role", role)
+			result.RoleBindingErrors = append(result.RoleBindingErrors, fmt.Errorf("RoleBinding %s/%s: %w", namespace, roleBindingName, err))
+			report(EntryOutcomeFailed, fmt.Sprintf("RoleBinding %s/%s: %v", namespace, roleBindingName, err))
+			keepSANamespaces[namespace] = true
 			continue
 		}
-		if !managed {
+		if conflict != nil {
 			// Ownership gate refused the takeover (issue #43): the RoleBinding
 			// belongs to another PermissionBinder - do not report it as
 			// successfully processed by this CR.
 			configMapEntriesProcessed.WithLabelValues("ownership_conflict").Inc()
+			addConflict(conflict)
+			report(EntryOutcomeOwnershipConflict, fmt.Sprintf("RoleBinding %s/%s is claimed by PermissionBinder %s",
+				namespace, roleBindingName, conflict.ClaimedBy))
+			keepSANamespaces[namespace] = true
 			continue
 		}
 
 		processedRoleBindings = append(processedRoleBindings, fmt.Sprintf("%s/%s", namespace, roleBindingName))
+		if !containsString(namespacePrefixes[namespace], matchedPrefix) {
+			namespacePrefixes[namespace] = append(namespacePrefixes[namespace], matchedPrefix)
+		}
+		reason := "RoleBinding " + namespace + "/" + roleBindingName
+		if missingLdapGroups[strings.ToLower(line)] {
+			reason += " (LDAP group does not exist)"
+		}
+		report(EntryOutcomeBound, reason)
 		configMapEntriesProcessed.WithLabelValues("success").Inc()
 		logger.Info("Created RoleBinding", "namespace", namespace, "role", role, "groupName", cnValue)
 	}
 
-	// Process LDAP group creation if enabled
-	if permissionBinder.Spec.CreateLdapGroups && len(validWhitelistEntries) > 0 {
-		logger.Info("🔐 LDAP group creation is enabled, processing entries", "count", len(validWhitelistEntries))
-		if err := r.ProcessLdapGroupCreation(ctx, permissionBinder, validWhitelistEntries); err != nil {
-			// Log error but don't fail the entire reconciliation
-			logger.Error(err, "⚠️  LDAP group creation failed (non-fatal)", "validEntries", len(validWhitelistEntries))
-		}
-	}
-
 	// Process ServiceAccount creation if configured
-	// ServiceAccounts are created per namespace based on serviceAccountMapping
+	// ServiceAccounts are created per namespace based on serviceAccountMapping,
+	// adjusted by the first matching entry of serviceAccountOverrides
 	// This happens for each namespace that was processed above
 	var allProcessedSAs []string
-	if len(permissionBinder.Spec.ServiceAccountMapping) > 0 {
-		// Get unique namespaces from processed RoleBindings
-		namespaces := make(map[string]bool)
-		for _, rb := range processedRoleBindings {
-			// RoleBinding format: "namespace/rolebinding-name"
-			parts := strings.Split(rb, "/")
-			if len(parts) == 2 {
-				namespaces[parts[0]] = true
+	// Effective ServiceAccount entries per namespace processed above
+	desiredSAConfigs := make(map[string]map[string]permissionv1.ServiceAccountConfig)
+	saResolver, patternErrs := newServiceAccountResolver(&permissionBinder.Spec)
+	for _, err := range patternErrs {
+		logger.Error(err, "⚠️  Invalid namespace pattern in serviceAccountOverrides, it selects no namespace")
+		result.ServiceAccountErrors = append(result.ServiceAccountErrors, err)
+	}
+	for _, rb := range processedRoleBindings {
+		// RoleBinding format: "namespace/rolebinding-name"
+		parts := strings.Split(rb, "/")
+		if len(parts) == 2 {
+			namespace := parts[0]
+			if _, seen := desiredSAConfigs[namespace]; !seen {
+				desiredSAConfigs[namespace] = saResolver.resolve(namespace, namespacePrefixes[namespace])
 			}
 		}
-
+	}
+	if len(permissionBinder.Spec.ServiceAccountMapping) > 0 || len(permissionBinder.Spec.ServiceAccounts) > 0 ||
+		len(permissionBinder.Spec.ServiceAccountOverrides) > 0 {
 		logger.Info("🔑 ServiceAccount mapping configured, creating ServiceAccounts",
 			"mappings", len(permissionBinder.Spec.ServiceAccountMapping),
-			"namespaces", len(namespaces))
+			"serviceAccounts", len(permissionBinder.Spec.ServiceAccounts),
+			"overrides", len(permissionBinder.Spec.ServiceAccountOverrides),
+			"namespaces", len(desiredSAConfigs))
 
 		// Process each namespace
-		for namespace := range namespaces {
-			processedSAs, err := ProcessServiceAccounts(
+		for namespace, saConfigs := range desiredSAConfigs {
+			if len(saConfigs) == 0 {
+				// Disabled or fully excluded by an override
+				logger.V(1).Info("No ServiceAccounts configured for namespace, skipping", "namespace", namespace)
+				continue
+			}
+			processedSAs, err := ProcessServiceAccountConfigs(
 				ctx,
 				r.Client,
 				namespace,
-				permissionBinder.Spec.ServiceAccountMapping,
+				saConfigs,
 				permissionBinder.Spec.ServiceAccountNamingPattern,
 				permissionBinder.Name,
 				permissionBinder.Namespace,
+				r.roleBindingEvents(permissionBinder),
 			)
 			if err != nil {
 				// Log error but don't fail the entire reconciliation
 				logger.Error(err, "⚠️  ServiceAccount creation failed (non-fatal)",
 					"namespace", namespace)
+				keepSANamespaces[namespace] = true
+				result.ServiceAccountErrors = append(result.ServiceAccountErrors, fmt.Errorf("namespace %s: %w", namespace, err))
 			} else {
 				allProcessedSAs = append(allProcessedSAs, processedSAs...)
 				logger.Info("✅ ServiceAccounts processed successfully",
 					"namespace", namespace,
 					"created", len(processedSAs))
+				tokens, tokenConflicts, tokenErrs := r.processServiceAccountTokens(ctx, permissionBinder, namespace, saConfigs, processedSAs)
+				result.ServiceAccountTokens = append(result.ServiceAccountTokens, tokens...)
+				result.ServiceAccountErrors = append(result.ServiceAccountErrors, tokenErrs...)
+				for i := range tokenConflicts {
+					addConflict(&tokenConflicts[i])
+				}
 			}
 		}
+	}
 
-		// Update managedServiceAccountsTotal metric
-		managedServiceAccountsTotal.Set(float64(len(allProcessedSAs)))
+	// Prune ServiceAccounts that left the desired set (key removed from
+	// serviceAccountMapping, excluded by an override, or namespace removed
+	// from the whitelist). This also runs when the mapping is now empty, so
+	// dropping the last key is handled too. An invalid namespace pattern may
+	// leave out an override, so nothing is pruned until it is fixed.
+	var pruneResult ServiceAccountPruneResult
+	if len(patternErrs) > 0 {
+		logger.Info("⚠️  Skipping ServiceAccount pruning until the invalid namespace patterns are fixed",
+			"invalidPatterns", len(patternErrs))
+	} else {
+		var err error
+		pruneResult, err = PruneServiceAccounts(
+			ctx,
+			r.Client,
+			desiredSAConfigs,
+			keepSANamespaces,
+			permissionBinder.Spec.ServiceAccountNamingPattern,
+			permissionBinder.Spec.ServiceAccountPruneMode,
+			permissionBinder.Name,
+			permissionBinder.Namespace,
+			r.roleBindingEvents(permissionBinder),
+		)
+		if err != nil {
+			// Log error but don't fail the entire reconciliation
+			logger.Error(err, "⚠️  ServiceAccount pruning failed (non-fatal)")
+			result.ServiceAccountErrors = append(result.ServiceAccountErrors, fmt.Errorf("pruning: %w", err))
+		} else if len(pruneResult.Deleted) > 0 || len(pruneResult.Orphaned) > 0 {
+			logger.Info("🧹 Pruned ServiceAccounts no longer in desired set",
+				"deleted", len(pruneResult.Deleted),
+				"orphaned", len(pruneResult.Orphaned))
+		}
 	}
 
 	// Populate result
 	result.ProcessedRoleBindings = processedRoleBindings
 	result.ProcessedServiceAccounts = allProcessedSAs
+	result.PrunedServiceAccounts = pruneResult.Deleted
+	result.OrphanedServiceAccounts = pruneResult.Orphaned
 
 	return result, nil
 }
 
+// processServiceAccountTokens ensures the managed token Secrets of the
+// ServiceAccounts processed in a namespace. Failures are logged, skipped and
+// returned; the returned status entries are sorted by ServiceAccount name.
+func (r *PermissionBinderReconciler) processServiceAccountTokens(
+	ctx context.Context,
+	permissionBinder *permissionv1.PermissionBinder,
+	namespace string,
+	saConfigs map[string]permissionv1.ServiceAccountConfig,
+	processedSAs []string,
+) ([]permissionv1.ServiceAccountTokenStatus, []permissionv1.OwnershipConflict, []error) {
+	logger := log.FromContext(ctx)
+	var tokens []permissionv1.ServiceAccountTokenStatus
+	var conflicts []permissionv1.OwnershipConflict
+	var errs []error
+	now := time.Now()
+
+	for saName, saConfig := range saConfigs {
+		if saConfig.Token == nil {
+			continue
+		}
+		fullSAName := GenerateServiceAccountName(permissionBinder.Spec.ServiceAccountNamingPattern, namespace, saName)
+		if !containsString(processedSAs, namespace+"/"+fullSAName) {
+			// Not managed by this PermissionBinder (e.g. ownership conflict)
+			continue
+		}
+		tokenStatus, err := EnsureServiceAccountToken(ctx, r.Client, namespace, fullSAName,
+			saConfig.Token, permissionBinder.Name, permissionBinder.Namespace, now)
+		var conflict *TokenSecretConflictError
+		if errors.As(err, &conflict) {
+			conflicts = append(conflicts, *r.recordOwnershipConflict(permissionBinder, "Secret", conflict.Secret))
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "⚠️  ServiceAccount token processing failed (non-fatal)",
+				"namespace", namespace,
+				"serviceAccount", fullSAName)
+			errs = append(errs, fmt.Errorf("token of ServiceAccount %s/%s: %w", namespace, fullSAName, err))
+			continue
+		}
+		tokens = append(tokens, tokenStatus)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].ServiceAccount < tokens[j].ServiceAccount
+	})
+	return tokens, conflicts, errs
+}
+
 // extractCNFromDN extracts the CN (Common Name) value from an LDAP DN string
 // Example: "CN=DD_0000-K8S-123-admin,OU=..." -> "DD_0000-K8S-123-admin"
 func (r *PermissionBinderReconciler) extractCNFromDN(dn string) (string, error) {
@@ -1,11 +1,20 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
 )
@@ -442,3 +451,163 @@ func TestHasRoleMappingChanged_Integration(t *testing.T) {
 	assert.False(t, changed4, "Fourth reconciliation should not detect change")
 	assert.Equal(t, hash3, hash4, "Hash should match after reconciliation")
 }
+
+// newServiceAccountPruneFixture returns a reconciler for a PermissionBinder that
+// prunes ServiceAccounts in Delete mode, whose whitelist binds payments. Writes of
+// the group RoleBinding payments/payments-admin fail while *failRoleBinding is set.
+func newServiceAccountPruneFixture(t *testing.T, failRoleBinding *bool) (*PermissionBinderReconciler, *permissionv1.PermissionBinder, *corev1.ConfigMap) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "operators"},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:           "permission-config",
+			ConfigMapNamespace:      "operators",
+			Prefixes:                []string{"COMPANY-K8S"},
+			RoleMapping:             map[string]string{"admin": "admin"},
+			ServiceAccountMapping:   map[string]string{"deploy": "edit"},
+			ServiceAccountPruneMode: ServiceAccountPruneModeDelete,
+		},
+	}
+	whitelist := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data:       map[string]string{"whitelist.txt": "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com"},
+	}
+	groupRoleBinding := func(obj client.Object) bool {
+		_, ok := obj.(*rbacv1.RoleBinding)
+		return ok && *failRoleBinding && obj.GetNamespace() == "payments" && obj.GetName() == "payments-admin"
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, whitelist).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*rbacv1.RoleBinding); ok && *failRoleBinding && key.Namespace == "payments" && key.Name == "payments-admin" {
+					return errors.New("apiserver unavailable")
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if groupRoleBinding(obj) {
+					return errors.New("apiserver unavailable")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if groupRoleBinding(obj) {
+					return errors.New("apiserver unavailable")
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).Build()
+	return &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}, pb, whitelist
+}
+
+// requireServiceAccountKept fails the test when the payments ServiceAccount or its
+// RoleBinding is gone
+func requireServiceAccountKept(t *testing.T, k8sClient client.Client) {
+	t.Helper()
+	ctx := context.Background()
+	var sa corev1.ServiceAccount
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "payments-sa-deploy"}, &sa))
+	var rb rbacv1.RoleBinding
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "sa-payments-deploy"}, &rb))
+}
+
+// TestProcessConfigMap_FailedRoleBindingKeepsServiceAccounts verifies that a
+// namespace whose group RoleBinding cannot be written is not taken for removed
+// from the whitelist: Delete mode leaves its ServiceAccounts alone
+func TestProcessConfigMap_FailedRoleBindingKeepsServiceAccounts(t *testing.T) {
+	failRoleBinding := false
+	r, pb, whitelist := newServiceAccountPruneFixture(t, &failRoleBinding)
+	ctx := context.Background()
+
+	result, err := r.processConfigMap(ctx, pb, whitelist)
+	require.NoError(t, err)
+	require.Contains(t, result.ProcessedServiceAccounts, "payments/payments-sa-deploy")
+	requireServiceAccountKept(t, r.Client)
+
+	failRoleBinding = true
+	result, err = r.processConfigMap(ctx, pb, whitelist)
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.RoleBindingErrors)
+	assert.Empty(t, result.PrunedServiceAccounts)
+	requireServiceAccountKept(t, r.Client)
+}
+
+// TestProcessConfigMap_TransferredNamespaceKeepsServiceAccounts verifies that Delete
+// mode leaves the ServiceAccounts of a handed-over namespace alone, even those the
+// handover did not re-stamp
+func TestProcessConfigMap_TransferredNamespaceKeepsServiceAccounts(t *testing.T) {
+	failRoleBinding := false
+	r, pb, whitelist := newServiceAccountPruneFixture(t, &failRoleBinding)
+	ctx := context.Background()
+
+	_, err := r.processConfigMap(ctx, pb, whitelist)
+	require.NoError(t, err)
+	requireServiceAccountKept(t, r.Client)
+
+	pb.Status.Transfers = []permissionv1.OwnershipTransferStatus{
+		{To: "operators/payments-team", Namespaces: []string{"payments"}, State: OwnershipTransferCompleted},
+	}
+	result, err := r.processConfigMap(ctx, pb, whitelist)
+	require.NoError(t, err)
+	assert.Empty(t, result.PrunedServiceAccounts)
+	requireServiceAccountKept(t, r.Client)
+}
+
+// TestProcessConfigMap_MissingGroupKeepsServiceAccounts verifies that an entry
+// skipped under missingGroupPolicy Skip leaves the ServiceAccounts of its namespace
+// alone in Delete mode - the group may only be missing for a while
+func TestProcessConfigMap_MissingGroupKeepsServiceAccounts(t *testing.T) {
+	failRoleBinding := false
+	r, pb, whitelist := newServiceAccountPruneFixture(t, &failRoleBinding)
+	ctx := context.Background()
+
+	_, err := r.processConfigMap(ctx, pb, whitelist)
+	require.NoError(t, err)
+	requireServiceAccountKept(t, r.Client)
+
+	pb.Spec.LdapGroupVerification = &permissionv1.LdapGroupVerificationSpec{
+		Enabled:            true,
+		MissingGroupPolicy: LdapMissingGroupSkip,
+	}
+	pb.Status.LdapSync = &permissionv1.LdapSyncStatus{
+		Groups: []permissionv1.LdapGroupSyncStatus{
+			{DN: "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com", State: LdapGroupMissing},
+		},
+	}
+	result, err := r.processConfigMap(ctx, pb, whitelist)
+	require.NoError(t, err)
+	assert.NotContains(t, result.ProcessedRoleBindings, "payments/payments-admin")
+	assert.Empty(t, result.PrunedServiceAccounts)
+	requireServiceAccountKept(t, r.Client)
+}
+
+// TestProcessConfigMap_InvalidOverridePatternKeepsServiceAccounts verifies that an
+// invalid namespacePatterns regex is reported and does not prune the
+// ServiceAccounts its override added while it was valid
+func TestProcessConfigMap_InvalidOverridePatternKeepsServiceAccounts(t *testing.T) {
+	failRoleBinding := false
+	r, pb, whitelist := newServiceAccountPruneFixture(t, &failRoleBinding)
+	ctx := context.Background()
+
+	pb.Spec.ServiceAccountOverrides = []permissionv1.ServiceAccountOverride{
+		{NamespacePatterns: []string{"^pay"}, ServiceAccountMapping: map[string]string{"backup": "view"}},
+	}
+	result, err := r.processConfigMap(ctx, pb, whitelist)
+	require.NoError(t, err)
+	require.Contains(t, result.ProcessedServiceAccounts, "payments/payments-sa-backup")
+
+	pb.Spec.ServiceAccountOverrides[0].NamespacePatterns = []string{"^(pay"}
+	result, err = r.processConfigMap(ctx, pb, whitelist)
+	require.NoError(t, err)
+	require.Len(t, result.ServiceAccountErrors, 1)
+	assert.Contains(t, result.ServiceAccountErrors[0].Error(), "serviceAccountOverrides[0].namespacePatterns[0]")
+	assert.Empty(t, result.PrunedServiceAccounts)
+	var sa corev1.ServiceAccount
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "payments-sa-backup"}, &sa))
+	requireServiceAccountKept(t, r.Client)
+}
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -113,3 +114,14 @@ func findCondition(conditions []metav1.Condition, conditionType string) *metav1.
 	}
 	return nil
 }
+
+// minRequeueAfter returns the shortest of the requeue delays, where zero means "no requeue"
+func minRequeueAfter(delays ...time.Duration) time.Duration {
+	var shortest time.Duration
+	for _, d := range delays {
+		if shortest == 0 || (d != 0 && d < shortest) {
+			shortest = d
+		}
+	}
+	return shortest
+}
@@ -25,11 +25,14 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
@@ -53,6 +56,14 @@ const (
 	AnnotationOrphanedAt = "permission-binder.io/orphaned-at"
 	AnnotationOrphanedBy = "permission-binder.io/orphaned-by"
 
+	// AnnotationTransitionFor marks the temporary {name}-transition RoleBinding
+	// that grants the new role while a RoleBinding's RoleRef is replaced
+	AnnotationTransitionFor = "permission-binder.io/transition-for"
+
+	// TransitionRoleBindingSuffix is appended to the name of the temporary
+	// RoleBinding used by make-before-break replacements
+	TransitionRoleBindingSuffix = "-transition"
+
 	// OrphanedByPermissionBinderDeletion is the AnnotationOrphanedBy value
 	// stamped by SAFE-MODE cleanup.
 	OrphanedByPermissionBinderDeletion = "permission-binder-deletion"
@@ -87,6 +98,22 @@ type PermissionBinderReconciler struct {
 	// created target namespaces stay visible - this is the knob that makes
 	// MANAGED_BY_VALUE safe in multi-instance deployments.
 	ReconcileNamespaces []string
+	// ClusterName is the operator-wide cluster name (--cluster-name flag,
+	// CLUSTER_NAME env). spec.clusterName of a PermissionBinder takes precedence.
+	ClusterName string
+	// Recorder emits Kubernetes Events for material actions (namespaces, RoleBindings,
+	// LDAP groups, NetworkPolicy PRs, refused ownership takeovers)
+	Recorder record.EventRecorder
+	// Frozen stops every PermissionBinder from applying changes (--freeze flag,
+	// FREEZE env); reconciliation only reports the changes it withholds
+	Frozen bool
+	// FreezeConfigMap optionally names a ConfigMap whose "frozen: true" freezes the
+	// operator at runtime (--freeze-configmap flag, FREEZE_CONFIGMAP env)
+	FreezeConfigMap types.NamespacedName
+
+	// ldapSyncEvents enqueues PermissionBinders whose missing LDAP groups changed,
+	// sent by the LDAP controller (see LdapSyncReconciler)
+	ldapSyncEvents chan event.GenericEvent
 }
 
 // reconcilesNamespace reports whether this instance reconciles PermissionBinder
@@ -111,13 +138,22 @@ func (r *PermissionBinderReconciler) Status() client.StatusWriter {
 // +kubebuilder:rbac:groups=permission.permission-binder.io,resources=permissionbinders,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=permission.permission-binder.io,resources=permissionbinders/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=permission.permission-binder.io,resources=permissionbinders/finalizers,verbs=update
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=permission.permission-binder.io,resources=bindingledgers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=permission.permission-binder.io,resources=bindingledgers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=permission.permission-binder.io,resources=bindingledgers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=permission.permission-binder.io,resources=permissionbinderreports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=permission.permission-binder.io,resources=permissionbinderreports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update;delete
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind;get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=bind;get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -163,16 +199,40 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			"finalizers", permissionBinder.Finalizers)
 	}
 
+	// Garbage collection must not act on a PermissionBinder or BindingLedger being
+	// deleted (SAFE MODE) - release their ownerReferences before anything else
+	if err := r.releaseDeletingOwners(ctx, &permissionBinder); err != nil {
+		logger.Error(err, "Failed to release the ownerReferences of a deleted owner")
+		return ctrl.Result{}, err
+	}
+
+	// Emergency freeze (--freeze, freeze ConfigMap, spec.paused): changes are
+	// computed and reported, but not applied
+	freeze, err := r.freezeFor(ctx, &permissionBinder)
+	if err != nil {
+		logger.Error(err, "Failed to check whether reconciliation is frozen")
+		return ctrl.Result{}, err
+	}
+
 	// Check if this is a deletion - clean up managed resources
 	if !permissionBinder.DeletionTimestamp.IsZero() {
 		// The object is being deleted
 		if containsString(permissionBinder.Finalizers, PermissionBinderFinalizer) {
+			if freeze != nil {
+				// Cleanup re-stamps and deletes RoleBindings - hold the finalizer
+				logger.Info("PermissionBinder is being deleted while frozen, deferring cleanup",
+					"reason", freeze.Message)
+				return ctrl.Result{RequeueAfter: frozenRequeueInterval}, nil
+			}
 			// Run finalization logic
 			logger.Info("PermissionBinder is being deleted, cleaning up managed resources")
 			if err := r.cleanupManagedResources(ctx, &permissionBinder); err != nil {
 				logger.Error(err, "Failed to cleanup managed resources")
 				return ctrl.Result{}, err
 			}
+			forgetClusterIdentityMetric(&permissionBinder)
+			forgetFreezeMetrics(&permissionBinder)
+			forgetBlockedDeletionsMetric(&permissionBinder)
 
 			// Remove finalizer to allow deletion
 			permissionBinder.Finalizers = removeString(permissionBinder.Finalizers, PermissionBinderFinalizer)
@@ -194,6 +254,21 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, nil
 	}
 
+	if freeze != nil {
+		requeueAfter, err := r.reconcileFrozen(ctx, &permissionBinder, freeze)
+		if err != nil {
+			logger.Error(err, "Failed to compute the withheld changes")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// Deletions are held back until the whole reconciliation is planned, so that
+	// spec.deletionProtection can halt a mass deletion before anything is deleted
+	deletions := newDeferredDeletionsClient(r.Client)
+	guarded := *r
+	guarded.Client = deletions
+
 	// Check if role mapping has changed
 	roleMappingChanged, currentHash := r.hasRoleMappingChanged(&permissionBinder)
 	if r.DebugMode {
@@ -207,7 +282,7 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		logger.Info("Role mapping has changed, reconciling all managed resources",
 			"currentHash", currentHash,
 			"previousHash", permissionBinder.Status.LastProcessedRoleMappingHash)
-		if err := r.reconcileAllManagedResources(ctx, &permissionBinder); err != nil {
+		if err := guarded.reconcileAllManagedResources(ctx, &permissionBinder); err != nil {
 			logger.Error(err, "Failed to reconcile all managed resources")
 			return ctrl.Result{}, err
 		}
@@ -231,24 +306,128 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			"lastProcessedConfigMapVersion", permissionBinder.Status.LastProcessedConfigMapVersion)
 	}
 
-	// Fetch the ConfigMap
+	// Resolve the cluster name once per reconciliation. It is kept on the in-memory
+	// status so that LDAP group descriptions and NetworkPolicy Git paths, branch
+	// names and metrics all use the same name.
+	clusterIdentity := r.ResolveClusterIdentity(ctx, &permissionBinder)
+	previousClusterIdentity := permissionBinder.Status.ClusterIdentity
+	permissionBinder.Status.ClusterIdentity = clusterIdentity
+	recordClusterIdentityMetric(&permissionBinder, clusterIdentity)
+
+	// Hand over the namespaces of spec.transfers before the whitelist is processed,
+	// and collect the handovers other PermissionBinders declare to this one
+	previousTransfers := permissionBinder.Status.Transfers
+	previousIncomingTransfers := permissionBinder.Status.IncomingTransfers
+	transfers := r.processOwnershipTransfers(ctx, &permissionBinder)
+	incomingTransfers, err := r.incomingTransfers(ctx, &permissionBinder)
+	if err != nil {
+		logger.Error(err, "Failed to collect incoming ownership transfers")
+		return ctrl.Result{}, err
+	}
+	permissionBinder.Status.Transfers = transfers
+	permissionBinder.Status.IncomingTransfers = incomingTransfers
+	transfersChanged := !reflect.DeepEqual(previousTransfers, transfers) ||
+		!reflect.DeepEqual(previousIncomingTransfers, incomingTransfers)
+
+	// Break-glass RoleBindings are left alone; once an annotation is removed or
+	// expires the whitelist is processed again to revert their drift
+	breakGlass, breakGlassRecheck, err := r.breakGlassRoleBindings(ctx, &permissionBinder, time.Now())
+	if err != nil {
+		logger.Error(err, "Failed to collect break-glass RoleBindings")
+		return ctrl.Result{}, err
+	}
+	newBreakGlassRoleBindings := boundedBreakGlassRoleBindings(breakGlass)
+	breakGlassChanged := !reflect.DeepEqual(permissionBinder.Status.BreakGlassRoleBindings, newBreakGlassRoleBindings)
+	recordFreezeMetrics(&permissionBinder, false, 0, len(breakGlass))
+
+	// The first reconciliation after a freeze applies the withheld changes
+	wasFrozen := meta.IsStatusConditionTrue(permissionBinder.Status.Conditions, FrozenCondition)
+
+	// Fetch the ConfigMap (or the result of the LDAP whitelist search, or the
+	// whitelist snapshot the PermissionBinder is rolled back to)
 	var configMap corev1.ConfigMap
+	var whitelistSource *permissionv1.WhitelistSourceStatus
+	whitelistFrom := WhitelistFromSource
 	configMapKey := types.NamespacedName{
 		Name:      permissionBinder.Spec.ConfigMapName,
 		Namespace: permissionBinder.Spec.ConfigMapNamespace,
 	}
-	if err := r.Get(ctx, configMapKey, &configMap); err != nil {
-		if errors.IsNotFound(err) {
+	rolledBack, err := r.rolledBackWhitelist(ctx, &permissionBinder)
+	if err != nil {
+		logger.Error(err, "Failed to roll back the whitelist", "snapshot", permissionBinder.Annotations[AnnotationRollbackTo])
+		if r.Recorder != nil {
+			r.Recorder.Event(&permissionBinder, corev1.EventTypeWarning, EventReasonWhitelistRollbackFailed, err.Error())
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	if rolledBack != nil {
+		logger.Info("Whitelist rolled back to a snapshot", "snapshot", permissionBinder.Annotations[AnnotationRollbackTo])
+		configMap = *rolledBack
+		whitelistFrom = WhitelistFromRollback
+		whitelistSource = permissionBinder.Status.WhitelistSource
+	} else if ldapWhitelistSearchEnabled(&permissionBinder) {
+		ldapWhitelist, sourceStatus, err := r.getLdapWhitelistConfigMap(ctx, &permissionBinder, time.Now())
+		if err != nil {
+			logger.Error(err, "Failed to get whitelist from LDAP search")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		configMap = *ldapWhitelist
+		whitelistSource = sourceStatus
+	} else if err := r.Get(ctx, configMapKey, &configMap); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get ConfigMap", "configMap", configMapKey)
+			return ctrl.Result{}, err
+		}
+		fallback, fallbackErr := r.fallbackWhitelist(ctx, &permissionBinder)
+		if fallbackErr != nil {
+			logger.Error(fallbackErr, "Failed to load the last-known-good whitelist snapshot")
+		}
+		if fallback == nil {
 			logger.Info("ConfigMap not found", "configMap", configMapKey)
 			return ctrl.Result{RequeueAfter: time.Minute}, nil
 		}
-		logger.Error(err, "Failed to get ConfigMap", "configMap", configMapKey)
-		return ctrl.Result{}, err
+		logger.Info("ConfigMap not found, using the last-known-good whitelist snapshot",
+			"configMap", configMapKey,
+			"snapshot", strings.TrimPrefix(fallback.ResourceVersion, whitelistSnapshotVersionPrefix))
+		if r.Recorder != nil && !strings.HasPrefix(permissionBinder.Status.LastProcessedConfigMapVersion, whitelistSnapshotVersionPrefix) {
+			r.Recorder.Eventf(&permissionBinder, corev1.EventTypeWarning, EventReasonWhitelistFallback,
+				"ConfigMap %s not found, using the last-known-good whitelist snapshot %s",
+				configMapKey, strings.TrimPrefix(fallback.ResourceVersion, whitelistSnapshotVersionPrefix))
+		}
+		configMap = *fallback
+		whitelistFrom = WhitelistFromFallback
 	}
 
 	// Check if ConfigMap has changed
 	configMapVersion := configMap.ResourceVersion
 
+	// LDAP directory work runs in the LDAP controller; the RoleBindings only depend
+	// on the groups it found missing (ldapGroupVerification with policy Skip)
+	ldapMissingGroupsHash := ldapSkipMissingGroupsHash(&permissionBinder)
+	ldapUpToDate := permissionBinder.Status.LastProcessedLdapMissingGroupsHash == ldapMissingGroupsHash
+	// LDAP group deletions that are due are checked against deletionProtection
+	ldapGroups, err := r.trackedLdapGroups(ctx, &permissionBinder)
+	if err != nil {
+		logger.Error(err, "Failed to read the tracked LDAP groups")
+		return ctrl.Result{}, err
+	}
+	ldapUpToDate = ldapUpToDate && !ldapGroupDeletionDue(&permissionBinder, ldapGroups, time.Now())
+
+	// Enabling or disabling whitelist snapshots, and switching between a rollback
+	// and a fallback on the same snapshot, take effect without a whitelist change
+	snapshotsUpToDate := whitelistSnapshotsEnabled(&permissionBinder) == (permissionBinder.Status.WhitelistSnapshot != nil) &&
+		(permissionBinder.Status.WhitelistSnapshot == nil || permissionBinder.Status.WhitelistSnapshot.Source == whitelistFrom)
+
+	// Toggling spec.ownerReferences and a deleted BindingLedger need a full pass
+	ownerReferencesUpToDate := r.ownerReferencesUpToDate(ctx, &permissionBinder)
+
+	// A new generation is always processed (excludeList, serviceAccountMapping, ...),
+	// and failed RoleBinding, ServiceAccount and whitelist snapshot writes are retried
+	generationObserved := permissionBinder.Status.ObservedGeneration == permissionBinder.Generation
+	writesFailed := meta.IsStatusConditionFalse(permissionBinder.Status.Conditions, RBACReconciledCondition) ||
+		meta.IsStatusConditionFalse(permissionBinder.Status.Conditions, ServiceAccountsReconciledCondition) ||
+		meta.IsStatusConditionFalse(permissionBinder.Status.Conditions, WhitelistSnapshotRecordedCondition)
+
 	// Re-check role mapping hash after re-fetch (in case it was updated)
 	// This ensures we don't incorrectly think role mapping changed when it didn't
 	roleMappingChangedAfterRefetch, currentHashAfterRefetch := r.hasRoleMappingChanged(&permissionBinder)
@@ -271,22 +450,87 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			"lastProcessedVersion", permissionBinder.Status.LastProcessedConfigMapVersion,
 			"roleMappingChanged", roleMappingChanged,
 			"roleMappingChangedAfterRefetch", roleMappingChangedAfterRefetch,
-			"skipReconciliation", permissionBinder.Status.LastProcessedConfigMapVersion == configMapVersion && !roleMappingChanged)
+			"ldapUpToDate", ldapUpToDate,
+			"transfersChanged", transfersChanged,
+			"breakGlassChanged", breakGlassChanged,
+			"wasFrozen", wasFrozen,
+			"snapshotsUpToDate", snapshotsUpToDate,
+			"ownerReferencesUpToDate", ownerReferencesUpToDate,
+			"generationObserved", generationObserved,
+			"writesFailed", writesFailed,
+			"skipReconciliation", permissionBinder.Status.LastProcessedConfigMapVersion == configMapVersion && !roleMappingChanged && ldapUpToDate && !transfersChanged && !breakGlassChanged && !wasFrozen && snapshotsUpToDate && ownerReferencesUpToDate && generationObserved && !writesFailed)
 	}
-	if permissionBinder.Status.LastProcessedConfigMapVersion == configMapVersion && !roleMappingChanged {
+	if permissionBinder.Status.LastProcessedConfigMapVersion == configMapVersion && !roleMappingChanged && ldapUpToDate && !transfersChanged && !breakGlassChanged && !wasFrozen && snapshotsUpToDate && ownerReferencesUpToDate && generationObserved && !writesFailed {
 		if r.DebugMode {
 			logger.Info("🔍 DEBUG: Skipping reconciliation - no changes detected",
 				"configMapVersion", configMapVersion,
 				"roleMappingChanged", roleMappingChanged)
 		}
 		logger.Info("ConfigMap and role mapping have not changed, skipping reconciliation")
-		return ctrl.Result{}, nil
+
+		// TokenRequest tokens expire regardless of ConfigMap changes - refresh
+		// the ones that are due and requeue for the next refresh
+		now := time.Now()
+		trackedTokens, err := r.trackedServiceAccountTokens(ctx, &permissionBinder)
+		if err != nil {
+			logger.Error(err, "Failed to read the tracked ServiceAccount tokens")
+			return ctrl.Result{}, err
+		}
+		tokens := RefreshServiceAccountTokens(ctx, r.Client, trackedTokens,
+			permissionBinder.Name, permissionBinder.Namespace, now)
+		reports := permissionBinder.Status.Reports
+		if !reflect.DeepEqual(trackedTokens, tokens) || permissionBinder.Status.ServiceAccountTokens != nil {
+			if reports, err = r.storeServiceAccountTokens(ctx, &permissionBinder, tokens); err != nil {
+				logger.Error(err, "Failed to store ServiceAccount tokens in PermissionBinderReports")
+				return ctrl.Result{}, err
+			}
+		}
+		if !reflect.DeepEqual(permissionBinder.Status.Reports, reports) ||
+			permissionBinder.Status.ServiceAccountTokens != nil ||
+			!reflect.DeepEqual(permissionBinder.Status.WhitelistSource, whitelistSource) ||
+			!reflect.DeepEqual(previousClusterIdentity, clusterIdentity) {
+			err := r.updateStatus(ctx, &permissionBinder, func(pb *permissionv1.PermissionBinder) {
+				pb.Status.Reports = reports
+				pb.Status.ServiceAccountTokens = nil
+				pb.Status.WhitelistSource = whitelistSource
+				pb.Status.ClusterIdentity = clusterIdentity
+			})
+			if err != nil {
+				logger.Error(err, "Failed to update ServiceAccount token status")
+				return ctrl.Result{}, err
+			}
+		}
+		requeueAfter := minRequeueAfter(
+			nextServiceAccountTokenRefresh(tokens, now),
+			nextLdapWhitelistSearch(&permissionBinder, whitelistSource, now),
+			nextLdapGroupDeletion(&permissionBinder, ldapGroups, now),
+			breakGlassRecheck)
+		if !transfersUpToDate(&permissionBinder) {
+			requeueAfter = minRequeueAfter(requeueAfter, time.Minute)
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	if r.DebugMode {
 		reason := "Role mapping changed"
 		if permissionBinder.Status.LastProcessedConfigMapVersion != configMapVersion {
 			reason = "ConfigMap version changed"
+		} else if !ldapUpToDate {
+			reason = "Missing LDAP groups changed or LDAP group deletions due"
+		} else if transfersChanged {
+			reason = "Ownership transfers changed"
+		} else if breakGlassChanged {
+			reason = "Break-glass RoleBindings changed"
+		} else if wasFrozen {
+			reason = "Freeze lifted"
+		} else if !snapshotsUpToDate {
+			reason = "Whitelist snapshots toggled"
+		} else if !ownerReferencesUpToDate {
+			reason = "ownerReferences toggled or BindingLedger deleted"
+		} else if !generationObserved {
+			reason = "Spec changed"
+		} else if writesFailed {
+			reason = "Retrying failed writes"
 		}
 		logger.Info("🔍 DEBUG: Processing ConfigMap",
 			"reason", reason,
@@ -296,14 +540,75 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	// Process ConfigMap data
-	result, err := r.processConfigMap(ctx, &permissionBinder, &configMap)
+	result, err := guarded.processConfigMap(ctx, &permissionBinder, &configMap)
 	if err != nil {
 		logger.Error(err, "Failed to process ConfigMap")
 		return ctrl.Result{}, err
 	}
 
-	// Process NetworkPolicies if enabled
-	if permissionBinder.Spec.NetworkPolicy != nil && permissionBinder.Spec.NetworkPolicy.Enabled {
+	// NetworkPolicy state written to the status by earlier versions moves to the
+	// PermissionBinderReports before the NetworkPolicy code reads it from there
+	if err := r.migrateNetworkPolicyStatus(ctx, &permissionBinder); err != nil {
+		logger.Error(err, "Failed to migrate NetworkPolicy status to PermissionBinderReports")
+		return ctrl.Result{}, err
+	}
+
+	// LDAP group deletions and NetworkPolicy removal Pull Requests count in the same
+	// deletionProtection budget as the held back deletions
+	networkPoliciesEnabled := permissionBinder.Spec.NetworkPolicy != nil && permissionBinder.Spec.NetworkPolicy.Enabled
+	var networkPolicyRemovals []string
+	if networkPoliciesEnabled {
+		// Namespaces handed over to another PermissionBinder keep their NetworkPolicies
+		current := transferredNamespaces(&permissionBinder)
+		for ns := range groupByNamespace(result.ProcessedRoleBindings) {
+			current[ns] = true
+		}
+		if networkPolicyRemovals, err = networkpolicy.RemovedNamespaces(ctx, r, &permissionBinder, current); err != nil {
+			logger.Error(err, "Failed to list the namespaces removed from the whitelist")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Execute the planned deletions unless they exceed spec.deletionProtection
+	blockedDeletions, err := r.applyDeletions(ctx, &permissionBinder, deletions,
+		dueLdapGroupDeletions(&permissionBinder, ldapGroups, time.Now()), networkPolicyRemovals)
+	if err != nil {
+		logger.Error(err, "Failed to check the planned deletions against deletionProtection")
+		return ctrl.Result{}, err
+	}
+	recordBlockedDeletionsMetric(&permissionBinder, blockedDeletions)
+
+	// Keep the applied whitelist as the last-known-good snapshot
+	var newWhitelistSnapshot *permissionv1.WhitelistSnapshotStatus
+	var snapshotErrs []error
+	if whitelistSnapshotsEnabled(&permissionBinder) {
+		newWhitelistSnapshot = permissionBinder.Status.WhitelistSnapshot
+		if blockedDeletions == nil {
+			snapshot, err := r.recordWhitelistSnapshot(ctx, &permissionBinder, configMap.Data["whitelist.txt"], configMapVersion, time.Now())
+			if err != nil {
+				logger.Error(err, "Failed to record the whitelist snapshot (non-fatal)")
+				snapshotErrs = append(snapshotErrs, err)
+			} else {
+				newWhitelistSnapshot = snapshot
+			}
+		}
+		if newWhitelistSnapshot != nil {
+			newWhitelistSnapshot = newWhitelistSnapshot.DeepCopy()
+			newWhitelistSnapshot.Source = whitelistFrom
+		}
+	}
+
+	// Let standard garbage collection and visualisation tools see what is owned
+	newOwnerReferences, err := r.reconcileOwnerReferences(ctx, &permissionBinder)
+	if err != nil {
+		logger.Error(err, "Failed to reconcile ownerReferences (non-fatal)")
+		newOwnerReferences = permissionBinder.Status.OwnerReferences
+	}
+
+	// Process NetworkPolicies if enabled; failures are non-fatal and reported in the
+	// NetworkPoliciesSynced condition
+	var networkPolicyErrs []error
+	if networkPoliciesEnabled {
 		// Check for multiple PermissionBinder CRs with NetworkPolicy enabled
 		if err := networkpolicy.CheckMultiplePermissionBinders(ctx, r, r.ReconcileNamespaces); err != nil {
 			logger.Error(err, "Failed to check multiple PermissionBinders (non-fatal)")
@@ -338,14 +643,24 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 				"count", len(namespaceList))
 			if err := networkpolicy.ProcessNetworkPoliciesForNamespaces(ctx, r, &permissionBinder, namespaceList); err != nil {
 				logger.Error(err, "Failed to process NetworkPolicies (non-fatal)")
+				networkPolicyErrs = append(networkPolicyErrs, err)
 				// Continue - don't fail reconciliation
 			}
 		}
 
-		// Process removed namespaces (check for namespaces that were removed from whitelist)
-		if err := networkpolicy.ProcessRemovedNamespaces(ctx, r, &permissionBinder, namespaces); err != nil {
-			logger.Error(err, "Failed to process removed namespaces (non-fatal)")
-			// Continue - don't fail reconciliation
+		// Namespaces handed over to another PermissionBinder keep their NetworkPolicies
+		for ns := range transferredNamespaces(&permissionBinder) {
+			namespaces[ns] = true
+		}
+
+		// Process removed namespaces (check for namespaces that were removed from whitelist),
+		// unless their removal Pull Requests wait for the deletionProtection acknowledgement
+		if blockedDeletions == nil {
+			if err := networkpolicy.ProcessRemovedNamespaces(ctx, r, &permissionBinder, namespaces); err != nil {
+				logger.Error(err, "Failed to process removed namespaces (non-fatal)")
+				networkPolicyErrs = append(networkPolicyErrs, err)
+				// Continue - don't fail reconciliation
+			}
 		}
 
 		// Periodic reconciliation: Check if it's time for periodic reconciliation
@@ -375,6 +690,7 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			logger.Info("Running periodic NetworkPolicy reconciliation")
 			if err := networkpolicy.PeriodicNetworkPolicyReconciliation(ctx, r, &permissionBinder); err != nil {
 				logger.Error(err, "Failed to run periodic NetworkPolicy reconciliation (non-fatal)")
+				networkPolicyErrs = append(networkPolicyErrs, err)
 				// Continue - don't fail reconciliation
 			}
 		}
@@ -382,30 +698,63 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		// Cleanup status (remove old entries after retention period)
 		if err := networkpolicy.CleanupStatus(ctx, r, &permissionBinder, namespaces); err != nil {
 			logger.Error(err, "Failed to cleanup NetworkPolicy status (non-fatal)")
+			networkPolicyErrs = append(networkPolicyErrs, err)
 			// Continue - don't fail reconciliation
 		}
 	}
 
+	// The per-namespace RoleBindings, ServiceAccounts and token Secrets go to the
+	// PermissionBinderReports; the status only keeps their summary
+	newReports, err := r.reconcileReports(ctx, &permissionBinder, result.ProcessedRoleBindings, result.ProcessedServiceAccounts,
+		result.ServiceAccountTokens)
+	if err != nil {
+		logger.Error(err, "Failed to update PermissionBinderReports")
+		return ctrl.Result{}, err
+	}
+
 	// Prepare new status values
 	newProcessedRoleBindings := result.ProcessedRoleBindings
 	newProcessedServiceAccounts := result.ProcessedServiceAccounts
+	newPrunedServiceAccounts := len(result.PrunedServiceAccounts)
+	newOrphanedServiceAccounts := len(result.OrphanedServiceAccounts)
+	newOwnershipConflicts := boundedOwnershipConflicts(result.OwnershipConflicts)
+	newEntries, newEntryResults := summarizeEntryResults(result.EntryResults)
+	newConflictCondition := ownershipConflictCondition(permissionBinder.Generation, len(result.OwnershipConflicts))
+	newFrozenCondition := frozenCondition(permissionBinder.Generation, nil, 0)
+	newBreakGlassCondition := breakGlassCondition(permissionBinder.Generation, len(breakGlass))
 	newConfigMapVersion := configMapVersion
+	newWhitelistSource := whitelistSource
 	newRoleMappingHash := permissionBinder.Status.LastProcessedRoleMappingHash
 	if roleMappingChanged {
 		newRoleMappingHash = currentHash
 	}
+	newBlockedCondition := blockedCondition(permissionBinder.Generation, blockedDeletions)
+	if blockedDeletions != nil {
+		// Nothing was deleted - keep the whitelist and role mapping unprocessed so that
+		// the deletions are planned again once acknowledged
+		newConfigMapVersion = permissionBinder.Status.LastProcessedConfigMapVersion
+		newRoleMappingHash = permissionBinder.Status.LastProcessedRoleMappingHash
+		newPrunedServiceAccounts = 0
+	}
 
 	// Check if status actually changed before updating
 	// This prevents unnecessary ResourceVersion changes
 	statusChanged := false
 
-	// Compare ProcessedRoleBindings
-	if !reflect.DeepEqual(permissionBinder.Status.ProcessedRoleBindings, newProcessedRoleBindings) {
+	// Compare the report summary
+	if !reflect.DeepEqual(permissionBinder.Status.Reports, newReports) {
+		statusChanged = true
+	}
+
+	// Clear the per-item lists written by earlier versions
+	if permissionBinder.Status.ProcessedRoleBindings != nil || permissionBinder.Status.ProcessedServiceAccounts != nil ||
+		permissionBinder.Status.NetworkPolicies != nil || permissionBinder.Status.ServiceAccountTokens != nil {
 		statusChanged = true
 	}
 
-	// Compare ProcessedServiceAccounts
-	if !reflect.DeepEqual(permissionBinder.Status.ProcessedServiceAccounts, newProcessedServiceAccounts) {
+	// Compare ServiceAccount prune counts
+	if permissionBinder.Status.PrunedServiceAccounts != newPrunedServiceAccounts ||
+		permissionBinder.Status.OrphanedServiceAccounts != newOrphanedServiceAccounts {
 		statusChanged = true
 	}
 
@@ -414,11 +763,70 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		statusChanged = true
 	}
 
+	// Compare the missing LDAP groups the RoleBindings were computed with
+	if permissionBinder.Status.LastProcessedLdapMissingGroupsHash != ldapMissingGroupsHash {
+		statusChanged = true
+	}
+
+	// Compare LDAP whitelist source
+	if !reflect.DeepEqual(permissionBinder.Status.WhitelistSource, newWhitelistSource) {
+		statusChanged = true
+	}
+
+	// Compare cluster identity
+	if !reflect.DeepEqual(previousClusterIdentity, clusterIdentity) {
+		statusChanged = true
+	}
+
+	// Compare outgoing and incoming ownership transfers
+	if transfersChanged {
+		statusChanged = true
+	}
+
 	// Compare role mapping hash
 	if permissionBinder.Status.LastProcessedRoleMappingHash != newRoleMappingHash {
 		statusChanged = true
 	}
 
+	// Compare the per-entry results
+	if !reflect.DeepEqual(permissionBinder.Status.Entries, newEntries) ||
+		!reflect.DeepEqual(permissionBinder.Status.EntryResults, newEntryResults) {
+		statusChanged = true
+	}
+
+	// Compare ownership conflicts and their condition
+	if !reflect.DeepEqual(permissionBinder.Status.OwnershipConflicts, newOwnershipConflicts) {
+		statusChanged = true
+	}
+	existingConflictCondition := findCondition(permissionBinder.Status.Conditions, OwnershipConflictCondition)
+	if existingConflictCondition == nil || existingConflictCondition.Status != newConflictCondition.Status ||
+		existingConflictCondition.Message != newConflictCondition.Message {
+		statusChanged = true
+	}
+
+	// Compare the freeze and break-glass reports
+	if permissionBinder.Status.WithheldChanges != nil || breakGlassChanged ||
+		conditionChanged(permissionBinder.Status.Conditions, newFrozenCondition) ||
+		conditionChanged(permissionBinder.Status.Conditions, newBreakGlassCondition) {
+		statusChanged = true
+	}
+
+	// Compare the whitelist snapshots
+	if !reflect.DeepEqual(permissionBinder.Status.WhitelistSnapshot, newWhitelistSnapshot) {
+		statusChanged = true
+	}
+
+	// Compare the ownerReferences report
+	if !reflect.DeepEqual(permissionBinder.Status.OwnerReferences, newOwnerReferences) {
+		statusChanged = true
+	}
+
+	// Compare the deletions halted by deletionProtection
+	if !reflect.DeepEqual(permissionBinder.Status.BlockedDeletions, blockedDeletions) ||
+		conditionChanged(permissionBinder.Status.Conditions, newBlockedCondition) {
+		statusChanged = true
+	}
+
 	// Check if Conditions need update (only update LastTransitionTime if status changed)
 	conditionMessage := fmt.Sprintf("Successfully processed %d role bindings and %d service accounts", len(newProcessedRoleBindings), len(newProcessedServiceAccounts))
 	existingCondition := findCondition(permissionBinder.Status.Conditions, "Processed")
@@ -426,6 +834,53 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		statusChanged = true
 	}
 
+	// The component conditions, and Ready and Degraded derived from them
+	generation := permissionBinder.Generation
+	newWhitelistParsedCondition := whitelistParsedCondition(generation, result.WhitelistFound, newEntries.Total, newEntries.Invalid)
+	newRBACCondition := failureCondition(RBACReconciledCondition, generation, result.RoleBindingErrors,
+		fmt.Sprintf("%d RoleBindings reconciled", len(newProcessedRoleBindings)),
+		"RoleBindingsFailed", "namespace or RoleBinding writes")
+	newServiceAccountsCondition := failureCondition(ServiceAccountsReconciledCondition, generation, result.ServiceAccountErrors,
+		fmt.Sprintf("%d ServiceAccounts reconciled", len(newProcessedServiceAccounts)),
+		"ServiceAccountsFailed", "ServiceAccount operations")
+	serviceAccountsConfigured := len(permissionBinder.Spec.ServiceAccountMapping) > 0 || len(permissionBinder.Spec.ServiceAccounts) > 0 ||
+		len(permissionBinder.Spec.ServiceAccountOverrides) > 0 || len(result.ServiceAccountErrors) > 0
+	newNetworkPoliciesCondition := failureCondition(NetworkPoliciesSyncedCondition, generation, networkPolicyErrs,
+		"NetworkPolicies synced", "NetworkPolicySyncFailed", "NetworkPolicy steps")
+	snapshotVersions := 0
+	if newWhitelistSnapshot != nil {
+		snapshotVersions = newWhitelistSnapshot.Versions
+	}
+	newWhitelistSnapshotCondition := failureCondition(WhitelistSnapshotRecordedCondition, generation, snapshotErrs,
+		fmt.Sprintf("%d whitelist snapshots kept", snapshotVersions), "SnapshotFailed", "whitelist snapshot writes")
+	setConditions := func(pb *permissionv1.PermissionBinder) {
+		// SetStatusCondition preserves LastTransitionTime while the status is
+		// unchanged and keeps the conditions of the LDAP controller
+		meta.SetStatusCondition(&pb.Status.Conditions, metav1.Condition{
+			Type:               "Processed",
+			Status:             metav1.ConditionTrue,
+			Reason:             "ConfigMapProcessed",
+			Message:            conditionMessage,
+			ObservedGeneration: generation,
+		})
+		meta.SetStatusCondition(&pb.Status.Conditions, newConflictCondition)
+		meta.SetStatusCondition(&pb.Status.Conditions, newFrozenCondition)
+		meta.SetStatusCondition(&pb.Status.Conditions, newBreakGlassCondition)
+		meta.SetStatusCondition(&pb.Status.Conditions, newBlockedCondition)
+		meta.SetStatusCondition(&pb.Status.Conditions, newWhitelistParsedCondition)
+		meta.SetStatusCondition(&pb.Status.Conditions, newRBACCondition)
+		setComponentCondition(pb, newServiceAccountsCondition, serviceAccountsConfigured)
+		setComponentCondition(pb, newNetworkPoliciesCondition, networkPoliciesEnabled)
+		setComponentCondition(pb, newWhitelistSnapshotCondition, whitelistSnapshotsEnabled(&permissionBinder))
+		setSummaryConditions(pb)
+	}
+	probe := permissionBinder.DeepCopy()
+	setConditions(probe)
+	if !reflect.DeepEqual(permissionBinder.Status.Conditions, probe.Status.Conditions) ||
+		permissionBinder.Status.ObservedGeneration != generation {
+		statusChanged = true
+	}
+
 	// Only update status if something actually changed
 	if !statusChanged {
 		if r.DebugMode {
@@ -437,40 +892,31 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		logger.Info("Status unchanged, skipping status update")
 	} else {
 		// Update status - do this in a single update to avoid multiple ResourceVersion changes
-		permissionBinder.Status.ProcessedRoleBindings = newProcessedRoleBindings
-		permissionBinder.Status.ProcessedServiceAccounts = newProcessedServiceAccounts
-		permissionBinder.Status.LastProcessedConfigMapVersion = newConfigMapVersion
-		permissionBinder.Status.LastProcessedRoleMappingHash = newRoleMappingHash
-
-		// Update Conditions - preserve LastTransitionTime if condition already exists with same status
-		now := metav1.Now()
-		if existingCondition != nil && existingCondition.Status == metav1.ConditionTrue && existingCondition.Message == conditionMessage {
-			// Condition already exists with same status, preserve LastTransitionTime
-			permissionBinder.Status.Conditions = []metav1.Condition{
-				{
-					Type:               "Processed",
-					Status:             metav1.ConditionTrue,
-					LastTransitionTime: existingCondition.LastTransitionTime, // Preserve original time
-					Reason:             "ConfigMapProcessed",
-					Message:            conditionMessage,
-					ObservedGeneration: permissionBinder.Generation,
-				},
-			}
-		} else {
-			// New condition or status changed, use current time
-			permissionBinder.Status.Conditions = []metav1.Condition{
-				{
-					Type:               "Processed",
-					Status:             metav1.ConditionTrue,
-					LastTransitionTime: now,
-					Reason:             "ConfigMapProcessed",
-					Message:            conditionMessage,
-					ObservedGeneration: permissionBinder.Generation,
-				},
-			}
-		}
-
-		if err := r.Status().Update(ctx, &permissionBinder); err != nil {
+		// Retried on conflicts with the LDAP controller and the NetworkPolicy status writes
+		err := r.updateStatus(ctx, &permissionBinder, func(pb *permissionv1.PermissionBinder) {
+			pb.Status.Reports = newReports
+			pb.Status.ProcessedRoleBindings = nil
+			pb.Status.ProcessedServiceAccounts = nil
+			pb.Status.NetworkPolicies = nil
+			pb.Status.Entries = newEntries
+			pb.Status.EntryResults = newEntryResults
+			pb.Status.PrunedServiceAccounts = newPrunedServiceAccounts
+			pb.Status.OrphanedServiceAccounts = newOrphanedServiceAccounts
+			pb.Status.ServiceAccountTokens = nil
+			pb.Status.LastProcessedConfigMapVersion = newConfigMapVersion
+			pb.Status.LastProcessedLdapMissingGroupsHash = ldapMissingGroupsHash
+			pb.Status.WhitelistSource = newWhitelistSource
+			pb.Status.LastProcessedRoleMappingHash = newRoleMappingHash
+			pb.Status.OwnershipConflicts = newOwnershipConflicts
+			pb.Status.WithheldChanges = nil
+			pb.Status.BreakGlassRoleBindings = newBreakGlassRoleBindings
+			pb.Status.BlockedDeletions = blockedDeletions
+			pb.Status.WhitelistSnapshot = newWhitelistSnapshot
+			pb.Status.OwnerReferences = newOwnerReferences
+			pb.Status.ObservedGeneration = generation
+			setConditions(pb)
+		})
+		if err != nil {
 			logger.Error(err, "Failed to update PermissionBinder status")
 			return ctrl.Result{}, err
 		}
@@ -493,5 +939,18 @@ func (r *PermissionBinderReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	logger.Info("Successfully processed ConfigMap",
 		"roleBindings", len(result.ProcessedRoleBindings),
 		"serviceAccounts", len(result.ProcessedServiceAccounts))
-	return ctrl.Result{}, nil
+	requeueAfter := minRequeueAfter(
+		nextServiceAccountTokenRefresh(result.ServiceAccountTokens, time.Now()),
+		nextLdapWhitelistSearch(&permissionBinder, newWhitelistSource, time.Now()),
+		nextLdapGroupDeletion(&permissionBinder, ldapGroups, time.Now()),
+		breakGlassRecheck)
+	if !transfersUpToDate(&permissionBinder) {
+		// Retry pending ownership transfers (receiver missing or re-stamping failed)
+		requeueAfter = minRequeueAfter(requeueAfter, time.Minute)
+	}
+	if len(result.RoleBindingErrors) > 0 || len(result.ServiceAccountErrors) > 0 || len(snapshotErrs) > 0 {
+		// Retry failed RoleBinding, ServiceAccount and whitelist snapshot writes
+		requeueAfter = minRequeueAfter(requeueAfter, time.Minute)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
@@ -27,13 +27,16 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
 )
 
-// ensureNamespace creates a namespace if it doesn't exist
-func (r *PermissionBinderReconciler) ensureNamespace(ctx context.Context, namespace string, permissionBinder *permissionv1.PermissionBinder) error {
+// ensureNamespace creates a namespace if it doesn't exist. It returns the
+// conflict (with a nil error) when the namespace is claimed by another
+// PermissionBinder and the ownership gate refused the takeover.
+func (r *PermissionBinderReconciler) ensureNamespace(ctx context.Context, namespace string, permissionBinder *permissionv1.PermissionBinder) (*permissionv1.OwnershipConflict, error) {
 	logger := log.FromContext(ctx)
 	var ns corev1.Namespace
 	err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns)
@@ -56,10 +59,12 @@ func (r *PermissionBinderReconciler) ensureNamespace(ctx context.Context, namesp
 				},
 			}
 			if err := r.Create(ctx, &ns); err != nil {
-				return fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+				return nil, fmt.Errorf("failed to create namespace %s: %w", namespace, err)
 			}
+			r.recordEvent(permissionBinder, &ns, corev1.EventTypeNormal, EventReasonNamespaceCreated,
+				"Created namespace %s for PermissionBinder %s/%s", namespace, permissionBinder.Namespace, permissionBinder.Name)
 		} else {
-			return fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+			return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
 		}
 	} else {
 		// OWNERSHIP GATE (issue #43): never take over a namespace with a live
@@ -73,11 +78,13 @@ func (r *PermissionBinderReconciler) ensureNamespace(ctx context.Context, namesp
 				"claimedByNamespace", ns.Annotations[AnnotationPermissionBinderNamespace],
 				"reconciledBy", permissionBinder.Name,
 				"reconciledByNamespace", permissionBinder.Namespace)
-			return nil
+			return r.recordOwnershipConflict(permissionBinder, "Namespace", &ns), nil
 		}
 
 		// Update existing namespace with annotations if not present
 		needsUpdate := false
+		// Taking over an orphaned or unclaimed namespace is an adoption
+		adopted := ns.Annotations[AnnotationOrphanedAt] != "" || ns.Annotations[AnnotationPermissionBinder] != permissionBinder.Name
 		if ns.Annotations == nil {
 			ns.Annotations = make(map[string]string)
 		}
@@ -125,11 +132,15 @@ func (r *PermissionBinderReconciler) ensureNamespace(ctx context.Context, namesp
 
 		if needsUpdate {
 			if err := r.Update(ctx, &ns); err != nil {
-				return fmt.Errorf("failed to update namespace %s: %w", namespace, err)
+				return nil, fmt.Errorf("failed to update namespace %s: %w", namespace, err)
+			}
+			if adopted {
+				r.recordEvent(permissionBinder, &ns, corev1.EventTypeNormal, EventReasonNamespaceAdopted,
+					"Namespace %s adopted by PermissionBinder %s/%s", namespace, permissionBinder.Namespace, permissionBinder.Name)
 			}
 		}
 	}
-	return nil
+	return nil, nil
 }
 
 // validateClusterRoleExists checks if the ClusterRole exists and logs a warning if it doesn't
@@ -159,12 +170,23 @@ func (r *PermissionBinderReconciler) validateClusterRoleExists(ctx context.Conte
 	return true
 }
 
+// recordClusterRoleMissing warns on the PermissionBinder and the written RoleBinding
+// that the RoleBinding grants nothing until its ClusterRole is created
+func (r *PermissionBinderReconciler) recordClusterRoleMissing(permissionBinder *permissionv1.PermissionBinder, roleBinding *rbacv1.RoleBinding, clusterRoleExists bool) {
+	if clusterRoleExists {
+		return
+	}
+	r.recordEvent(permissionBinder, roleBinding, corev1.EventTypeWarning, EventReasonClusterRoleMissing,
+		"RoleBinding %s/%s references ClusterRole %s, which does not exist - no permissions are granted until it is created",
+		roleBinding.Namespace, roleBinding.Name, roleBinding.RoleRef.Name)
+}
+
 // createRoleBinding ensures the desired RoleBinding exists and is owned by
-// the given PermissionBinder. It returns managed=false (with a nil error) when
+// the given PermissionBinder. It returns the conflict (with a nil error) when
 // the RoleBinding is claimed by another PermissionBinder and the ownership
 // gate refused the takeover - the caller must not report such an entry as
 // successfully processed.
-func (r *PermissionBinderReconciler) createRoleBinding(ctx context.Context, namespace, name, role, group, clusterRole string, permissionBinder *permissionv1.PermissionBinder) (managed bool, err error) {
+func (r *PermissionBinderReconciler) createRoleBinding(ctx context.Context, namespace, name, role, group, clusterRole string, permissionBinder *permissionv1.PermissionBinder) (conflict *permissionv1.OwnershipConflict, err error) {
 	logger := log.FromContext(ctx)
 	now := time.Now().Format(time.RFC3339)
 
@@ -216,10 +238,19 @@ func (r *PermissionBinderReconciler) createRoleBinding(ctx context.Context, name
 		if errors.IsNotFound(err) {
 			// Create new RoleBinding
 			if err := r.Create(ctx, roleBinding); err != nil {
-				return false, fmt.Errorf("failed to create RoleBinding %s/%s: %w", namespace, name, err)
+				return nil, fmt.Errorf("failed to create RoleBinding %s/%s: %w", namespace, name, err)
+			}
+			r.recordEvent(permissionBinder, roleBinding, corev1.EventTypeNormal, EventReasonRoleBindingCreated,
+				"Created RoleBinding %s/%s granting ClusterRole %s to group %s", namespace, name, clusterRole, group)
+			r.recordClusterRoleMissing(permissionBinder, roleBinding, clusterRoleExists)
+			// Finish an interrupted make-before-break replacement
+			if err := cleanupTransitionRoleBinding(ctx, r.Client, namespace, name); err != nil {
+				logger.Error(err, "Failed to clean up transition RoleBinding (non-fatal)",
+					"namespace", namespace,
+					"roleBinding", name)
 			}
 		} else {
-			return false, fmt.Errorf("failed to get RoleBinding %s/%s: %w", namespace, name, err)
+			return nil, fmt.Errorf("failed to get RoleBinding %s/%s: %w", namespace, name, err)
 		}
 	} else {
 		// OWNERSHIP GATE (issue #43): never overwrite a RoleBinding with a live
@@ -235,7 +266,17 @@ func (r *PermissionBinderReconciler) createRoleBinding(ctx context.Context, name
 				"claimedByNamespace", existing.Annotations[AnnotationPermissionBinderNamespace],
 				"reconciledBy", permissionBinder.Name,
 				"reconciledByNamespace", permissionBinder.Namespace)
-			return false, nil
+			return r.recordOwnershipConflict(permissionBinder, "RoleBinding", &existing), nil
+		}
+
+		// BREAK-GLASS: a RoleBinding edited during an incident is left alone,
+		// drift included, until its annotation is removed or expires
+		if breakGlassActive(existing.Annotations, time.Now()) {
+			logger.Info("Leaving break-glass RoleBinding alone",
+				"namespace", namespace,
+				"roleBinding", name,
+				"breakGlass", existing.Annotations[AnnotationBreakGlass])
+			return nil, nil
 		}
 
 		// Check if RoleBinding needs update - avoid unnecessary updates that change ResourceVersion
@@ -290,11 +331,15 @@ func (r *PermissionBinderReconciler) createRoleBinding(ctx context.Context, name
 		// Only update if something actually changed - this prevents unnecessary ResourceVersion changes
 		if !needsUpdate {
 			// RoleBinding is already up-to-date, no update needed
-			return true, nil
+			return nil, nil
 		}
 
 		// Update existing RoleBinding - OVERRIDE any manual changes
 		// This ensures consistency and predictability in production environments
+		// RoleRef is immutable - a changed RoleRef is applied by replacing the
+		// RoleBinding (make-before-break) after the metadata is prepared below
+		roleRefChanged := existing.RoleRef != roleBinding.RoleRef
+		previous := existing.DeepCopy()
 		existing.Subjects = roleBinding.Subjects
 		existing.RoleRef = roleBinding.RoleRef
 
@@ -324,10 +369,167 @@ func (r *PermissionBinderReconciler) createRoleBinding(ctx context.Context, name
 		}
 		existing.Labels[LabelManagedBy] = ManagedByValue
 
-		if err := r.Update(ctx, &existing); err != nil {
-			return false, fmt.Errorf("failed to update RoleBinding %s/%s: %w", namespace, name, err)
+		if roleRefChanged {
+			if err := replaceRoleBinding(ctx, r.Client, previous, &existing, "rolebinding"); err != nil {
+				return nil, err
+			}
+		} else if err := r.Update(ctx, &existing); err != nil {
+			return nil, fmt.Errorf("failed to update RoleBinding %s/%s: %w", namespace, name, err)
+		}
+		r.recordEvent(permissionBinder, &existing, corev1.EventTypeNormal, EventReasonRoleBindingUpdated,
+			"Updated RoleBinding %s/%s granting ClusterRole %s to group %s", namespace, name, clusterRole, group)
+		r.recordClusterRoleMissing(permissionBinder, &existing, clusterRoleExists)
+	}
+
+	return nil, nil
+}
+
+// transitionRoleBindingName returns the temporary name used while a
+// RoleBinding's immutable RoleRef is replaced (make-before-break)
+func transitionRoleBindingName(name string) string {
+	return name + TransitionRoleBindingSuffix
+}
+
+// replaceRoleBinding replaces existing with desired when the RoleRef changed.
+// RoleRef is immutable, so the binding has to be recreated; to avoid a window
+// without permissions this is done make-before-break:
+//  1. grant the new role under a temporary {name}-transition RoleBinding
+//  2. verify the temporary RoleBinding
+//  3. delete the old RoleBinding
+//  4. recreate the RoleBinding under its stable name
+//  5. delete the temporary RoleBinding
+//
+// A failure in steps 1-4 is rolled back (temporary binding removed, old binding
+// restored) so the previous permissions stay in place. If even the rollback
+// fails the temporary binding is kept, so the subjects keep the new role until
+// the next reconciliation recreates the stable binding.
+// resourceType labels the metric (rolebinding | serviceaccount_rolebinding).
+func replaceRoleBinding(ctx context.Context, k8sClient client.Client, existing, desired *rbacv1.RoleBinding, resourceType string) error {
+	logger := log.FromContext(ctx)
+
+	transition := desired.DeepCopy()
+	transition.Name = transitionRoleBindingName(desired.Name)
+	transition.ResourceVersion = ""
+	transition.UID = ""
+	transition.CreationTimestamp = metav1.Time{}
+	if transition.Annotations == nil {
+		transition.Annotations = make(map[string]string)
+	}
+	transition.Annotations[AnnotationTransitionFor] = desired.Name
+
+	logger.Info("Replacing RoleBinding (make-before-break)",
+		"namespace", desired.Namespace,
+		"roleBinding", desired.Name,
+		"oldRole", existing.RoleRef.Name,
+		"newRole", desired.RoleRef.Name,
+		"transitionRoleBinding", transition.Name)
+
+	// 1. Make: grant the new role under the temporary name (replace a
+	// leftover from an interrupted earlier attempt)
+	if err := k8sClient.Create(ctx, transition); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			roleBindingReplacementsTotal.WithLabelValues(resourceType, "rolled_back").Inc()
+			return fmt.Errorf("failed to create transition RoleBinding %s/%s: %w", transition.Namespace, transition.Name, err)
+		}
+		if err := cleanupTransitionRoleBinding(ctx, k8sClient, desired.Namespace, desired.Name); err != nil {
+			roleBindingReplacementsTotal.WithLabelValues(resourceType, "rolled_back").Inc()
+			return err
+		}
+		transition.ResourceVersion = ""
+		if err := k8sClient.Create(ctx, transition); err != nil {
+			roleBindingReplacementsTotal.WithLabelValues(resourceType, "rolled_back").Inc()
+			return fmt.Errorf("failed to create transition RoleBinding %s/%s: %w", transition.Namespace, transition.Name, err)
 		}
 	}
 
-	return true, nil
+	// 2. Verify the temporary binding grants the new role
+	var verified rbacv1.RoleBinding
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: transition.Name, Namespace: transition.Namespace}, &verified); err != nil ||
+		verified.RoleRef != desired.RoleRef {
+		if err == nil {
+			err = fmt.Errorf("unexpected RoleRef %s/%s", verified.RoleRef.Kind, verified.RoleRef.Name)
+		}
+		rollbackTransitionRoleBinding(ctx, k8sClient, transition)
+		roleBindingReplacementsTotal.WithLabelValues(resourceType, "rolled_back").Inc()
+		return fmt.Errorf("failed to verify transition RoleBinding %s/%s: %w", transition.Namespace, transition.Name, err)
+	}
+
+	// 3. Break: delete the old binding
+	if err := k8sClient.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+		rollbackTransitionRoleBinding(ctx, k8sClient, transition)
+		roleBindingReplacementsTotal.WithLabelValues(resourceType, "rolled_back").Inc()
+		return fmt.Errorf("failed to delete RoleBinding %s/%s for replacement: %w", existing.Namespace, existing.Name, err)
+	}
+
+	// 4. Recreate under the stable name
+	final := desired.DeepCopy()
+	final.ResourceVersion = ""
+	final.UID = ""
+	final.CreationTimestamp = metav1.Time{}
+	if err := k8sClient.Create(ctx, final); err != nil {
+		restore := existing.DeepCopy()
+		restore.ResourceVersion = ""
+		restore.UID = ""
+		restore.CreationTimestamp = metav1.Time{}
+		if restoreErr := k8sClient.Create(ctx, restore); restoreErr != nil {
+			// Keep the transition binding - it is the only grant left
+			roleBindingReplacementsTotal.WithLabelValues(resourceType, "failed").Inc()
+			logger.Error(restoreErr, "Failed to restore RoleBinding after failed replacement - keeping transition RoleBinding",
+				"namespace", existing.Namespace,
+				"roleBinding", existing.Name,
+				"transitionRoleBinding", transition.Name)
+			return fmt.Errorf("failed to recreate RoleBinding %s/%s: %w (restore failed: %v)", final.Namespace, final.Name, err, restoreErr)
+		}
+		rollbackTransitionRoleBinding(ctx, k8sClient, transition)
+		roleBindingReplacementsTotal.WithLabelValues(resourceType, "rolled_back").Inc()
+		return fmt.Errorf("failed to recreate RoleBinding %s/%s: %w", final.Namespace, final.Name, err)
+	}
+
+	// 5. Remove the temporary binding - a leftover is cleaned up on the next
+	// create or replacement of the stable binding
+	if err := k8sClient.Delete(ctx, transition); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete transition RoleBinding (non-fatal)",
+			"namespace", transition.Namespace,
+			"roleBinding", transition.Name)
+	}
+
+	roleBindingReplacementsTotal.WithLabelValues(resourceType, "success").Inc()
+	logger.Info("RoleBinding replaced successfully",
+		"namespace", desired.Namespace,
+		"roleBinding", desired.Name,
+		"newRole", desired.RoleRef.Name)
+	return nil
+}
+
+// rollbackTransitionRoleBinding removes a temporary RoleBinding after a failed replacement
+func rollbackTransitionRoleBinding(ctx context.Context, k8sClient client.Client, transition *rbacv1.RoleBinding) {
+	if err := k8sClient.Delete(ctx, transition); err != nil && !errors.IsNotFound(err) {
+		log.FromContext(ctx).Error(err, "Failed to delete transition RoleBinding during rollback",
+			"namespace", transition.Namespace,
+			"roleBinding", transition.Name)
+	}
+}
+
+// cleanupTransitionRoleBinding deletes a leftover {name}-transition RoleBinding
+// created by an interrupted replaceRoleBinding for the given RoleBinding
+func cleanupTransitionRoleBinding(ctx context.Context, k8sClient client.Client, namespace, name string) error {
+	var leftover rbacv1.RoleBinding
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: transitionRoleBindingName(name), Namespace: namespace}, &leftover)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get transition RoleBinding %s/%s: %w", namespace, transitionRoleBindingName(name), err)
+	}
+	if leftover.Annotations[AnnotationTransitionFor] != name {
+		// Not ours - a RoleBinding that merely happens to use the name
+		return nil
+	}
+	if err := k8sClient.Delete(ctx, &leftover); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete transition RoleBinding %s/%s: %w", namespace, leftover.Name, err)
+	}
+	log.FromContext(ctx).Info("Removed leftover transition RoleBinding",
+		"namespace", namespace,
+		"roleBinding", leftover.Name)
+	return nil
 }
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+	"github.com/permission-binder-operator/operator/internal/controller/reportstore"
+)
+
+// groupByNamespace groups "namespace/name" items by namespace, sorted by name
+func groupByNamespace(items []string) map[string][]string {
+	grouped := make(map[string][]string)
+	for _, item := range items {
+		namespace, _, found := strings.Cut(item, "/")
+		if !found {
+			continue
+		}
+		grouped[namespace] = append(grouped[namespace], item)
+	}
+	for _, namespaceItems := range grouped {
+		sort.Strings(namespaceItems)
+	}
+	return grouped
+}
+
+// reconcileReports writes the RoleBindings, ServiceAccounts and token Secrets of the
+// last reconciliation into the PermissionBinderReports, one per target namespace, and
+// returns the summary for status.reports. Reports of namespaces that are gone are
+// emptied (and deleted unless they still carry NetworkPolicy or LDAP group state).
+// Only changed reports are written.
+func (r *PermissionBinderReconciler) reconcileReports(ctx context.Context, pb *permissionv1.PermissionBinder, roleBindings, serviceAccounts []string, tokens []permissionv1.ServiceAccountTokenStatus) (*permissionv1.ReportsSummary, error) {
+	roleBindingsByNamespace := groupByNamespace(roleBindings)
+	serviceAccountsByNamespace := groupByNamespace(serviceAccounts)
+	tokensByNamespace := make(map[string][]permissionv1.ServiceAccountTokenStatus)
+	for _, token := range tokens {
+		tokensByNamespace[token.Namespace] = append(tokensByNamespace[token.Namespace], token)
+	}
+
+	namespaces := make(map[string]bool)
+	for namespace := range roleBindingsByNamespace {
+		namespaces[namespace] = true
+	}
+	for namespace := range serviceAccountsByNamespace {
+		namespaces[namespace] = true
+	}
+	for namespace := range tokensByNamespace {
+		namespaces[namespace] = true
+	}
+	return r.updateReports(ctx, pb, namespaces,
+		func(status *permissionv1.PermissionBinderReportStatus) bool {
+			return len(status.RoleBindings) > 0 || len(status.ServiceAccounts) > 0 || len(status.ServiceAccountTokens) > 0
+		},
+		func(namespace string, status *permissionv1.PermissionBinderReportStatus) {
+			status.RoleBindings = roleBindingsByNamespace[namespace]
+			status.ServiceAccounts = serviceAccountsByNamespace[namespace]
+			status.ServiceAccountTokens = tokensByNamespace[namespace]
+		})
+}
+
+// storeServiceAccountTokens writes the token Secrets refreshed outside a full
+// reconciliation into the PermissionBinderReports and returns the summary for
+// status.reports
+func (r *PermissionBinderReconciler) storeServiceAccountTokens(ctx context.Context, pb *permissionv1.PermissionBinder, tokens []permissionv1.ServiceAccountTokenStatus) (*permissionv1.ReportsSummary, error) {
+	tokensByNamespace := make(map[string][]permissionv1.ServiceAccountTokenStatus)
+	namespaces := make(map[string]bool)
+	for _, token := range tokens {
+		tokensByNamespace[token.Namespace] = append(tokensByNamespace[token.Namespace], token)
+		namespaces[token.Namespace] = true
+	}
+	return r.updateReports(ctx, pb, namespaces,
+		func(status *permissionv1.PermissionBinderReportStatus) bool {
+			return len(status.ServiceAccountTokens) > 0
+		},
+		func(namespace string, status *permissionv1.PermissionBinderReportStatus) {
+			status.ServiceAccountTokens = tokensByNamespace[namespace]
+		})
+}
+
+// storeLdapGroups writes the tracked LDAP groups into the PermissionBinderReports of
+// the namespaces of their whitelist entries and returns the summary for
+// status.reports. Groups tracked by earlier versions without a namespace go to the
+// report of the namespace of the PermissionBinder.
+func (r *PermissionBinderReconciler) storeLdapGroups(ctx context.Context, pb *permissionv1.PermissionBinder, groups []permissionv1.LdapGroupStatus) (*permissionv1.ReportsSummary, error) {
+	groupsByNamespace := make(map[string][]permissionv1.LdapGroupStatus)
+	namespaces := make(map[string]bool)
+	for _, group := range groups {
+		namespace := group.Namespace
+		if namespace == "" {
+			namespace = pb.Namespace
+		}
+		groupsByNamespace[namespace] = append(groupsByNamespace[namespace], group)
+		namespaces[namespace] = true
+	}
+	return r.updateReports(ctx, pb, namespaces,
+		func(status *permissionv1.PermissionBinderReportStatus) bool {
+			return len(status.LdapGroups) > 0
+		},
+		func(namespace string, status *permissionv1.PermissionBinderReportStatus) {
+			status.LdapGroups = groupsByNamespace[namespace]
+		})
+}
+
+// updateReports applies mutate to the reports of namespaces and to the existing
+// reports for which holds is true, so that state of namespaces that are gone is
+// emptied, and returns the summary of the resulting reports
+func (r *PermissionBinderReconciler) updateReports(
+	ctx context.Context,
+	pb *permissionv1.PermissionBinder,
+	namespaces map[string]bool,
+	holds func(*permissionv1.PermissionBinderReportStatus) bool,
+	mutate func(namespace string, status *permissionv1.PermissionBinderReportStatus),
+) (*permissionv1.ReportsSummary, error) {
+	existing, err := reportstore.List(ctx, r, pb)
+	if err != nil {
+		return nil, err
+	}
+	reports := make(map[string]permissionv1.PermissionBinderReport, len(existing))
+	for _, report := range existing {
+		reports[report.Spec.TargetNamespace] = report
+		if holds(&report.Status) {
+			namespaces[report.Spec.TargetNamespace] = true
+		}
+	}
+
+	var errs []error
+	for namespace := range namespaces {
+		written, err := reportstore.Update(ctx, r, pb, namespace, func(status *permissionv1.PermissionBinderReportStatus) {
+			mutate(namespace, status)
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if written == nil {
+			delete(reports, namespace)
+		} else {
+			reports[namespace] = *written
+		}
+	}
+
+	summarized := make([]permissionv1.PermissionBinderReport, 0, len(reports))
+	for _, report := range reports {
+		summarized = append(summarized, report)
+	}
+	return reportstore.Summarize(summarized), errors.Join(errs...)
+}
+
+// trackedServiceAccountTokens returns the token Secrets tracked in the
+// PermissionBinderReports, and those only tracked in the status of PermissionBinders
+// written by earlier versions
+func (r *PermissionBinderReconciler) trackedServiceAccountTokens(ctx context.Context, pb *permissionv1.PermissionBinder) ([]permissionv1.ServiceAccountTokenStatus, error) {
+	reports, err := reportstore.List(ctx, r, pb)
+	if err != nil {
+		return nil, err
+	}
+	tokens := reportstore.ServiceAccountTokens(reports)
+	tracked := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		tracked[token.Namespace+"/"+token.SecretName] = true
+	}
+	for _, token := range pb.Status.ServiceAccountTokens {
+		if !tracked[token.Namespace+"/"+token.SecretName] {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// trackedLdapGroups returns the LDAP groups tracked in the PermissionBinderReports,
+// and those only tracked in the status of PermissionBinders written by earlier versions
+func (r *PermissionBinderReconciler) trackedLdapGroups(ctx context.Context, pb *permissionv1.PermissionBinder) ([]permissionv1.LdapGroupStatus, error) {
+	reports, err := reportstore.List(ctx, r, pb)
+	if err != nil {
+		return nil, err
+	}
+	return withLegacyLdapGroups(reportstore.LdapGroups(reports), pb.Status.LdapGroups), nil
+}
+
+// withLegacyLdapGroups adds the groups of the legacy status list that groups does
+// not track (by DN, case-insensitively)
+func withLegacyLdapGroups(groups, legacy []permissionv1.LdapGroupStatus) []permissionv1.LdapGroupStatus {
+	tracked := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		tracked[strings.ToLower(group.DN)] = true
+	}
+	for _, group := range legacy {
+		if !tracked[strings.ToLower(group.DN)] {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// migrateNetworkPolicyStatus moves the NetworkPolicy state of PermissionBinders
+// written by earlier versions from the status into the PermissionBinderReports, so
+// that open Pull Requests stay tracked. Reports that already have NetworkPolicy
+// state are left alone. The status entries are cleared by the next status write.
+func (r *PermissionBinderReconciler) migrateNetworkPolicyStatus(ctx context.Context, pb *permissionv1.PermissionBinder) error {
+	if len(pb.Status.NetworkPolicies) == 0 {
+		return nil
+	}
+	log.FromContext(ctx).Info("Migrating NetworkPolicy status to PermissionBinderReports",
+		"namespaces", len(pb.Status.NetworkPolicies))
+	var errs []error
+	for _, networkPolicy := range pb.Status.NetworkPolicies {
+		_, err := reportstore.Update(ctx, r, pb, networkPolicy.Namespace, func(status *permissionv1.PermissionBinderReportStatus) {
+			if status.NetworkPolicy == nil {
+				status.NetworkPolicy = networkPolicy.DeepCopy()
+			}
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
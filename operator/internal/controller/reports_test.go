@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+	"github.com/permission-binder-operator/operator/internal/controller/reportstore"
+)
+
+func TestReconcileReports(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	// A PermissionBinder with the per-item status of earlier versions
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 1,
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin", "view": "view"},
+			ClusterName:        "prod",
+		},
+		Status: permissionv1.PermissionBinderStatus{
+			ProcessedRoleBindings: []string{"payments/payments-admin"},
+			NetworkPolicies:       []permissionv1.NetworkPolicyStatus{{Namespace: "payments", State: "pr-merged"}},
+		},
+	}
+	whitelist := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data: map[string]string{"whitelist.txt": "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com\n" +
+			"CN=COMPANY-K8S-orders-admin,OU=K8S,DC=example,DC=com\n" +
+			"CN=COMPANY-K8S-orders-view,OU=K8S,DC=example,DC=com"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, whitelist).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(pb)
+
+	reconcile := func() (permissionv1.PermissionBinder, []permissionv1.PermissionBinderReport) {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got permissionv1.PermissionBinder
+		if err := k8sClient.Get(ctx, key, &got); err != nil {
+			t.Fatal(err)
+		}
+		reports, err := reportstore.List(ctx, k8sClient, &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got, reports
+	}
+
+	got, reports := reconcile()
+	if len(reports) != 2 {
+		t.Fatalf("reports = %+v, want one per namespace", reports)
+	}
+	orders, payments := reports[0], reports[1]
+	if orders.Name != "team-a.orders" ||
+		!reflect.DeepEqual(orders.Status.RoleBindings, []string{"orders/orders-admin", "orders/orders-view"}) ||
+		orders.Status.NetworkPolicy != nil {
+		t.Errorf("orders report = %+v", orders)
+	}
+	if payments.Name != "team-a.payments" ||
+		!reflect.DeepEqual(payments.Status.RoleBindings, []string{"payments/payments-admin"}) {
+		t.Errorf("payments report = %+v", payments)
+	}
+	if payments.Status.NetworkPolicy == nil || payments.Status.NetworkPolicy.State != "pr-merged" {
+		t.Errorf("payments NetworkPolicy = %+v, want the migrated status", payments.Status.NetworkPolicy)
+	}
+	if owner := metav1.GetControllerOf(&payments); owner == nil || owner.Name != "team-a" {
+		t.Errorf("payments owner = %+v, want the PermissionBinder", owner)
+	}
+	want := &permissionv1.ReportsSummary{Reports: 2, RoleBindings: 3, NetworkPolicies: map[string]int{"pr-merged": 1}}
+	if !reflect.DeepEqual(got.Status.Reports, want) {
+		t.Errorf("status.reports = %+v, want %+v", got.Status.Reports, want)
+	}
+	if got.Status.ProcessedRoleBindings != nil || got.Status.NetworkPolicies != nil {
+		t.Errorf("status = %+v, want the per-item lists cleared", got.Status)
+	}
+
+	// A namespace leaving the whitelist loses its report
+	var current corev1.ConfigMap
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(whitelist), &current); err != nil {
+		t.Fatal(err)
+	}
+	current.Data["whitelist.txt"] = "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com"
+	if err := k8sClient.Update(ctx, &current); err != nil {
+		t.Fatal(err)
+	}
+	got, reports = reconcile()
+	if len(reports) != 1 || reports[0].Name != "team-a.payments" {
+		t.Errorf("reports = %+v, want only payments", reports)
+	}
+	want = &permissionv1.ReportsSummary{Reports: 1, RoleBindings: 1, NetworkPolicies: map[string]int{"pr-merged": 1}}
+	if !reflect.DeepEqual(got.Status.Reports, want) {
+		t.Errorf("status.reports = %+v, want %+v", got.Status.Reports, want)
+	}
+}
+
+func TestTrackedStateInReports(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = permissionv1.AddToScheme(scheme)
+	removedAt := metav1.NewTime(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	// A PermissionBinder with the token and LDAP group status of earlier versions
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "operators"},
+		Status: permissionv1.PermissionBinderStatus{
+			ServiceAccountTokens: []permissionv1.ServiceAccountTokenStatus{
+				{Namespace: "payments", ServiceAccount: "payments-sa-ci", SecretName: "payments-sa-ci-token", Mode: "LongLived"},
+			},
+			LdapGroups: []permissionv1.LdapGroupStatus{
+				{DN: "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com", State: LdapGroupStateActive},
+				{DN: "CN=COMPANY-K8S-orders-admin,OU=K8S,DC=example,DC=com", State: LdapGroupStateRetired, RemovedAt: &removedAt},
+			},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&permissionv1.PermissionBinderReport{}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+
+	tokens, err := r.trackedServiceAccountTokens(ctx, pb)
+	if err != nil || len(tokens) != 1 {
+		t.Fatalf("trackedServiceAccountTokens() = %+v, %v, want the legacy status", tokens, err)
+	}
+	summary, err := r.storeServiceAccountTokens(ctx, pb, tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Reports != 1 || summary.ServiceAccountTokens != 1 {
+		t.Errorf("summary = %+v, want one token in one report", summary)
+	}
+
+	// Groups still in the whitelist take the namespace of their entry
+	groups, err := r.trackedLdapGroups(ctx, pb)
+	if err != nil || len(groups) != 2 {
+		t.Fatalf("trackedLdapGroups() = %+v, %v, want the legacy status", groups, err)
+	}
+	whitelist := map[string]string{"cn=company-k8s-payments-admin,ou=k8s,dc=example,dc=com": "payments"}
+	groups = r.ProcessLdapGroupLifecycle(ctx, pb, groups, whitelist, nil, removedAt.Time)
+	if summary, err = r.storeLdapGroups(ctx, pb, groups); err != nil {
+		t.Fatal(err)
+	}
+	want := &permissionv1.ReportsSummary{
+		Reports: 2, ServiceAccountTokens: 1,
+		LdapGroups: map[string]int{LdapGroupStateActive: 1, LdapGroupStateRetired: 1},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+
+	// Once the legacy status is cleared, the reports alone track everything
+	pb.Status.ServiceAccountTokens = nil
+	pb.Status.LdapGroups = nil
+	payments, err := reportstore.Get(ctx, k8sClient, pb, "payments")
+	if err != nil || payments == nil || len(payments.Status.ServiceAccountTokens) != 1 ||
+		len(payments.Status.LdapGroups) != 1 || payments.Status.LdapGroups[0].Namespace != "payments" {
+		t.Fatalf("payments report = %+v, %v, want the token and the active group", payments, err)
+	}
+	operators, err := reportstore.Get(ctx, k8sClient, pb, "operators")
+	if err != nil || operators == nil || len(operators.Status.LdapGroups) != 1 ||
+		operators.Status.LdapGroups[0].State != LdapGroupStateRetired {
+		t.Fatalf("operators report = %+v, %v, want the retired group without a namespace", operators, err)
+	}
+	if groups, err := r.trackedLdapGroups(ctx, pb); err != nil || len(groups) != 2 {
+		t.Errorf("trackedLdapGroups() = %+v, %v, want both groups from the reports", groups, err)
+	}
+
+	// Groups no longer tracked leave the reports, emptied reports are deleted
+	if summary, err = r.storeLdapGroups(ctx, pb, groups[:0]); err != nil {
+		t.Fatal(err)
+	}
+	want = &permissionv1.ReportsSummary{Reports: 1, ServiceAccountTokens: 1}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+}
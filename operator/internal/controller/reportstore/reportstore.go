@@ -0,0 +1,238 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reportstore stores the per-namespace state of a PermissionBinder in
+// PermissionBinderReports.
+//
+// The RoleBindings, ServiceAccounts, token Secrets, LDAP groups and NetworkPolicy
+// state of large whitelists would grow the PermissionBinder status towards the etcd
+// object size limit and make every status write rewrite all of it. Instead, each
+// target namespace gets one report, named "<permissionbinder>.<namespace>" (see
+// Name), in the namespace of the PermissionBinder; only summaries (see Summarize)
+// stay in the status. The controller writes the RoleBindings, ServiceAccounts and
+// token Secrets of a report, the LDAP controller its LDAP groups and the
+// networkpolicy package its NetworkPolicy state.
+package reportstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+// Client is the part of the Kubernetes client the store needs; the controller and
+// networkpolicy.ReconcilerInterface both provide it
+type Client interface {
+	client.Reader
+	client.Writer
+	client.StatusClient
+}
+
+// maxNameLength is the longest object name (a DNS subdomain)
+const maxNameLength = 253
+
+// Name returns the name of the report of a PermissionBinder for a target namespace.
+// Namespace names cannot contain dots, so the name is unambiguous. Beyond 253
+// characters the PermissionBinder name is cut and followed by a hash of the full
+// name, which keeps it unique; reports are found by name or by their spec, never
+// by parsing the name.
+func Name(pb *permissionv1.PermissionBinder, namespace string) string {
+	name := pb.Name + "." + namespace
+	if len(name) <= maxNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	suffix := "-" + hex.EncodeToString(sum[:])[:10] + "." + namespace
+	// A label of a DNS subdomain must not end in a dash or start with one
+	return strings.TrimRight(pb.Name[:maxNameLength-len(suffix)], ".-") + suffix
+}
+
+// Get returns the report of the PermissionBinder for a target namespace, nil when it
+// does not exist
+func Get(ctx context.Context, c client.Reader, pb *permissionv1.PermissionBinder, namespace string) (*permissionv1.PermissionBinderReport, error) {
+	var report permissionv1.PermissionBinderReport
+	key := types.NamespacedName{Name: Name(pb, namespace), Namespace: pb.Namespace}
+	if err := c.Get(ctx, key, &report); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get PermissionBinderReport %s: %w", key, err)
+	}
+	return &report, nil
+}
+
+// List returns the reports of the PermissionBinder sorted by target namespace
+func List(ctx context.Context, c client.Reader, pb *permissionv1.PermissionBinder) ([]permissionv1.PermissionBinderReport, error) {
+	var list permissionv1.PermissionBinderReportList
+	if err := c.List(ctx, &list, client.InNamespace(pb.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list PermissionBinderReports: %w", err)
+	}
+	reports := make([]permissionv1.PermissionBinderReport, 0, len(list.Items))
+	for _, report := range list.Items {
+		if report.Spec.PermissionBinder == pb.Name {
+			reports = append(reports, report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Spec.TargetNamespace < reports[j].Spec.TargetNamespace
+	})
+	return reports, nil
+}
+
+// Update applies mutate to the status of the report for a target namespace. The
+// report is created when it does not exist and deleted when mutate leaves it empty;
+// nothing is written when mutate changes nothing. Conflicting writes are retried on
+// the latest version. It returns the resulting report, nil when there is none.
+func Update(ctx context.Context, c Client, pb *permissionv1.PermissionBinder, namespace string, mutate func(*permissionv1.PermissionBinderReportStatus)) (*permissionv1.PermissionBinderReport, error) {
+	retriable := func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}
+	var result *permissionv1.PermissionBinderReport
+	err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+		report, err := Get(ctx, c, pb, namespace)
+		if err != nil {
+			return err
+		}
+		exists := report != nil
+		if !exists {
+			report = newReport(pb, namespace)
+		}
+		status := report.Status.DeepCopy()
+		mutate(status)
+		switch {
+		case reflect.DeepEqual(*status, report.Status):
+			if exists {
+				result = report
+			}
+			return nil
+		case isEmpty(status) && !exists:
+			return nil
+		case isEmpty(status):
+			if err := c.Delete(ctx, report); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete PermissionBinderReport %s/%s: %w", report.Namespace, report.Name, err)
+			}
+			return nil
+		}
+		if !exists {
+			if err := c.Create(ctx, report); err != nil {
+				return err
+			}
+		}
+		report.Status = *status
+		if err := c.Status().Update(ctx, report); err != nil {
+			return err
+		}
+		result = report
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newReport returns a report of the PermissionBinder for a target namespace. The
+// PermissionBinder owns it, so that it is garbage collected with the PermissionBinder.
+func newReport(pb *permissionv1.PermissionBinder, namespace string) *permissionv1.PermissionBinderReport {
+	controller := true
+	return &permissionv1.PermissionBinderReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name(pb, namespace),
+			Namespace: pb.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: permissionv1.GroupVersion.String(),
+				Kind:       "PermissionBinder",
+				Name:       pb.Name,
+				UID:        pb.UID,
+				Controller: &controller,
+			}},
+		},
+		Spec: permissionv1.PermissionBinderReportSpec{
+			PermissionBinder: pb.Name,
+			TargetNamespace:  namespace,
+		},
+	}
+}
+
+// isEmpty reports whether a report has nothing left to report
+func isEmpty(status *permissionv1.PermissionBinderReportStatus) bool {
+	return len(status.RoleBindings) == 0 && len(status.ServiceAccounts) == 0 && status.NetworkPolicy == nil &&
+		len(status.ServiceAccountTokens) == 0 && len(status.LdapGroups) == 0
+}
+
+// Summarize returns the summary of the reports for the PermissionBinder status
+func Summarize(reports []permissionv1.PermissionBinderReport) *permissionv1.ReportsSummary {
+	summary := &permissionv1.ReportsSummary{Reports: len(reports)}
+	for _, report := range reports {
+		summary.RoleBindings += len(report.Status.RoleBindings)
+		summary.ServiceAccounts += len(report.Status.ServiceAccounts)
+		if report.Status.NetworkPolicy != nil {
+			if summary.NetworkPolicies == nil {
+				summary.NetworkPolicies = make(map[string]int)
+			}
+			summary.NetworkPolicies[report.Status.NetworkPolicy.State]++
+		}
+		summary.ServiceAccountTokens += len(report.Status.ServiceAccountTokens)
+		for _, group := range report.Status.LdapGroups {
+			if summary.LdapGroups == nil {
+				summary.LdapGroups = make(map[string]int)
+			}
+			summary.LdapGroups[group.State]++
+		}
+	}
+	return summary
+}
+
+// NetworkPolicies returns the NetworkPolicy state of the reports
+func NetworkPolicies(reports []permissionv1.PermissionBinderReport) []permissionv1.NetworkPolicyStatus {
+	var statuses []permissionv1.NetworkPolicyStatus
+	for _, report := range reports {
+		if report.Status.NetworkPolicy != nil {
+			statuses = append(statuses, *report.Status.NetworkPolicy)
+		}
+	}
+	return statuses
+}
+
+// ServiceAccountTokens returns the token Secrets tracked in the reports
+func ServiceAccountTokens(reports []permissionv1.PermissionBinderReport) []permissionv1.ServiceAccountTokenStatus {
+	var tokens []permissionv1.ServiceAccountTokenStatus
+	for _, report := range reports {
+		tokens = append(tokens, report.Status.ServiceAccountTokens...)
+	}
+	return tokens
+}
+
+// LdapGroups returns the LDAP groups tracked in the reports
+func LdapGroups(reports []permissionv1.PermissionBinderReport) []permissionv1.LdapGroupStatus {
+	var groups []permissionv1.LdapGroupStatus
+	for _, report := range reports {
+		groups = append(groups, report.Status.LdapGroups...)
+	}
+	return groups
+}
@@ -0,0 +1,177 @@
+package reportstore
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = permissionv1.AddToScheme(scheme)
+	writes := 0
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&permissionv1.PermissionBinderReport{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				writes++
+				return c.Create(ctx, obj, opts...)
+			},
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				writes++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).Build()
+	pb := &permissionv1.PermissionBinder{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "operators", UID: "uid-1"}}
+	ctx := context.Background()
+	set := func(roleBindings ...string) func(*permissionv1.PermissionBinderReportStatus) {
+		return func(status *permissionv1.PermissionBinderReportStatus) {
+			status.RoleBindings = roleBindings
+		}
+	}
+
+	// Nothing to report creates nothing
+	if report, err := Update(ctx, c, pb, "payments", set()); err != nil || report != nil || writes != 0 {
+		t.Fatalf("Update() = %v, %v after %d writes, want no report", report, err, writes)
+	}
+
+	report, err := Update(ctx, c, pb, "payments", set("payments/payments-admin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Name != "team-a.payments" || report.Spec.TargetNamespace != "payments" ||
+		metav1.GetControllerOf(report).UID != pb.UID {
+		t.Errorf("report = %+v", report)
+	}
+	got, err := Get(ctx, c, pb, "payments")
+	if err != nil || got == nil || !reflect.DeepEqual(got.Status.RoleBindings, []string{"payments/payments-admin"}) {
+		t.Fatalf("Get() = %+v, %v", got, err)
+	}
+
+	// An unchanged status is not written again
+	writes = 0
+	if _, err := Update(ctx, c, pb, "payments", set("payments/payments-admin")); err != nil || writes != 0 {
+		t.Errorf("Update() = %v after %d writes, want none", err, writes)
+	}
+
+	// A report left empty is deleted
+	if report, err := Update(ctx, c, pb, "payments", set()); err != nil || report != nil {
+		t.Errorf("Update() = %v, %v, want the report deleted", report, err)
+	}
+	if got, err := Get(ctx, c, pb, "payments"); err != nil || got != nil {
+		t.Errorf("Get() = %+v, %v, want none", got, err)
+	}
+}
+
+func TestList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = permissionv1.AddToScheme(scheme)
+	report := func(pb, namespace string) *permissionv1.PermissionBinderReport {
+		return &permissionv1.PermissionBinderReport{
+			ObjectMeta: metav1.ObjectMeta{Name: pb + "." + namespace, Namespace: "operators"},
+			Spec:       permissionv1.PermissionBinderReportSpec{PermissionBinder: pb, TargetNamespace: namespace},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(report("team-a", "payments"), report("team-a", "orders"), report("team-b", "billing")).Build()
+	pb := &permissionv1.PermissionBinder{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "operators"}}
+
+	reports, err := List(context.Background(), c, pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var namespaces []string
+	for _, report := range reports {
+		namespaces = append(namespaces, report.Spec.TargetNamespace)
+	}
+	if !reflect.DeepEqual(namespaces, []string{"orders", "payments"}) {
+		t.Errorf("List() namespaces = %v, want the reports of team-a by namespace", namespaces)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	reports := []permissionv1.PermissionBinderReport{
+		{Status: permissionv1.PermissionBinderReportStatus{
+			RoleBindings:    []string{"a/a-admin", "a/a-view"},
+			ServiceAccounts: []string{"a/a-sa-deploy"},
+			NetworkPolicy:   &permissionv1.NetworkPolicyStatus{Namespace: "a", State: "pr-merged"},
+		}},
+		{Status: permissionv1.PermissionBinderReportStatus{
+			RoleBindings:  []string{"b/b-admin"},
+			NetworkPolicy: &permissionv1.NetworkPolicyStatus{Namespace: "b", State: "pr-created"},
+		}},
+		{Status: permissionv1.PermissionBinderReportStatus{RoleBindings: []string{"c/c-admin"}}},
+		{Status: permissionv1.PermissionBinderReportStatus{
+			ServiceAccountTokens: []permissionv1.ServiceAccountTokenStatus{{Namespace: "d", ServiceAccount: "d-sa-ci", SecretName: "d-sa-ci-token", Mode: "LongLived"}},
+			LdapGroups: []permissionv1.LdapGroupStatus{
+				{DN: "CN=d-admin", State: "Active", Namespace: "d"},
+				{DN: "CN=d-view", State: "Retired", Namespace: "d"},
+			},
+		}},
+	}
+
+	want := &permissionv1.ReportsSummary{
+		Reports: 4, RoleBindings: 4, ServiceAccounts: 1,
+		NetworkPolicies:      map[string]int{"pr-merged": 1, "pr-created": 1},
+		ServiceAccountTokens: 1,
+		LdapGroups:           map[string]int{"Active": 1, "Retired": 1},
+	}
+	if got := Summarize(reports); !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+	if got := NetworkPolicies(reports); len(got) != 2 || got[0].Namespace != "a" || got[1].Namespace != "b" {
+		t.Errorf("NetworkPolicies() = %+v", got)
+	}
+	if got := ServiceAccountTokens(reports); len(got) != 1 || got[0].SecretName != "d-sa-ci-token" {
+		t.Errorf("ServiceAccountTokens() = %+v", got)
+	}
+	if got := LdapGroups(reports); len(got) != 2 || got[0].DN != "CN=d-admin" {
+		t.Errorf("LdapGroups() = %+v", got)
+	}
+}
+
+func TestNameTruncatesLongNames(t *testing.T) {
+	namespace := strings.Repeat("n", 63)
+	short := &permissionv1.PermissionBinder{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+	if got := Name(short, namespace); got != "team-a."+namespace {
+		t.Errorf("Name() = %s, want the plain name", got)
+	}
+
+	long := &permissionv1.PermissionBinder{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 177) + "." + strings.Repeat("b", 75)}}
+	other := &permissionv1.PermissionBinder{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 177) + "." + strings.Repeat("c", 75)}}
+	name := Name(long, namespace)
+	if len(name) > maxNameLength || !strings.HasSuffix(name, "."+namespace) || strings.Contains(name, ".-") {
+		t.Errorf("Name() = %s (%d characters), want a valid name of at most %d ending in the namespace", name, len(name), maxNameLength)
+	}
+	if name != Name(long, namespace) || name == Name(other, namespace) {
+		t.Error("Name() must be stable and unique for PermissionBinders with a common prefix")
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		t.Errorf("Name() = %s is no DNS subdomain: %v", name, errs)
+	}
+
+	// The report is stored and found under the truncated name
+	scheme := runtime.NewScheme()
+	_ = permissionv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&permissionv1.PermissionBinderReport{}).Build()
+	long.Namespace = "operators"
+	ctx := context.Background()
+	if _, err := Update(ctx, c, long, namespace, func(status *permissionv1.PermissionBinderReportStatus) {
+		status.RoleBindings = []string{namespace + "/" + namespace + "-admin"}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := Get(ctx, c, long, namespace); err != nil || report == nil || report.Name != name {
+		t.Errorf("Get() = %+v, %v, want the report %s", report, err, name)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newOwnedGroupRoleBinding returns a group RoleBinding owned by my-namespace/my-binder
+func newOwnedGroupRoleBinding(clusterRole string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "team-a-admin",
+			Namespace: "team-a",
+			Annotations: map[string]string{
+				AnnotationManagedBy:                 ManagedByValue,
+				AnnotationCreatedAt:                 "2025-01-01T00:00:00Z",
+				AnnotationPermissionBinder:          "my-binder",
+				AnnotationPermissionBinderNamespace: "my-namespace",
+				AnnotationRole:                      "admin",
+			},
+			Labels: map[string]string{LabelManagedBy: ManagedByValue},
+		},
+		Subjects: []rbacv1.Subject{{Kind: "Group", Name: "COMPANY-K8S-team-a-admin"}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     clusterRole,
+		},
+	}
+}
+
+// TestCreateRoleBinding_RoleRefChangeMakeBeforeBreak verifies that a RoleRef
+// change is applied by replacement (RoleRef is immutable) and that the new
+// role is granted before the old RoleBinding is deleted
+func TestCreateRoleBinding_RoleRefChangeMakeBeforeBreak(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+
+	transitionExistedOnDelete := false
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newOwnedGroupRoleBinding("view")).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if rb, ok := obj.(*rbacv1.RoleBinding); ok && rb.RoleRef.Name != "view" {
+					return fmt.Errorf("RoleRef is immutable")
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if obj.GetName() == "team-a-admin" {
+					var transition rbacv1.RoleBinding
+					err := c.Get(ctx, types.NamespacedName{Name: "team-a-admin-transition", Namespace: "team-a"}, &transition)
+					transitionExistedOnDelete = err == nil && transition.RoleRef.Name == "admin"
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	before := testutil.ToFloat64(roleBindingReplacementsTotal.WithLabelValues("rolebinding", "success"))
+
+	conflict, err := r.createRoleBinding(context.Background(), "team-a", "team-a-admin", "admin",
+		"COMPANY-K8S-team-a-admin", "admin", newPermissionBinder("my-namespace", "my-binder"))
+	if err != nil || conflict != nil {
+		t.Fatalf("createRoleBinding() = %v, %v; want managed without error", conflict, err)
+	}
+	if !transitionExistedOnDelete {
+		t.Error("Expected the transition RoleBinding with the new role to exist when the old one was deleted")
+	}
+
+	var rb rbacv1.RoleBinding
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-a-admin", Namespace: "team-a"}, &rb); err != nil {
+		t.Fatalf("RoleBinding missing after replacement: %v", err)
+	}
+	if rb.RoleRef.Name != "admin" || rb.Annotations[AnnotationCreatedAt] != "2025-01-01T00:00:00Z" {
+		t.Errorf("Expected admin role with preserved created-at, got %s / %v", rb.RoleRef.Name, rb.Annotations)
+	}
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-a-admin-transition", Namespace: "team-a"}, &rbacv1.RoleBinding{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("Expected transition RoleBinding to be removed, got err=%v", err)
+	}
+	if after := testutil.ToFloat64(roleBindingReplacementsTotal.WithLabelValues("rolebinding", "success")); after-before != 1 {
+		t.Errorf("Expected one successful replacement, got %v", after-before)
+	}
+}
+
+// TestReplaceRoleBinding_RollbackOnRecreateFailure verifies that the previous
+// RoleBinding is restored when the stable binding cannot be recreated
+func TestReplaceRoleBinding_RollbackOnRecreateFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+
+	failNext := true
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newOwnedGroupRoleBinding("view")).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if rb, ok := obj.(*rbacv1.RoleBinding); ok && rb.Name == "team-a-admin" && rb.RoleRef.Name == "admin" && failNext {
+					failNext = false
+					return fmt.Errorf("admission webhook denied the request")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	before := testutil.ToFloat64(roleBindingReplacementsTotal.WithLabelValues("rolebinding", "rolled_back"))
+
+	var existing rbacv1.RoleBinding
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-a-admin", Namespace: "team-a"}, &existing); err != nil {
+		t.Fatalf("Failed to get RoleBinding: %v", err)
+	}
+	desired := existing.DeepCopy()
+	desired.RoleRef.Name = "admin"
+
+	if err := replaceRoleBinding(context.Background(), k8sClient, &existing, desired, "rolebinding"); err == nil {
+		t.Fatal("Expected replaceRoleBinding to return the recreate error")
+	}
+
+	var rb rbacv1.RoleBinding
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-a-admin", Namespace: "team-a"}, &rb); err != nil {
+		t.Fatalf("Previous RoleBinding must be restored: %v", err)
+	}
+	if rb.RoleRef.Name != "view" {
+		t.Errorf("Expected restored RoleBinding to keep the previous role, got %s", rb.RoleRef.Name)
+	}
+	err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-a-admin-transition", Namespace: "team-a"}, &rbacv1.RoleBinding{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("Expected transition RoleBinding to be rolled back, got err=%v", err)
+	}
+	if after := testutil.ToFloat64(roleBindingReplacementsTotal.WithLabelValues("rolebinding", "rolled_back")); after-before != 1 {
+		t.Errorf("Expected one rolled back replacement, got %v", after-before)
+	}
+}
@@ -2,8 +2,12 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -12,6 +16,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
 )
 
 const (
@@ -19,6 +25,14 @@ const (
 	AnnotationCreatedBy      = "permission-binder.io/created-by"
 	AnnotationSAType         = "permission-binder.io/sa-type"
 	AnnotationServiceAccount = "permission-binder.io/service-account"
+
+	// OrphanedByServiceAccountPrune is the AnnotationOrphanedBy value stamped
+	// when a ServiceAccount leaves the desired set in Orphan prune mode.
+	OrphanedByServiceAccountPrune = "service-account-prune"
+
+	// ServiceAccountPruneMode values (spec.serviceAccountPruneMode).
+	ServiceAccountPruneModeOrphan = "Orphan"
+	ServiceAccountPruneModeDelete = "Delete"
 )
 
 // GenerateServiceAccountName generates a ServiceAccount name based on the pattern
@@ -38,10 +52,152 @@ func GenerateServiceAccountName(pattern, namespace, name string) string {
 	return result
 }
 
+// ServiceAccountConfigsFromMapping converts a legacy serviceAccountMapping
+// (name -> ClusterRole) into structured ServiceAccount entries.
+func ServiceAccountConfigsFromMapping(saMapping map[string]string) map[string]permissionv1.ServiceAccountConfig {
+	configs := make(map[string]permissionv1.ServiceAccountConfig, len(saMapping))
+	mergeServiceAccountConfigs(configs, saMapping, nil)
+	return configs
+}
+
+// mergeServiceAccountConfigs overlays a string mapping and structured entries
+// onto configs. Structured entries replace string entries with the same name.
+func mergeServiceAccountConfigs(
+	configs map[string]permissionv1.ServiceAccountConfig,
+	saMapping map[string]string,
+	serviceAccounts []permissionv1.ServiceAccountConfig,
+) {
+	for saName, roleName := range saMapping {
+		configs[saName] = permissionv1.ServiceAccountConfig{
+			Name:  saName,
+			Roles: []permissionv1.ServiceAccountRoleRef{{Kind: "ClusterRole", Name: roleName}},
+		}
+	}
+	for _, saConfig := range serviceAccounts {
+		configs[saConfig.Name] = saConfig
+	}
+}
+
+// serviceAccountRoleKind returns the RoleRef kind, defaulting to ClusterRole
+func serviceAccountRoleKind(roleRef permissionv1.ServiceAccountRoleRef) string {
+	if roleRef.Kind == "" {
+		return "ClusterRole"
+	}
+	return roleRef.Kind
+}
+
+// ServiceAccountRoleBindingNames returns the RoleBinding name for each role of
+// a ServiceAccount entry, in the order of saConfig.Roles. A single role keeps
+// the historical sa-{namespace}-{name} name of the string mapping. With several
+// roles each is bound by sa-{namespace}-{name}-{kind}-{hash}, the hash covering
+// the entry and its role: the name neither depends on the order of the roles
+// nor collides with another entry, and stays valid for role names such as
+// system:aggregate-to-view.
+func ServiceAccountRoleBindingNames(namespace string, saConfig permissionv1.ServiceAccountConfig) []string {
+	if len(saConfig.Roles) == 1 {
+		return []string{fmt.Sprintf("sa-%s-%s", namespace, saConfig.Name)}
+	}
+	names := make([]string, 0, len(saConfig.Roles))
+	for _, roleRef := range saConfig.Roles {
+		kind := serviceAccountRoleKind(roleRef)
+		sum := sha256.Sum256([]byte(saConfig.Name + "\x00" + kind + "\x00" + roleRef.Name))
+		names = append(names, fmt.Sprintf("sa-%s-%s-%s-%s",
+			namespace, saConfig.Name, strings.ToLower(kind), hex.EncodeToString(sum[:])[:8]))
+	}
+	return names
+}
+
+// serviceAccountRoleNames returns the role names of an entry, comma separated
+// (value of the permission-binder.io/role annotation on the ServiceAccount)
+func serviceAccountRoleNames(saConfig permissionv1.ServiceAccountConfig) string {
+	roleNames := make([]string, 0, len(saConfig.Roles))
+	for _, roleRef := range saConfig.Roles {
+		roleNames = append(roleNames, roleRef.Name)
+	}
+	return strings.Join(roleNames, ",")
+}
+
+// applyServiceAccountConfig applies the optional fields of a structured entry
+// to a ServiceAccount and reports whether anything changed. Operator-managed
+// labels and permission-binder.io/ annotations are never overridden, labels
+// and annotations removed from the entry are left in place, and
+// imagePullSecrets are only added so platform-injected secrets survive.
+func applyServiceAccountConfig(sa *corev1.ServiceAccount, saConfig permissionv1.ServiceAccountConfig) bool {
+	changed := false
+
+	if len(saConfig.Labels) > 0 && sa.Labels == nil {
+		sa.Labels = make(map[string]string)
+	}
+	for key, value := range saConfig.Labels {
+		if strings.HasPrefix(key, "app.kubernetes.io/") {
+			continue
+		}
+		if sa.Labels[key] != value {
+			sa.Labels[key] = value
+			changed = true
+		}
+	}
+
+	if len(saConfig.Annotations) > 0 && sa.Annotations == nil {
+		sa.Annotations = make(map[string]string)
+	}
+	for key, value := range saConfig.Annotations {
+		if strings.HasPrefix(key, "permission-binder.io/") {
+			continue
+		}
+		if sa.Annotations[key] != value {
+			sa.Annotations[key] = value
+			changed = true
+		}
+	}
+
+	if roleNames := serviceAccountRoleNames(saConfig); sa.Annotations[AnnotationRole] != roleNames {
+		if sa.Annotations == nil {
+			sa.Annotations = make(map[string]string)
+		}
+		sa.Annotations[AnnotationRole] = roleNames
+		changed = true
+	}
+
+	if saConfig.AutomountServiceAccountToken != nil &&
+		(sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken != *saConfig.AutomountServiceAccountToken) {
+		automount := *saConfig.AutomountServiceAccountToken
+		sa.AutomountServiceAccountToken = &automount
+		changed = true
+	}
+
+	for _, secretName := range saConfig.ImagePullSecrets {
+		found := false
+		for _, ref := range sa.ImagePullSecrets {
+			if ref.Name == secretName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: secretName})
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// RoleBindingEventFunc records an Event about a ServiceAccount RoleBinding that was
+// created, updated, orphaned or deleted. A nil RoleBindingEventFunc records nothing.
+type RoleBindingEventFunc func(roleBinding *rbacv1.RoleBinding, reason, messageFmt string, args ...interface{})
+
+func (f RoleBindingEventFunc) record(roleBinding *rbacv1.RoleBinding, reason, messageFmt string, args ...interface{}) {
+	if f != nil {
+		f(roleBinding, reason, messageFmt, args...)
+	}
+}
+
 // ProcessServiceAccounts creates ServiceAccounts and RoleBindings for a namespace
 // based on the ServiceAccountMapping configuration
 // ownerName and ownerNamespace identify the owning PermissionBinder CR; they are
 // stamped on the created resources so ownership is unique per operator instance.
+// It records no Events, see ProcessServiceAccountConfigs.
 func ProcessServiceAccounts(
 	ctx context.Context,
 	k8sClient client.Client,
@@ -50,20 +206,38 @@ func ProcessServiceAccounts(
 	namingPattern string,
 	ownerName string,
 	ownerNamespace string,
+) ([]string, error) {
+	return ProcessServiceAccountConfigs(ctx, k8sClient, namespace,
+		ServiceAccountConfigsFromMapping(saMapping), namingPattern, ownerName, ownerNamespace, nil)
+}
+
+// ProcessServiceAccountConfigs creates ServiceAccounts and their RoleBindings for
+// a namespace based on structured ServiceAccount entries (one RoleBinding per role).
+// recordEvent is told about every RoleBinding created or updated.
+func ProcessServiceAccountConfigs(
+	ctx context.Context,
+	k8sClient client.Client,
+	namespace string,
+	saConfigs map[string]permissionv1.ServiceAccountConfig,
+	namingPattern string,
+	ownerName string,
+	ownerNamespace string,
+	recordEvent RoleBindingEventFunc,
 ) ([]string, error) {
 	logger := log.FromContext(ctx)
 	processedSAs := []string{}
 
-	if len(saMapping) == 0 {
+	if len(saConfigs) == 0 {
 		logger.Info("No ServiceAccount mappings configured, skipping SA creation")
 		return processedSAs, nil
 	}
 
 	logger.Info("Processing ServiceAccount mappings",
 		"namespace", namespace,
-		"mappings", len(saMapping))
+		"mappings", len(saConfigs))
 
-	for saName, roleName := range saMapping {
+	for saName, saConfig := range saConfigs {
+		saConfig.Name = saName
 		// Generate SA name using pattern
 		fullSAName := GenerateServiceAccountName(namingPattern, namespace, saName)
 
@@ -94,12 +268,12 @@ func ProcessServiceAccounts(
 						Annotations: map[string]string{
 							AnnotationCreatedBy:                 ManagedByValue,
 							AnnotationSAType:                    saName,
-							AnnotationRole:                      roleName,
 							AnnotationPermissionBinder:          ownerName,
 							AnnotationPermissionBinderNamespace: ownerNamespace,
 						},
 					},
 				}
+				applyServiceAccountConfig(newSA, saConfig)
 
 				if err := k8sClient.Create(ctx, newSA); err != nil {
 					logger.Error(err, "Failed to create ServiceAccount",
@@ -120,14 +294,54 @@ func ProcessServiceAccounts(
 					"namespace", namespace)
 				return processedSAs, err
 			}
+		} else if sa.Annotations[AnnotationOrphanedAt] != "" && canTakeOwnership(sa.Annotations, ownerName, ownerNamespace) {
+			// ADOPTION LOGIC: the SA was orphaned by pruning (or SAFE-MODE
+			// cleanup) and is desired again - remove the orphan markers and
+			// re-stamp ownership instead of treating it as foreign.
+			delete(sa.Annotations, AnnotationOrphanedAt)
+			delete(sa.Annotations, AnnotationOrphanedBy)
+			sa.Annotations[AnnotationPermissionBinder] = ownerName
+			sa.Annotations[AnnotationPermissionBinderNamespace] = ownerNamespace
+			applyServiceAccountConfig(sa, saConfig)
+			if err := k8sClient.Update(ctx, sa); err != nil {
+				logger.Error(err, "Failed to adopt orphaned ServiceAccount",
+					"name", fullSAName,
+					"namespace", namespace)
+				return processedSAs, err
+			}
+			adoptionEventsTotal.Inc()
+			logger.Info("Adopted orphaned ServiceAccount - removed orphaned annotations",
+				"name", fullSAName,
+				"namespace", namespace,
+				"permissionBinder", ownerName,
+				"action", "adoption",
+				"recovery", "automatic")
+		} else if isOwnedBy(sa.Annotations, ownerName, ownerNamespace) {
+			// Owned ServiceAccount - keep annotations, labels, automount and
+			// imagePullSecrets in line with the entry
+			if applyServiceAccountConfig(sa, saConfig) {
+				if err := k8sClient.Update(ctx, sa); err != nil {
+					logger.Error(err, "Failed to update ServiceAccount",
+						"name", fullSAName,
+						"namespace", namespace)
+					return processedSAs, err
+				}
+				logger.Info("ServiceAccount updated to match configuration",
+					"name", fullSAName,
+					"namespace", namespace)
+			} else {
+				logger.Info("ServiceAccount already exists, skipping creation",
+					"name", fullSAName,
+					"namespace", namespace)
+			}
 		} else {
 			// ServiceAccount already exists, skip (idempotent)
 			logger.Info("ServiceAccount already exists, skipping creation",
 				"name", fullSAName,
 				"namespace", namespace)
-			// Visibility only (issue #43): the SA half never mutates existing
+			// Visibility only (issue #43): the SA half never mutates foreign
 			// objects, but flag when a foreign-claimed SA is treated as satisfied.
-			if sa.Annotations[AnnotationPermissionBinder] != "" && !isOwnedBy(sa.Annotations, ownerName, ownerNamespace) {
+			if sa.Annotations[AnnotationPermissionBinder] != "" {
 				logger.Info("Existing ServiceAccount is claimed by another PermissionBinder - treating as satisfied without taking ownership",
 					"name", fullSAName,
 					"namespace", namespace,
@@ -138,212 +352,537 @@ func ProcessServiceAccounts(
 			}
 		}
 
-		// 2. Create or update RoleBinding for ServiceAccount
+		// 2. Create or update one RoleBinding per role of the ServiceAccount
 		// Use SA key (e.g. "deploy") in name, not ClusterRole name (e.g. "edit")
 		// This matches the convention for LDAP group RoleBindings: namespace-role
-		roleBindingName := fmt.Sprintf("sa-%s-%s", namespace, saName)
-		rb := &rbacv1.RoleBinding{}
-		rbKey := types.NamespacedName{
+		managed := true
+		seen := make(map[string]bool)
+		for i, roleBindingName := range ServiceAccountRoleBindingNames(namespace, saConfig) {
+			if seen[roleBindingName] {
+				logger.Info("Duplicate role reference for ServiceAccount, skipping",
+					"serviceAccount", fullSAName,
+					"role", saConfig.Roles[i].Name)
+				continue
+			}
+			seen[roleBindingName] = true
+
+			ok, err := ensureServiceAccountRoleBinding(ctx, k8sClient, namespace, roleBindingName,
+				fullSAName, saName, saConfig.Roles[i], ownerName, ownerNamespace, recordEvent)
+			if err != nil {
+				return processedSAs, err
+			}
+			if !ok {
+				managed = false
+			}
+		}
+		if !managed {
+			continue
+		}
+
+		// Track processed SA
+		processedSAs = append(processedSAs, fmt.Sprintf("%s/%s", namespace, fullSAName))
+	}
+
+	logger.Info("ServiceAccount processing completed",
+		"namespace", namespace,
+		"processed", len(processedSAs))
+
+	return processedSAs, nil
+}
+
+// newServiceAccountRoleBinding builds a RoleBinding granting roleRef to a ServiceAccount
+func newServiceAccountRoleBinding(
+	namespace, roleBindingName, fullSAName, saName string,
+	roleRef permissionv1.ServiceAccountRoleRef,
+	ownerName, ownerNamespace string,
+) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
 			Name:      roleBindingName,
 			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": ManagedByValue,
+				"app.kubernetes.io/component":  "service-account-binding",
+				"app.kubernetes.io/name":       ownerName,
+			},
+			Annotations: map[string]string{
+				AnnotationCreatedBy:                 ManagedByValue,
+				AnnotationServiceAccount:            fullSAName,
+				AnnotationSAType:                    saName,
+				AnnotationPermissionBinder:          ownerName,
+				AnnotationPermissionBinderNamespace: ownerNamespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     serviceAccountRoleKind(roleRef),
+			Name:     roleRef.Name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      fullSAName,
+				Namespace: namespace,
+			},
+		},
+	}
+}
+
+// ensureServiceAccountRoleBinding creates or updates a single ServiceAccount
+// RoleBinding. It returns false (without error) when the RoleBinding is claimed
+// by another PermissionBinder and was left untouched.
+func ensureServiceAccountRoleBinding(
+	ctx context.Context,
+	k8sClient client.Client,
+	namespace, roleBindingName, fullSAName, saName string,
+	roleRef permissionv1.ServiceAccountRoleRef,
+	ownerName, ownerNamespace string,
+	recordEvent RoleBindingEventFunc,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+	roleKind := serviceAccountRoleKind(roleRef)
+
+	rb := &rbacv1.RoleBinding{}
+	rbKey := types.NamespacedName{
+		Name:      roleBindingName,
+		Namespace: namespace,
+	}
+
+	err := k8sClient.Get(ctx, rbKey, rb)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get RoleBinding",
+				"roleBinding", roleBindingName,
+				"namespace", namespace)
+			return false, err
 		}
 
-		err = k8sClient.Get(ctx, rbKey, rb)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				// RoleBinding doesn't exist, create it
-				logger.Info("Creating RoleBinding for ServiceAccount",
-					"roleBinding", roleBindingName,
-					"serviceAccount", fullSAName,
-					"role", roleName,
-					"namespace", namespace)
+		// RoleBinding doesn't exist, create it
+		logger.Info("Creating RoleBinding for ServiceAccount",
+			"roleBinding", roleBindingName,
+			"serviceAccount", fullSAName,
+			"roleKind", roleKind,
+			"role", roleRef.Name,
+			"namespace", namespace)
+
+		newRB := newServiceAccountRoleBinding(namespace, roleBindingName, fullSAName, saName, roleRef, ownerName, ownerNamespace)
+		if err := k8sClient.Create(ctx, newRB); err != nil {
+			logger.Error(err, "Failed to create RoleBinding for ServiceAccount",
+				"roleBinding", roleBindingName,
+				"serviceAccount", fullSAName,
+				"namespace", namespace)
+			return false, err
+		}
+		recordEvent.record(newRB, EventReasonRoleBindingCreated,
+			"Created RoleBinding %s/%s granting %s %s to ServiceAccount %s",
+			namespace, roleBindingName, roleKind, roleRef.Name, fullSAName)
+
+		// Finish an interrupted make-before-break replacement
+		if err := cleanupTransitionRoleBinding(ctx, k8sClient, namespace, roleBindingName); err != nil {
+			logger.Error(err, "Failed to clean up transition RoleBinding (non-fatal)",
+				"roleBinding", roleBindingName,
+				"namespace", namespace)
+		}
 
-				newRB := &rbacv1.RoleBinding{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      roleBindingName,
-						Namespace: namespace,
-						Labels: map[string]string{
-							"app.kubernetes.io/managed-by": ManagedByValue,
-							"app.kubernetes.io/component":  "service-account-binding",
-							"app.kubernetes.io/name":       ownerName,
-						},
-						Annotations: map[string]string{
-							AnnotationCreatedBy:                 ManagedByValue,
-							AnnotationServiceAccount:            fullSAName,
-							AnnotationSAType:                    saName,
-							AnnotationPermissionBinder:          ownerName,
-							AnnotationPermissionBinderNamespace: ownerNamespace,
-						},
-					},
-					RoleRef: rbacv1.RoleRef{
-						APIGroup: "rbac.authorization.k8s.io",
-						Kind:     "ClusterRole",
-						Name:     roleName,
-					},
-					Subjects: []rbacv1.Subject{
-						{
-							Kind:      "ServiceAccount",
-							Name:      fullSAName,
-							Namespace: namespace,
-						},
-					},
-				}
+		logger.Info("RoleBinding created successfully for ServiceAccount",
+			"roleBinding", roleBindingName,
+			"serviceAccount", fullSAName,
+			"roleKind", roleKind,
+			"role", roleRef.Name,
+			"namespace", namespace)
 
-				if err := k8sClient.Create(ctx, newRB); err != nil {
-					logger.Error(err, "Failed to create RoleBinding for ServiceAccount",
-						"roleBinding", roleBindingName,
-						"serviceAccount", fullSAName,
-						"namespace", namespace)
-					return processedSAs, err
-				}
+		// Metrics are updated in controller after processing all namespaces
+		return true, nil
+	}
 
-				logger.Info("RoleBinding created successfully for ServiceAccount",
-					"roleBinding", roleBindingName,
-					"serviceAccount", fullSAName,
-					"role", roleName,
-					"namespace", namespace)
+	// OWNERSHIP GATE (issue #43): a RoleBinding with a live claim by
+	// another PermissionBinder is excluded from this CR's processing
+	// entirely - not deleted/recreated on drift and not counted in
+	// processedSAs/status either (consistent exclusion, no metric
+	// flapping when the mapping later changes). SA RoleBindings are
+	// only orphan-annotated by PruneServiceAccounts (SAFE-MODE cleanup
+	// selects by LabelManagedBy, which they do not carry); such
+	// orphans are adoptable like any other orphaned resource.
+	if !canTakeOwnership(rb.Annotations, ownerName, ownerNamespace) {
+		ownershipConflictsTotal.WithLabelValues("serviceaccount_rolebinding").Inc()
+		logger.Info("Refusing to take ownership of ServiceAccount RoleBinding claimed by another PermissionBinder",
+			"roleBinding", roleBindingName,
+			"namespace", namespace,
+			"claimedBy", rb.Annotations[AnnotationPermissionBinder],
+			"claimedByNamespace", rb.Annotations[AnnotationPermissionBinderNamespace],
+			"reconciledBy", ownerName,
+			"reconciledByNamespace", ownerNamespace)
+		return false, nil
+	}
 
-				// Metrics are updated in controller after processing all namespaces
-			} else {
-				logger.Error(err, "Failed to get RoleBinding",
-					"roleBinding", roleBindingName,
-					"namespace", namespace)
-				return processedSAs, err
-			}
-		} else {
-			// OWNERSHIP GATE (issue #43): a RoleBinding with a live claim by
-			// another PermissionBinder is excluded from this CR's processing
-			// entirely - not deleted/recreated on drift and not counted in
-			// processedSAs/status either (consistent exclusion, no metric
-			// flapping when the mapping later changes). SA RoleBindings are
-			// never orphan-annotated (SAFE-MODE cleanup selects by
-			// LabelManagedBy, which they do not carry), so only unclaimed,
-			// own and legacy name-only RoleBindings are manageable here.
-			if !canTakeOwnership(rb.Annotations, ownerName, ownerNamespace) {
-				ownershipConflictsTotal.WithLabelValues("serviceaccount_rolebinding").Inc()
-				logger.Info("Refusing to take ownership of ServiceAccount RoleBinding claimed by another PermissionBinder",
-					"roleBinding", roleBindingName,
-					"namespace", namespace,
-					"claimedBy", rb.Annotations[AnnotationPermissionBinder],
-					"claimedByNamespace", rb.Annotations[AnnotationPermissionBinderNamespace],
-					"reconciledBy", ownerName,
-					"reconciledByNamespace", ownerNamespace)
+	// BREAK-GLASS: left alone, drift included, until the annotation is removed or expires
+	if breakGlassActive(rb.Annotations, time.Now()) {
+		logger.Info("Leaving break-glass ServiceAccount RoleBinding alone",
+			"roleBinding", roleBindingName,
+			"namespace", namespace,
+			"breakGlass", rb.Annotations[AnnotationBreakGlass])
+		return true, nil
+	}
+
+	// RoleBinding exists, check if it needs update
+	needsUpdate := false
+
+	// Check if RoleRef changed
+	if rb.RoleRef.Name != roleRef.Name || rb.RoleRef.Kind != roleKind {
+		logger.Info("RoleBinding role changed, needs update",
+			"roleBinding", roleBindingName,
+			"oldRoleKind", rb.RoleRef.Kind,
+			"oldRole", rb.RoleRef.Name,
+			"newRoleKind", roleKind,
+			"newRole", roleRef.Name)
+		needsUpdate = true
+	}
+
+	// Check if Subject changed
+	if len(rb.Subjects) == 0 || rb.Subjects[0].Name != fullSAName {
+		logger.Info("RoleBinding subject changed, needs update",
+			"roleBinding", roleBindingName)
+		needsUpdate = true
+	}
+
+	// Legacy RoleBindings (name-only or missing ownership annotations)
+	// are re-stamped in place on first reconcile, so the
+	// namespace-aware takeover protection engages immediately after
+	// upgrade instead of only when the role mapping changes (issue #43
+	// upgrade asymmetry). Annotation-only changes use a plain Update -
+	// delete+recreate would briefly drop the granted permissions.
+	stampOutdated := rb.Annotations[AnnotationPermissionBinderNamespace] != ownerNamespace ||
+		rb.Annotations[AnnotationPermissionBinder] != ownerName ||
+		rb.Annotations[AnnotationOrphanedAt] != ""
+
+	if !needsUpdate && stampOutdated {
+		if rb.Annotations == nil {
+			rb.Annotations = make(map[string]string)
+		}
+		if rb.Annotations[AnnotationOrphanedAt] != "" {
+			// ADOPTION LOGIC: orphaned by a previous prune, desired again
+			delete(rb.Annotations, AnnotationOrphanedAt)
+			delete(rb.Annotations, AnnotationOrphanedBy)
+			adoptionEventsTotal.Inc()
+		}
+		rb.Annotations[AnnotationPermissionBinder] = ownerName
+		rb.Annotations[AnnotationPermissionBinderNamespace] = ownerNamespace
+		if err := k8sClient.Update(ctx, rb); err != nil {
+			logger.Error(err, "Failed to re-stamp ownership annotations on RoleBinding",
+				"roleBinding", roleBindingName,
+				"namespace", namespace)
+			return false, err
+		}
+		recordEvent.record(rb, EventReasonRoleBindingUpdated,
+			"Updated ownership of RoleBinding %s/%s of ServiceAccount %s", namespace, roleBindingName, fullSAName)
+		logger.Info("Re-stamped ownership annotations on legacy RoleBinding",
+			"roleBinding", roleBindingName,
+			"namespace", namespace)
+	}
+
+	if !needsUpdate {
+		logger.Info("RoleBinding already up-to-date",
+			"roleBinding", roleBindingName,
+			"namespace", namespace)
+		return true, nil
+	}
+
+	newRB := newServiceAccountRoleBinding(namespace, roleBindingName, fullSAName, saName, roleRef, ownerName, ownerNamespace)
+	updated := newRB
+	if rb.RoleRef == newRB.RoleRef {
+		// Only the subject drifted - Subjects are mutable, update in place
+		rb.Subjects = newRB.Subjects
+		if rb.Annotations == nil {
+			rb.Annotations = make(map[string]string)
+		}
+		if rb.Annotations[AnnotationOrphanedAt] != "" {
+			delete(rb.Annotations, AnnotationOrphanedAt)
+			delete(rb.Annotations, AnnotationOrphanedBy)
+			adoptionEventsTotal.Inc()
+		}
+		rb.Annotations[AnnotationServiceAccount] = fullSAName
+		rb.Annotations[AnnotationPermissionBinder] = ownerName
+		rb.Annotations[AnnotationPermissionBinderNamespace] = ownerNamespace
+		if err := k8sClient.Update(ctx, rb); err != nil {
+			logger.Error(err, "Failed to update RoleBinding subject",
+				"roleBinding", roleBindingName)
+			return false, err
+		}
+		updated = rb
+	} else if err := replaceRoleBinding(ctx, k8sClient, rb, newRB, "serviceaccount_rolebinding"); err != nil {
+		// RoleRef is immutable - replaced make-before-break, the previous
+		// binding is kept on failure
+		logger.Error(err, "Failed to replace RoleBinding",
+			"roleBinding", roleBindingName)
+		return false, err
+	}
+	recordEvent.record(updated, EventReasonRoleBindingUpdated,
+		"Updated RoleBinding %s/%s granting %s %s to ServiceAccount %s",
+		namespace, roleBindingName, roleKind, roleRef.Name, fullSAName)
+
+	logger.Info("RoleBinding updated successfully",
+		"roleBinding", roleBindingName,
+		"namespace", namespace)
+	return true, nil
+}
+
+// ServiceAccountPruneResult holds the outcome of PruneServiceAccounts.
+// Entries use the "namespace/name" format of ProcessedServiceAccounts.
+type ServiceAccountPruneResult struct {
+	Deleted  []string
+	Orphaned []string
+}
+
+// ResolveServiceAccountConfigs returns the effective ServiceAccount entries for
+// a namespace: serviceAccountMapping merged with the structured serviceAccounts.
+// The first override selecting the namespace (by explicit name, regex pattern
+// or one of the whitelist prefixes that produced it) is merged over them: its
+// serviceAccountMapping and serviceAccounts add or replace entries, exclude
+// drops entries and disabled yields no entries. Invalid regex patterns select
+// no namespace; newServiceAccountResolver reports them and compiles the patterns
+// once for many namespaces.
+func ResolveServiceAccountConfigs(
+	spec *permissionv1.PermissionBinderSpec,
+	namespace string,
+	prefixes []string,
+) map[string]permissionv1.ServiceAccountConfig {
+	resolver, _ := newServiceAccountResolver(spec)
+	return resolver.resolve(namespace, prefixes)
+}
+
+// serviceAccountResolver resolves the effective ServiceAccount entries of the
+// namespaces of a reconciliation (see ResolveServiceAccountConfigs)
+type serviceAccountResolver struct {
+	spec *permissionv1.PermissionBinderSpec
+	// patterns holds the compiled namespacePatterns per override, invalid ones left out
+	patterns [][]*regexp.Regexp
+}
+
+// newServiceAccountResolver compiles the namespacePatterns of the
+// serviceAccountOverrides. It returns an error per invalid pattern; such a pattern
+// selects no namespace.
+func newServiceAccountResolver(spec *permissionv1.PermissionBinderSpec) (*serviceAccountResolver, []error) {
+	resolver := &serviceAccountResolver{
+		spec:     spec,
+		patterns: make([][]*regexp.Regexp, len(spec.ServiceAccountOverrides)),
+	}
+	var errs []error
+	for i, override := range spec.ServiceAccountOverrides {
+		for j, pattern := range override.NamespacePatterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("serviceAccountOverrides[%d].namespacePatterns[%d]: invalid regex %q: %w", i, j, pattern, err))
 				continue
 			}
+			resolver.patterns[i] = append(resolver.patterns[i], re)
+		}
+	}
+	return resolver, errs
+}
 
-			// RoleBinding exists, check if it needs update
-			needsUpdate := false
+// resolve returns the effective ServiceAccount entries for a namespace
+func (r *serviceAccountResolver) resolve(namespace string, prefixes []string) map[string]permissionv1.ServiceAccountConfig {
+	resolved := make(map[string]permissionv1.ServiceAccountConfig)
+	mergeServiceAccountConfigs(resolved, r.spec.ServiceAccountMapping, r.spec.ServiceAccounts)
 
-			// Check if RoleRef changed
-			if rb.RoleRef.Name != roleName {
-				logger.Info("RoleBinding role changed, needs update",
-					"roleBinding", roleBindingName,
-					"oldRole", rb.RoleRef.Name,
-					"newRole", roleName)
-				needsUpdate = true
-			}
+	for i := range r.spec.ServiceAccountOverrides {
+		override := &r.spec.ServiceAccountOverrides[i]
+		if !serviceAccountOverrideMatches(override, r.patterns[i], namespace, prefixes) {
+			continue
+		}
+		if override.Disabled {
+			return map[string]permissionv1.ServiceAccountConfig{}
+		}
+		mergeServiceAccountConfigs(resolved, override.ServiceAccountMapping, override.ServiceAccounts)
+		for _, name := range override.Exclude {
+			delete(resolved, name)
+		}
+		break
+	}
 
-			// Check if Subject changed
-			if len(rb.Subjects) == 0 || rb.Subjects[0].Name != fullSAName {
-				logger.Info("RoleBinding subject changed, needs update",
-					"roleBinding", roleBindingName)
-				needsUpdate = true
-			}
+	return resolved
+}
 
-			// Legacy RoleBindings (name-only or missing ownership annotations)
-			// are re-stamped in place on first reconcile, so the
-			// namespace-aware takeover protection engages immediately after
-			// upgrade instead of only when the role mapping changes (issue #43
-			// upgrade asymmetry). Annotation-only changes use a plain Update -
-			// delete+recreate would briefly drop the granted permissions.
-			stampOutdated := rb.Annotations[AnnotationPermissionBinderNamespace] != ownerNamespace ||
-				rb.Annotations[AnnotationPermissionBinder] != ownerName
-
-			if !needsUpdate && stampOutdated {
-				if rb.Annotations == nil {
-					rb.Annotations = make(map[string]string)
-				}
-				rb.Annotations[AnnotationPermissionBinder] = ownerName
-				rb.Annotations[AnnotationPermissionBinderNamespace] = ownerNamespace
-				if err := k8sClient.Update(ctx, rb); err != nil {
-					logger.Error(err, "Failed to re-stamp ownership annotations on RoleBinding",
-						"roleBinding", roleBindingName,
-						"namespace", namespace)
-					return processedSAs, err
-				}
-				logger.Info("Re-stamped ownership annotations on legacy RoleBinding",
-					"roleBinding", roleBindingName,
-					"namespace", namespace)
+// serviceAccountOverrideMatches reports whether the override selects the namespace;
+// patterns are its compiled namespacePatterns
+func serviceAccountOverrideMatches(override *permissionv1.ServiceAccountOverride, patterns []*regexp.Regexp, namespace string, prefixes []string) bool {
+	for _, name := range override.Namespaces {
+		if name == namespace {
+			return true
+		}
+	}
+	for _, re := range patterns {
+		if re.MatchString(namespace) {
+			return true
+		}
+	}
+	for _, prefix := range override.Prefixes {
+		for _, p := range prefixes {
+			if prefix == p {
+				return true
 			}
+		}
+	}
+	return false
+}
 
-			if needsUpdate {
-				// Update RoleBinding
-				// Note: RoleRef is immutable, so we need to delete and recreate
-				logger.Info("Deleting RoleBinding for update",
-					"roleBinding", roleBindingName,
-					"namespace", namespace)
+// PruneServiceAccounts removes ServiceAccounts and their sa-{namespace}-{name}
+// RoleBindings owned by the given PermissionBinder that are no longer in the
+// desired set. desiredConfigs holds the effective ServiceAccount entries per
+// namespace (see ResolveServiceAccountConfigs); the desired set is every entry
+// of every namespace, with one RoleBinding per role. Namespaces listed in
+// skipNamespaces (e.g. where ProcessServiceAccounts failed this round) are left
+// untouched so a transient error never looks like a shrunk mapping.
+//
+// pruneMode mirrors SAFE MODE semantics: ServiceAccountPruneModeOrphan (the
+// default for an empty value) only stamps orphaned-at/orphaned-by annotations,
+// ServiceAccountPruneModeDelete deletes the RoleBinding first and then the
+// ServiceAccount. Resources claimed by another PermissionBinder are never
+// touched. recordEvent is told about every RoleBinding orphaned or deleted; a
+// deletion held back by deletionProtection is reported by applyDeletions once
+// it is executed.
+func PruneServiceAccounts(
+	ctx context.Context,
+	k8sClient client.Client,
+	desiredConfigs map[string]map[string]permissionv1.ServiceAccountConfig,
+	skipNamespaces map[string]bool,
+	namingPattern string,
+	pruneMode string,
+	ownerName string,
+	ownerNamespace string,
+	recordEvent RoleBindingEventFunc,
+) (ServiceAccountPruneResult, error) {
+	logger := log.FromContext(ctx)
+	result := ServiceAccountPruneResult{}
 
-				if err := k8sClient.Delete(ctx, rb); err != nil {
-					logger.Error(err, "Failed to delete RoleBinding for update",
-						"roleBinding", roleBindingName)
-					return processedSAs, err
-				}
+	if pruneMode == "" {
+		pruneMode = ServiceAccountPruneModeOrphan
+	}
 
-				// Recreate with new values
-				newRB := &rbacv1.RoleBinding{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      roleBindingName,
-						Namespace: namespace,
-						Labels: map[string]string{
-							"app.kubernetes.io/managed-by": ManagedByValue,
-							"app.kubernetes.io/component":  "service-account-binding",
-							"app.kubernetes.io/name":       ownerName,
-						},
-						Annotations: map[string]string{
-							AnnotationCreatedBy:                 ManagedByValue,
-							AnnotationServiceAccount:            fullSAName,
-							AnnotationSAType:                    saName,
-							AnnotationPermissionBinder:          ownerName,
-							AnnotationPermissionBinderNamespace: ownerNamespace,
-						},
-					},
-					RoleRef: rbacv1.RoleRef{
-						APIGroup: "rbac.authorization.k8s.io",
-						Kind:     "ClusterRole",
-						Name:     roleName,
-					},
-					Subjects: []rbacv1.Subject{
-						{
-							Kind:      "ServiceAccount",
-							Name:      fullSAName,
-							Namespace: namespace,
-						},
-					},
-				}
+	// Build the desired ServiceAccount and RoleBinding names ("namespace/name")
+	desiredSAs := make(map[string]bool)
+	desiredRBs := make(map[string]bool)
+	for namespace, saConfigs := range desiredConfigs {
+		for saName, saConfig := range saConfigs {
+			saConfig.Name = saName
+			desiredSAs[namespace+"/"+GenerateServiceAccountName(namingPattern, namespace, saName)] = true
+			for _, roleBindingName := range ServiceAccountRoleBindingNames(namespace, saConfig) {
+				desiredRBs[namespace+"/"+roleBindingName] = true
+			}
+		}
+	}
 
-				if err := k8sClient.Create(ctx, newRB); err != nil {
-					logger.Error(err, "Failed to recreate RoleBinding",
-						"roleBinding", roleBindingName)
-					return processedSAs, err
-				}
+	managedLabels := client.MatchingLabels{"app.kubernetes.io/managed-by": ManagedByValue}
 
-				logger.Info("RoleBinding updated successfully",
-					"roleBinding", roleBindingName,
-					"namespace", namespace)
-			} else {
-				logger.Info("RoleBinding already up-to-date",
-					"roleBinding", roleBindingName,
-					"namespace", namespace)
+	// RoleBindings first - in Delete mode this revokes permissions before the
+	// ServiceAccount disappears, so no binding ever points at a missing subject
+	var rbList rbacv1.RoleBindingList
+	if err := k8sClient.List(ctx, &rbList, managedLabels,
+		client.MatchingLabels{"app.kubernetes.io/component": "service-account-binding"}); err != nil {
+		return result, fmt.Errorf("failed to list ServiceAccount RoleBindings: %w", err)
+	}
+	for i := range rbList.Items {
+		rb := &rbList.Items[i]
+		key := rb.Namespace + "/" + rb.Name
+		if desiredRBs[key] || skipNamespaces[rb.Namespace] || !isOwnedBy(rb.Annotations, ownerName, ownerNamespace) ||
+			breakGlassActive(rb.Annotations, time.Now()) {
+			continue
+		}
+		if rb.Annotations[AnnotationTransitionFor] != "" {
+			// Leftover of an interrupted make-before-break replacement - the
+			// stable binding was ensured above, so it is always removed
+			if err := k8sClient.Delete(ctx, rb); err != nil && !errors.IsNotFound(err) {
+				return result, fmt.Errorf("failed to delete transition RoleBinding %s: %w", key, err)
 			}
+			continue
+		}
+		changed, err := pruneObject(ctx, k8sClient, rb, pruneMode)
+		if err != nil {
+			logger.Error(err, "Failed to prune ServiceAccount RoleBinding",
+				"roleBinding", rb.Name,
+				"namespace", rb.Namespace,
+				"mode", pruneMode)
+			return result, err
+		}
+		switch {
+		case !changed:
+		case pruneMode != ServiceAccountPruneModeDelete:
+			recordEvent.record(rb, EventReasonRoleBindingOrphaned,
+				"Orphaned RoleBinding %s/%s no longer in the desired ServiceAccount set", rb.Namespace, rb.Name)
+		case !deletionsDeferred(k8sClient):
+			recordEvent.record(rb, EventReasonRoleBindingDeleted,
+				"Deleted RoleBinding %s/%s no longer in the desired ServiceAccount set", rb.Namespace, rb.Name)
 		}
+	}
 
-		// Track processed SA
-		processedSAs = append(processedSAs, fmt.Sprintf("%s/%s", namespace, fullSAName))
+	var saList corev1.ServiceAccountList
+	if err := k8sClient.List(ctx, &saList, managedLabels); err != nil {
+		return result, fmt.Errorf("failed to list ServiceAccounts: %w", err)
 	}
+	for i := range saList.Items {
+		sa := &saList.Items[i]
+		key := sa.Namespace + "/" + sa.Name
+		if sa.Annotations[AnnotationSAType] == "" || desiredSAs[key] || skipNamespaces[sa.Namespace] ||
+			!isOwnedBy(sa.Annotations, ownerName, ownerNamespace) {
+			continue
+		}
+		changed, err := pruneObject(ctx, k8sClient, sa, pruneMode)
+		if err != nil {
+			logger.Error(err, "Failed to prune ServiceAccount",
+				"name", sa.Name,
+				"namespace", sa.Namespace,
+				"mode", pruneMode)
+			return result, err
+		}
+		if !changed {
+			continue
+		}
 
-	logger.Info("ServiceAccount processing completed",
-		"namespace", namespace,
-		"processed", len(processedSAs))
+		saType := sa.Annotations[AnnotationSAType]
+		if pruneMode == ServiceAccountPruneModeDelete {
+			result.Deleted = append(result.Deleted, key)
+			serviceAccountsPruned.WithLabelValues(sa.Namespace, saType, "deleted").Inc()
+		} else {
+			result.Orphaned = append(result.Orphaned, key)
+			serviceAccountsPruned.WithLabelValues(sa.Namespace, saType, "orphaned").Inc()
+		}
+		logger.Info("Pruned ServiceAccount no longer in desired set",
+			"name", sa.Name,
+			"namespace", sa.Namespace,
+			"saType", saType,
+			"mode", pruneMode,
+			"permissionBinder", ownerName)
+	}
 
-	return processedSAs, nil
+	return result, nil
+}
+
+// pruneObject deletes obj (Delete mode) or stamps the orphan annotations on it
+// (Orphan mode). It reports whether anything changed - an object that is
+// already orphaned is left as is, so repeated reconciles do not re-count it.
+func pruneObject(ctx context.Context, k8sClient client.Client, obj client.Object, pruneMode string) (bool, error) {
+	if pruneMode == ServiceAccountPruneModeDelete {
+		if err := k8sClient.Delete(ctx, obj); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations[AnnotationOrphanedAt] != "" {
+		return false, nil
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AnnotationOrphanedAt] = time.Now().Format(time.RFC3339)
+	annotations[AnnotationOrphanedBy] = OrphanedByServiceAccountPrune
+	obj.SetAnnotations(annotations)
+	if err := k8sClient.Update(ctx, obj); err != nil {
+		return false, err
+	}
+	return true, nil
 }
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -10,8 +11,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
 )
 
 // TestGenerateServiceAccountName tests the GenerateServiceAccountName function
@@ -628,3 +632,438 @@ func TestProcessServiceAccounts_ManagedByValueOverrideStamping(t *testing.T) {
 		t.Errorf("RB created-by annotation ignores MANAGED_BY_VALUE override: %q", got)
 	}
 }
+
+// ============================================================================
+// PruneServiceAccounts tests (fake K8s client)
+// ============================================================================
+
+// createSAsForPrune runs ProcessServiceAccounts for the given namespaces so the
+// prune tests start from resources stamped exactly like production ones.
+func createSAsForPrune(t *testing.T, k8sClient client.Client, mapping map[string]string, namespaces ...string) {
+	t.Helper()
+	for _, ns := range namespaces {
+		if _, err := ProcessServiceAccounts(context.Background(), k8sClient, ns,
+			mapping, "", "my-binder", "my-namespace"); err != nil {
+			t.Fatalf("ProcessServiceAccounts returned error: %v", err)
+		}
+	}
+}
+
+// TestPruneServiceAccounts_DeleteRemovedKey verifies that in Delete mode a key
+// dropped from the mapping removes both the ServiceAccount and its RoleBinding,
+// while the remaining key is untouched.
+func TestPruneServiceAccounts_DeleteRemovedKey(t *testing.T) {
+	const ns = "team-a"
+	k8sClient := newSAFakeClient()
+	createSAsForPrune(t, k8sClient, map[string]string{"deploy": "edit", "runtime": "view"}, ns)
+
+	result, err := PruneServiceAccounts(context.Background(), k8sClient,
+		map[string]map[string]permissionv1.ServiceAccountConfig{ns: ServiceAccountConfigsFromMapping(map[string]string{"deploy": "edit"})}, nil,
+		"", ServiceAccountPruneModeDelete,
+		"my-binder", "my-namespace", nil)
+	if err != nil {
+		t.Fatalf("PruneServiceAccounts returned error: %v", err)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0] != ns+"/"+ns+"-sa-runtime" {
+		t.Errorf("Expected runtime SA to be deleted, got %v", result.Deleted)
+	}
+
+	var sa corev1.ServiceAccount
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Name: ns + "-sa-runtime", Namespace: ns}, &sa)
+	if err == nil {
+		t.Errorf("Pruned ServiceAccount still exists")
+	}
+	var rb rbacv1.RoleBinding
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Name: "sa-" + ns + "-runtime", Namespace: ns}, &rb)
+	if err == nil {
+		t.Errorf("Pruned ServiceAccount RoleBinding still exists")
+	}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: ns + "-sa-deploy", Namespace: ns}, &sa); err != nil {
+		t.Errorf("Desired ServiceAccount was pruned: %v", err)
+	}
+}
+
+// TestPruneServiceAccounts_OrphanRemovedNamespace verifies the SAFE MODE default:
+// a namespace that left the whitelist keeps its resources, which are only
+// orphan-annotated - and a second prune does not count them again.
+func TestPruneServiceAccounts_OrphanRemovedNamespace(t *testing.T) {
+	k8sClient := newSAFakeClient()
+	mapping := map[string]string{"deploy": "edit"}
+	createSAsForPrune(t, k8sClient, mapping, "team-a", "team-b")
+
+	result, err := PruneServiceAccounts(context.Background(), k8sClient,
+		map[string]map[string]permissionv1.ServiceAccountConfig{"team-a": ServiceAccountConfigsFromMapping(mapping)}, nil, "", "",
+		"my-binder", "my-namespace", nil)
+	if err != nil {
+		t.Fatalf("PruneServiceAccounts returned error: %v", err)
+	}
+	if len(result.Orphaned) != 1 || len(result.Deleted) != 0 {
+		t.Fatalf("Expected exactly one orphaned SA, got %+v", result)
+	}
+
+	var sa corev1.ServiceAccount
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-b-sa-deploy", Namespace: "team-b"}, &sa); err != nil {
+		t.Fatalf("Orphan mode must not delete the ServiceAccount: %v", err)
+	}
+	if sa.Annotations[AnnotationOrphanedBy] != OrphanedByServiceAccountPrune || sa.Annotations[AnnotationOrphanedAt] == "" {
+		t.Errorf("ServiceAccount not orphan-annotated: %v", sa.Annotations)
+	}
+	var rb rbacv1.RoleBinding
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "sa-team-b-deploy", Namespace: "team-b"}, &rb); err != nil {
+		t.Fatalf("Orphan mode must not delete the RoleBinding: %v", err)
+	}
+	if rb.Annotations[AnnotationOrphanedAt] == "" {
+		t.Errorf("RoleBinding not orphan-annotated: %v", rb.Annotations)
+	}
+
+	again, err := PruneServiceAccounts(context.Background(), k8sClient,
+		map[string]map[string]permissionv1.ServiceAccountConfig{"team-a": ServiceAccountConfigsFromMapping(mapping)}, nil, "", "",
+		"my-binder", "my-namespace", nil)
+	if err != nil {
+		t.Fatalf("PruneServiceAccounts returned error: %v", err)
+	}
+	if len(again.Orphaned) != 0 {
+		t.Errorf("Already orphaned SA must not be counted again, got %v", again.Orphaned)
+	}
+
+	// Re-adding the namespace adopts the orphaned resources
+	createSAsForPrune(t, k8sClient, mapping, "team-b")
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-b-sa-deploy", Namespace: "team-b"}, &sa); err != nil {
+		t.Fatalf("ServiceAccount not found after adoption: %v", err)
+	}
+	if sa.Annotations[AnnotationOrphanedAt] != "" {
+		t.Errorf("Orphan markers not removed on adoption: %v", sa.Annotations)
+	}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "sa-team-b-deploy", Namespace: "team-b"}, &rb); err != nil {
+		t.Fatalf("RoleBinding not found after adoption: %v", err)
+	}
+	if rb.Annotations[AnnotationOrphanedAt] != "" {
+		t.Errorf("Orphan markers not removed from RoleBinding on adoption: %v", rb.Annotations)
+	}
+}
+
+// TestPruneServiceAccounts_ForeignAndSkippedUntouched verifies that resources
+// owned by another PermissionBinder and namespaces whose processing failed are
+// never pruned.
+func TestPruneServiceAccounts_ForeignAndSkippedUntouched(t *testing.T) {
+	k8sClient := newSAFakeClient()
+	createSAsForPrune(t, k8sClient, map[string]string{"deploy": "edit"}, "team-a")
+	if _, err := ProcessServiceAccounts(context.Background(), k8sClient, "team-b",
+		map[string]string{"deploy": "edit"}, "", "other-binder", "other-namespace"); err != nil {
+		t.Fatalf("ProcessServiceAccounts returned error: %v", err)
+	}
+
+	result, err := PruneServiceAccounts(context.Background(), k8sClient,
+		map[string]map[string]permissionv1.ServiceAccountConfig{}, map[string]bool{"team-a": true},
+		"", ServiceAccountPruneModeDelete,
+		"my-binder", "my-namespace", nil)
+	if err != nil {
+		t.Fatalf("PruneServiceAccounts returned error: %v", err)
+	}
+	if len(result.Deleted) != 0 {
+		t.Errorf("Expected nothing pruned, got %v", result.Deleted)
+	}
+
+	var sa corev1.ServiceAccount
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-a-sa-deploy", Namespace: "team-a"}, &sa); err != nil {
+		t.Errorf("ServiceAccount in skipped namespace was pruned: %v", err)
+	}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-b-sa-deploy", Namespace: "team-b"}, &sa); err != nil {
+		t.Errorf("Foreign-owned ServiceAccount was pruned: %v", err)
+	}
+}
+
+// TestResolveServiceAccountConfigs tests per-namespace serviceAccountOverrides
+func TestResolveServiceAccountConfigs(t *testing.T) {
+	spec := &permissionv1.PermissionBinderSpec{
+		ServiceAccountMapping: map[string]string{"deploy": "edit", "runtime": "view"},
+		ServiceAccounts: []permissionv1.ServiceAccountConfig{
+			{Name: "runtime", Roles: []permissionv1.ServiceAccountRoleRef{{Kind: "Role", Name: "app-reader"}}},
+		},
+		ServiceAccountOverrides: []permissionv1.ServiceAccountOverride{
+			{Namespaces: []string{"sandbox"}, Disabled: true},
+			{NamespacePatterns: []string{"[invalid", "^prod-"}, ServiceAccountMapping: map[string]string{"deploy": "admin", "backup": "view"}},
+			{Prefixes: []string{"MT-K8S-DEV"}, Exclude: []string{"runtime"}},
+			{NamespacePatterns: []string{"^prod-"}, Disabled: true},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		prefixes  []string
+		expected  map[string]string // name -> kind/role
+	}{
+		{
+			name:      "No matching override uses global entries, structured entry wins",
+			namespace: "team-a",
+			prefixes:  []string{"COMPANY-K8S"},
+			expected:  map[string]string{"deploy": "ClusterRole/edit", "runtime": "Role/app-reader"},
+		},
+		{
+			name:      "Explicit namespace disables ServiceAccounts",
+			namespace: "sandbox",
+			expected:  map[string]string{},
+		},
+		{
+			name:      "Pattern adds and replaces keys, first match wins",
+			namespace: "prod-payments",
+			expected:  map[string]string{"deploy": "ClusterRole/admin", "runtime": "Role/app-reader", "backup": "ClusterRole/view"},
+		},
+		{
+			name:      "Prefix excludes key",
+			namespace: "team-b",
+			prefixes:  []string{"COMPANY-K8S", "MT-K8S-DEV"},
+			expected:  map[string]string{"deploy": "ClusterRole/edit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ResolveServiceAccountConfigs(spec, tt.namespace, tt.prefixes)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("ResolveServiceAccountConfigs() = %v, want %v", result, tt.expected)
+			}
+			for name, want := range tt.expected {
+				saConfig := result[name]
+				if len(saConfig.Roles) != 1 {
+					t.Fatalf("Expected one role for %q, got %v", name, saConfig.Roles)
+				}
+				got := serviceAccountRoleKind(saConfig.Roles[0]) + "/" + saConfig.Roles[0].Name
+				if got != want {
+					t.Errorf("ResolveServiceAccountConfigs()[%q] = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+
+	if len(spec.ServiceAccountMapping) != 2 || spec.ServiceAccountMapping["deploy"] != "edit" {
+		t.Errorf("Global mapping must not be modified, got %v", spec.ServiceAccountMapping)
+	}
+}
+
+// TestProcessServiceAccountConfigs_StructuredEntry verifies Role references,
+// multiple roles per ServiceAccount and the optional ServiceAccount fields
+func TestProcessServiceAccountConfigs_StructuredEntry(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	ns := "team-a"
+	automount := false
+	configs := map[string]permissionv1.ServiceAccountConfig{
+		"deploy": {
+			Roles: []permissionv1.ServiceAccountRoleRef{
+				{Name: "edit"},
+				{Kind: "Role", Name: "secret-reader"},
+				{Kind: "ClusterRole", Name: "view"},
+			},
+			Annotations:                  map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123:role/ci"},
+			Labels:                       map[string]string{"team": "a", "app.kubernetes.io/name": "spoofed"},
+			AutomountServiceAccountToken: &automount,
+			ImagePullSecrets:             []string{"registry-creds"},
+		},
+	}
+
+	processed, err := ProcessServiceAccountConfigs(context.Background(), k8sClient, ns, configs, "", "my-binder", "my-namespace", nil)
+	if err != nil {
+		t.Fatalf("ProcessServiceAccountConfigs returned error: %v", err)
+	}
+	if len(processed) != 1 {
+		t.Fatalf("Expected one processed ServiceAccount, got %v", processed)
+	}
+
+	var sa corev1.ServiceAccount
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-a-sa-deploy", Namespace: ns}, &sa); err != nil {
+		t.Fatalf("ServiceAccount not created: %v", err)
+	}
+	if sa.Annotations["eks.amazonaws.com/role-arn"] != "arn:aws:iam::123:role/ci" {
+		t.Errorf("Expected IRSA annotation, got %v", sa.Annotations)
+	}
+	if sa.Labels["team"] != "a" || sa.Labels["app.kubernetes.io/name"] != "my-binder" {
+		t.Errorf("Expected custom label without overriding managed labels, got %v", sa.Labels)
+	}
+	if sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken {
+		t.Errorf("Expected automountServiceAccountToken=false, got %v", sa.AutomountServiceAccountToken)
+	}
+	if len(sa.ImagePullSecrets) != 1 || sa.ImagePullSecrets[0].Name != "registry-creds" {
+		t.Errorf("Expected imagePullSecrets [registry-creds], got %v", sa.ImagePullSecrets)
+	}
+	if sa.Annotations[AnnotationRole] != "edit,secret-reader,view" {
+		t.Errorf("Expected role annotation listing all roles, got %q", sa.Annotations[AnnotationRole])
+	}
+
+	entry := configs["deploy"]
+	entry.Name = "deploy"
+	names := ServiceAccountRoleBindingNames(ns, entry)
+	expectedBindings := map[string]rbacv1.RoleRef{
+		names[0]: {APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+		names[1]: {APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "secret-reader"},
+		names[2]: {APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+	}
+	if len(expectedBindings) != 3 {
+		t.Fatalf("RoleBinding names %v are not unique", names)
+	}
+	for name, roleRef := range expectedBindings {
+		var rb rbacv1.RoleBinding
+		if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: ns}, &rb); err != nil {
+			t.Fatalf("RoleBinding %s not created: %v", name, err)
+		}
+		if rb.RoleRef != roleRef {
+			t.Errorf("RoleBinding %s RoleRef = %+v, want %+v", name, rb.RoleRef, roleRef)
+		}
+	}
+
+	// Platform-injected pull secrets are preserved when the entry is reapplied
+	sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: "default-dockercfg-abc"})
+	if err := k8sClient.Update(context.Background(), &sa); err != nil {
+		t.Fatalf("Failed to update ServiceAccount: %v", err)
+	}
+	if _, err := ProcessServiceAccountConfigs(context.Background(), k8sClient, ns, configs, "", "my-binder", "my-namespace", nil); err != nil {
+		t.Fatalf("ProcessServiceAccountConfigs returned error: %v", err)
+	}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "team-a-sa-deploy", Namespace: ns}, &sa); err != nil {
+		t.Fatalf("Failed to get ServiceAccount: %v", err)
+	}
+	if len(sa.ImagePullSecrets) != 2 {
+		t.Errorf("Expected platform pull secret to be preserved, got %v", sa.ImagePullSecrets)
+	}
+
+	// Dropping the additional roles binds the remaining one by the single-role
+	// name and prunes the other RoleBindings
+	saConfig := configs["deploy"]
+	saConfig.Roles = saConfig.Roles[:1]
+	if _, err := ProcessServiceAccountConfigs(context.Background(), k8sClient, ns,
+		map[string]permissionv1.ServiceAccountConfig{"deploy": saConfig}, "", "my-binder", "my-namespace", nil); err != nil {
+		t.Fatalf("ProcessServiceAccountConfigs returned error: %v", err)
+	}
+	result, err := PruneServiceAccounts(context.Background(), k8sClient,
+		map[string]map[string]permissionv1.ServiceAccountConfig{ns: {"deploy": saConfig}}, nil,
+		"", ServiceAccountPruneModeDelete, "my-binder", "my-namespace", nil)
+	if err != nil {
+		t.Fatalf("PruneServiceAccounts returned error: %v", err)
+	}
+	if len(result.Deleted) != 0 {
+		t.Errorf("ServiceAccount must stay while it is desired, got %v", result.Deleted)
+	}
+	var rbList rbacv1.RoleBindingList
+	if err := k8sClient.List(context.Background(), &rbList, client.InNamespace(ns)); err != nil {
+		t.Fatalf("Failed to list RoleBindings: %v", err)
+	}
+	if len(rbList.Items) != 1 || rbList.Items[0].Name != "sa-team-a-deploy" {
+		t.Errorf("Expected only sa-team-a-deploy to remain, got %d RoleBindings", len(rbList.Items))
+	}
+}
+
+// TestServiceAccountRoleBindingEvents verifies the RoleBinding Events of the
+// ServiceAccount code: create, make-before-break replace, orphan, adoption and
+// delete. A deletion held back by deletionProtection is not reported yet.
+func TestServiceAccountRoleBindingEvents(t *testing.T) {
+	const ns = "team-a"
+	k8sClient := newSAFakeClient()
+	var events []string
+	recordEvent := func(roleBinding *rbacv1.RoleBinding, reason, messageFmt string, args ...interface{}) {
+		events = append(events, reason+" "+roleBinding.Namespace+"/"+roleBinding.Name)
+	}
+	expectEvents := func(step string, want ...string) {
+		t.Helper()
+		if strings.Join(events, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: Events = %v, want %v", step, events, want)
+		}
+		events = nil
+	}
+	process := func(role string) {
+		t.Helper()
+		if _, err := ProcessServiceAccountConfigs(context.Background(), k8sClient, ns,
+			ServiceAccountConfigsFromMapping(map[string]string{"deploy": role}), "", "my-binder", "my-namespace", recordEvent); err != nil {
+			t.Fatalf("ProcessServiceAccountConfigs returned error: %v", err)
+		}
+	}
+	prune := func(c client.Client, pruneMode string) {
+		t.Helper()
+		if _, err := PruneServiceAccounts(context.Background(), c, nil, nil,
+			"", pruneMode, "my-binder", "my-namespace", recordEvent); err != nil {
+			t.Fatalf("PruneServiceAccounts returned error: %v", err)
+		}
+	}
+
+	process("edit")
+	expectEvents("create", "RoleBindingCreated team-a/sa-team-a-deploy")
+	process("edit")
+	expectEvents("unchanged")
+	process("view")
+	expectEvents("replace", "RoleBindingUpdated team-a/sa-team-a-deploy")
+
+	prune(k8sClient, ServiceAccountPruneModeOrphan)
+	expectEvents("orphan", "RoleBindingOrphaned team-a/sa-team-a-deploy")
+	prune(k8sClient, ServiceAccountPruneModeOrphan)
+	expectEvents("already orphaned")
+	process("view")
+	expectEvents("adopt", "RoleBindingUpdated team-a/sa-team-a-deploy")
+
+	prune(newDeferredDeletionsClient(k8sClient), ServiceAccountPruneModeDelete)
+	expectEvents("held back delete")
+	prune(k8sClient, ServiceAccountPruneModeDelete)
+	expectEvents("delete", "RoleBindingDeleted team-a/sa-team-a-deploy")
+}
+
+// TestNewServiceAccountResolver verifies that the namespacePatterns are compiled
+// once and invalid ones are reported instead of silently selecting nothing
+func TestNewServiceAccountResolver(t *testing.T) {
+	spec := &permissionv1.PermissionBinderSpec{
+		ServiceAccountMapping: map[string]string{"deploy": "edit"},
+		ServiceAccountOverrides: []permissionv1.ServiceAccountOverride{
+			{NamespacePatterns: []string{"[invalid", "^prod-"}, Disabled: true},
+			{NamespacePatterns: []string{"(unclosed"}, Exclude: []string{"deploy"}},
+		},
+	}
+	resolver, errs := newServiceAccountResolver(spec)
+	if len(errs) != 2 ||
+		!strings.Contains(errs[0].Error(), "serviceAccountOverrides[0].namespacePatterns[0]") ||
+		!strings.Contains(errs[1].Error(), "serviceAccountOverrides[1].namespacePatterns[0]") {
+		t.Fatalf("newServiceAccountResolver() errors = %v, want one per invalid pattern", errs)
+	}
+	if got := resolver.resolve("prod-payments", nil); len(got) != 0 {
+		t.Errorf("resolve(prod-payments) = %v, want the valid pattern of the override applied", got)
+	}
+	if got := resolver.resolve("team-a", nil); len(got) != 1 {
+		t.Errorf("resolve(team-a) = %v, want the global entries", got)
+	}
+}
+
+// TestServiceAccountRoleBindingNames verifies that the RoleBinding names of
+// several roles are unique, valid and independent of the order of the roles
+func TestServiceAccountRoleBindingNames(t *testing.T) {
+	single := permissionv1.ServiceAccountConfig{Name: "deploy-view", Roles: []permissionv1.ServiceAccountRoleRef{{Name: "edit"}}}
+	if got := ServiceAccountRoleBindingNames("team-a", single); len(got) != 1 || got[0] != "sa-team-a-deploy-view" {
+		t.Fatalf("single role names = %v, want the legacy name", got)
+	}
+
+	multi := permissionv1.ServiceAccountConfig{Name: "deploy", Roles: []permissionv1.ServiceAccountRoleRef{
+		{Name: "edit"},
+		{Name: "view"},
+		{Kind: "Role", Name: "view"},
+		{Name: "system:aggregate-to-view"},
+	}}
+	names := ServiceAccountRoleBindingNames("team-a", multi)
+	seen := map[string]bool{"sa-team-a-deploy-view": true, "sa-team-a-deploy": true}
+	for _, name := range names {
+		if seen[name] {
+			t.Errorf("RoleBinding name %s collides", name)
+		}
+		seen[name] = true
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			t.Errorf("RoleBinding name %s is invalid: %v", name, errs)
+		}
+	}
+
+	reordered := multi
+	reordered.Roles = []permissionv1.ServiceAccountRoleRef{multi.Roles[3], multi.Roles[2], multi.Roles[1], multi.Roles[0]}
+	again := ServiceAccountRoleBindingNames("team-a", reordered)
+	for i := range names {
+		if again[len(names)-1-i] != names[i] {
+			t.Errorf("reordered names = %v, want %v reversed", again, names)
+		}
+	}
+}
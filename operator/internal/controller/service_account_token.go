@@ -0,0 +1,421 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// ServiceAccountTokenMode values (serviceAccounts[].token.mode).
+	ServiceAccountTokenModeLongLived    = "LongLived"
+	ServiceAccountTokenModeTokenRequest = "TokenRequest"
+
+	// Annotation keys recorded on TokenRequest token Secrets. They carry the
+	// requested parameters so periodic refreshes don't need the CR spec.
+	AnnotationTokenIssuedAt          = "permission-binder.io/token-issued-at"
+	AnnotationTokenExpiresAt         = "permission-binder.io/token-expires-at"
+	AnnotationTokenAudiences         = "permission-binder.io/token-audiences"
+	AnnotationTokenExpirationSeconds = "permission-binder.io/token-expiration-seconds"
+
+	// defaultTokenExpirationSeconds is the TokenRequest lifetime when unset
+	defaultTokenExpirationSeconds int64 = 3600
+
+	// tokenRefreshFraction is the part of the token lifetime after which a
+	// TokenRequest token is refreshed
+	tokenRefreshFraction = 0.8
+)
+
+// TokenSecretConflictError is returned by EnsureServiceAccountToken for an existing
+// Secret it must not take over: one that does not carry the ownership annotations
+// of the PermissionBinder, e.g. an application credential of the tenant or a
+// Secret of another PermissionBinder
+type TokenSecretConflictError struct {
+	Secret *corev1.Secret
+}
+
+func (e *TokenSecretConflictError) Error() string {
+	if owner := claimedBy(e.Secret.Annotations); owner != "" {
+		return fmt.Sprintf("token Secret %s/%s is claimed by PermissionBinder %s", e.Secret.Namespace, e.Secret.Name, owner)
+	}
+	return fmt.Sprintf("token Secret %s/%s exists and is not managed by a PermissionBinder", e.Secret.Namespace, e.Secret.Name)
+}
+
+// ServiceAccountTokenSecretName returns the token Secret name for a ServiceAccount
+// Default: {serviceAccountName}-token
+func ServiceAccountTokenSecretName(fullSAName string, tokenSpec *permissionv1.ServiceAccountTokenSpec) string {
+	if tokenSpec.SecretName != "" {
+		return tokenSpec.SecretName
+	}
+	return fullSAName + "-token"
+}
+
+// serviceAccountTokenMode returns the token mode, defaulting to LongLived
+func serviceAccountTokenMode(tokenSpec *permissionv1.ServiceAccountTokenSpec) string {
+	if tokenSpec.Mode == "" {
+		return ServiceAccountTokenModeLongLived
+	}
+	return tokenSpec.Mode
+}
+
+// serviceAccountTokenExpiration returns the requested lifetime, defaulting to one hour
+func serviceAccountTokenExpiration(tokenSpec *permissionv1.ServiceAccountTokenSpec) int64 {
+	if tokenSpec.ExpirationSeconds <= 0 {
+		return defaultTokenExpirationSeconds
+	}
+	return tokenSpec.ExpirationSeconds
+}
+
+// EnsureServiceAccountToken creates the token Secret of a ServiceAccount or
+// refreshes it when due and returns its status entry.
+//
+// LongLived tokens use a kubernetes.io/service-account-token Secret that
+// Kubernetes populates and never expires. TokenRequest tokens are requested
+// via the serviceaccounts/token subresource and written to an Opaque Secret
+// (key "token"); they are refreshed after 80% of their lifetime or when the
+// audiences/lifetime change. The Secret is owned by the ServiceAccount
+// (ownerReference), so it is garbage collected with it. An existing Secret is
+// only updated, replaced or adopted when it carries the ownership annotations of
+// this PermissionBinder; any other Secret of that name is left alone and a
+// *TokenSecretConflictError is returned. A LongLived Secret must also belong to
+// the ServiceAccount.
+func EnsureServiceAccountToken(
+	ctx context.Context,
+	k8sClient client.Client,
+	namespace string,
+	fullSAName string,
+	tokenSpec *permissionv1.ServiceAccountTokenSpec,
+	ownerName string,
+	ownerNamespace string,
+	now time.Time,
+) (permissionv1.ServiceAccountTokenStatus, error) {
+	logger := log.FromContext(ctx)
+	mode := serviceAccountTokenMode(tokenSpec)
+	secretName := ServiceAccountTokenSecretName(fullSAName, tokenSpec)
+	tokenStatus := permissionv1.ServiceAccountTokenStatus{
+		Namespace:      namespace,
+		ServiceAccount: fullSAName,
+		SecretName:     secretName,
+		Mode:           mode,
+	}
+
+	sa := &corev1.ServiceAccount{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: fullSAName, Namespace: namespace}, sa); err != nil {
+		return tokenStatus, fmt.Errorf("failed to get ServiceAccount %s/%s: %w", namespace, fullSAName, err)
+	}
+
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return tokenStatus, fmt.Errorf("failed to get token Secret %s/%s: %w", namespace, secretName, err)
+	}
+	exists := err == nil
+
+	// secretName is configurable - a Secret of the tenant (or of another
+	// PermissionBinder) with that name is never deleted, overwritten or adopted
+	if exists && !isOwnedBy(secret.Annotations, ownerName, ownerNamespace) {
+		ownershipConflictsTotal.WithLabelValues("serviceaccount_token_secret").Inc()
+		logger.Info("Refusing to take over token Secret not owned by this PermissionBinder",
+			"secret", secretName,
+			"namespace", namespace,
+			"claimedBy", secret.Annotations[AnnotationPermissionBinder],
+			"claimedByNamespace", secret.Annotations[AnnotationPermissionBinderNamespace],
+			"reconciledBy", ownerName,
+			"reconciledByNamespace", ownerNamespace)
+		return tokenStatus, &TokenSecretConflictError{Secret: secret}
+	}
+
+	// Secret type is immutable - switching modes needs a new Secret
+	wantType := corev1.SecretTypeOpaque
+	if mode == ServiceAccountTokenModeLongLived {
+		wantType = corev1.SecretTypeServiceAccountToken
+	}
+	if exists && secret.Type != wantType {
+		logger.Info("Token Secret mode changed, recreating",
+			"secret", secretName,
+			"namespace", namespace,
+			"oldType", secret.Type,
+			"newType", wantType)
+		if err := k8sClient.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+			return tokenStatus, fmt.Errorf("failed to delete token Secret %s/%s: %w", namespace, secretName, err)
+		}
+		exists = false
+		secret = &corev1.Secret{}
+	}
+
+	if mode == ServiceAccountTokenModeLongLived {
+		if exists {
+			if boundTo := secret.Annotations[corev1.ServiceAccountNameKey]; boundTo != fullSAName {
+				return tokenStatus, fmt.Errorf("token Secret %s/%s holds the token of ServiceAccount %q, not %s",
+					namespace, secretName, boundTo, fullSAName)
+			}
+			if stampTokenSecretOwner(secret, ownerName, ownerNamespace) {
+				if err := k8sClient.Update(ctx, secret); err != nil {
+					return tokenStatus, fmt.Errorf("failed to re-stamp token Secret %s/%s: %w", namespace, secretName, err)
+				}
+			}
+			issued := secret.CreationTimestamp
+			tokenStatus.LastRotationTime = &issued
+			return tokenStatus, nil
+		}
+
+		newSecret := newServiceAccountTokenSecret(namespace, secretName, sa, wantType, ownerName, ownerNamespace)
+		newSecret.Annotations[corev1.ServiceAccountNameKey] = fullSAName
+		if err := k8sClient.Create(ctx, newSecret); err != nil {
+			serviceAccountTokenRotations.WithLabelValues(namespace, mode, "error").Inc()
+			return tokenStatus, fmt.Errorf("failed to create token Secret %s/%s: %w", namespace, secretName, err)
+		}
+		serviceAccountTokenRotations.WithLabelValues(namespace, mode, "success").Inc()
+		logger.Info("Created long-lived token Secret for ServiceAccount",
+			"secret", secretName,
+			"serviceAccount", fullSAName,
+			"namespace", namespace)
+		issued := newSecret.CreationTimestamp
+		if issued.IsZero() {
+			issued = metav1.NewTime(now)
+		}
+		tokenStatus.LastRotationTime = &issued
+		return tokenStatus, nil
+	}
+
+	// TokenRequest mode
+	audiences := strings.Join(tokenSpec.Audiences, ",")
+	expirationSeconds := serviceAccountTokenExpiration(tokenSpec)
+	if exists && !tokenRefreshDue(secret, audiences, expirationSeconds, now) {
+		fillTokenRequestStatus(&tokenStatus, secret)
+		return tokenStatus, nil
+	}
+
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         tokenSpec.Audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	if err := k8sClient.SubResource("token").Create(ctx, sa, tokenRequest); err != nil {
+		serviceAccountTokenRotations.WithLabelValues(namespace, mode, "error").Inc()
+		return tokenStatus, fmt.Errorf("failed to request token for ServiceAccount %s/%s: %w", namespace, fullSAName, err)
+	}
+
+	expiresAt := tokenRequest.Status.ExpirationTimestamp.Time
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(time.Duration(expirationSeconds) * time.Second)
+	}
+
+	if !exists {
+		secret = newServiceAccountTokenSecret(namespace, secretName, sa, wantType, ownerName, ownerNamespace)
+	} else {
+		stampTokenSecretOwner(secret, ownerName, ownerNamespace)
+	}
+	secret.Annotations[AnnotationTokenIssuedAt] = now.UTC().Format(time.RFC3339)
+	secret.Annotations[AnnotationTokenExpiresAt] = expiresAt.UTC().Format(time.RFC3339)
+	secret.Annotations[AnnotationTokenAudiences] = audiences
+	secret.Annotations[AnnotationTokenExpirationSeconds] = strconv.FormatInt(expirationSeconds, 10)
+	secret.Data = map[string][]byte{
+		"token":     []byte(tokenRequest.Status.Token),
+		"namespace": []byte(namespace),
+	}
+
+	if exists {
+		err = k8sClient.Update(ctx, secret)
+	} else {
+		err = k8sClient.Create(ctx, secret)
+	}
+	if err != nil {
+		serviceAccountTokenRotations.WithLabelValues(namespace, mode, "error").Inc()
+		return tokenStatus, fmt.Errorf("failed to write token Secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	serviceAccountTokenRotations.WithLabelValues(namespace, mode, "success").Inc()
+	logger.Info("Rotated ServiceAccount token",
+		"secret", secretName,
+		"serviceAccount", fullSAName,
+		"namespace", namespace,
+		"expiresAt", expiresAt.UTC().Format(time.RFC3339))
+
+	fillTokenRequestStatus(&tokenStatus, secret)
+	return tokenStatus, nil
+}
+
+// RefreshServiceAccountTokens refreshes the TokenRequest tokens in tokens whose
+// NextRotationTime has passed, using the parameters recorded on their Secrets.
+// It returns the updated status entries; entries that fail to refresh are kept
+// unchanged so they are retried on the next reconciliation.
+func RefreshServiceAccountTokens(
+	ctx context.Context,
+	k8sClient client.Client,
+	tokens []permissionv1.ServiceAccountTokenStatus,
+	ownerName string,
+	ownerNamespace string,
+	now time.Time,
+) []permissionv1.ServiceAccountTokenStatus {
+	logger := log.FromContext(ctx)
+	refreshed := make([]permissionv1.ServiceAccountTokenStatus, 0, len(tokens))
+
+	for _, tokenStatus := range tokens {
+		if tokenStatus.Mode != ServiceAccountTokenModeTokenRequest ||
+			tokenStatus.NextRotationTime == nil || now.Before(tokenStatus.NextRotationTime.Time) {
+			refreshed = append(refreshed, tokenStatus)
+			continue
+		}
+
+		tokenSpec := &permissionv1.ServiceAccountTokenSpec{
+			Mode:       ServiceAccountTokenModeTokenRequest,
+			SecretName: tokenStatus.SecretName,
+		}
+		secret := &corev1.Secret{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: tokenStatus.SecretName, Namespace: tokenStatus.Namespace}, secret); err == nil {
+			if audiences := secret.Annotations[AnnotationTokenAudiences]; audiences != "" {
+				tokenSpec.Audiences = strings.Split(audiences, ",")
+			}
+			tokenSpec.ExpirationSeconds, _ = strconv.ParseInt(secret.Annotations[AnnotationTokenExpirationSeconds], 10, 64)
+		}
+
+		updated, err := EnsureServiceAccountToken(ctx, k8sClient, tokenStatus.Namespace, tokenStatus.ServiceAccount,
+			tokenSpec, ownerName, ownerNamespace, now)
+		if err != nil {
+			logger.Error(err, "⚠️  ServiceAccount token refresh failed (non-fatal)",
+				"namespace", tokenStatus.Namespace,
+				"serviceAccount", tokenStatus.ServiceAccount)
+			refreshed = append(refreshed, tokenStatus)
+			continue
+		}
+		refreshed = append(refreshed, updated)
+	}
+
+	return refreshed
+}
+
+// nextServiceAccountTokenRefresh returns the delay until the earliest
+// NextRotationTime in tokens, or 0 when no token needs refreshing
+func nextServiceAccountTokenRefresh(tokens []permissionv1.ServiceAccountTokenStatus, now time.Time) time.Duration {
+	var next time.Duration
+	for _, tokenStatus := range tokens {
+		if tokenStatus.NextRotationTime == nil {
+			continue
+		}
+		delay := tokenStatus.NextRotationTime.Sub(now)
+		if delay < time.Second {
+			// Overdue (e.g. failed refresh) - retry soon without hot-looping
+			delay = 30 * time.Second
+		}
+		if next == 0 || delay < next {
+			next = delay
+		}
+	}
+	return next
+}
+
+// newServiceAccountTokenSecret builds a token Secret owned by the ServiceAccount
+func newServiceAccountTokenSecret(
+	namespace, secretName string,
+	sa *corev1.ServiceAccount,
+	secretType corev1.SecretType,
+	ownerName, ownerNamespace string,
+) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": ManagedByValue,
+				"app.kubernetes.io/component":  "service-account-token",
+				"app.kubernetes.io/name":       ownerName,
+			},
+			Annotations: map[string]string{
+				AnnotationCreatedBy:                 ManagedByValue,
+				AnnotationServiceAccount:            sa.Name,
+				AnnotationPermissionBinder:          ownerName,
+				AnnotationPermissionBinderNamespace: ownerNamespace,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "v1",
+					Kind:       "ServiceAccount",
+					Name:       sa.Name,
+					UID:        sa.UID,
+				},
+			},
+		},
+		Type: secretType,
+	}
+}
+
+// stampTokenSecretOwner stamps the ownership annotations on a token Secret of the
+// PermissionBinder and lifts an orphan mark. It reports whether anything changed.
+func stampTokenSecretOwner(secret *corev1.Secret, ownerName, ownerNamespace string) bool {
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	changed := false
+	if secret.Annotations[AnnotationOrphanedAt] != "" {
+		// ADOPTION LOGIC: orphaned token Secret desired again
+		delete(secret.Annotations, AnnotationOrphanedAt)
+		delete(secret.Annotations, AnnotationOrphanedBy)
+		adoptionEventsTotal.Inc()
+		changed = true
+	}
+	if secret.Annotations[AnnotationPermissionBinder] != ownerName ||
+		secret.Annotations[AnnotationPermissionBinderNamespace] != ownerNamespace {
+		secret.Annotations[AnnotationPermissionBinder] = ownerName
+		secret.Annotations[AnnotationPermissionBinderNamespace] = ownerNamespace
+		changed = true
+	}
+	return changed
+}
+
+// tokenRefreshDue reports whether a TokenRequest Secret needs a new token:
+// missing token, changed parameters, or 80% of the lifetime elapsed
+func tokenRefreshDue(secret *corev1.Secret, audiences string, expirationSeconds int64, now time.Time) bool {
+	if len(secret.Data["token"]) == 0 {
+		return true
+	}
+	if secret.Annotations[AnnotationTokenAudiences] != audiences ||
+		secret.Annotations[AnnotationTokenExpirationSeconds] != strconv.FormatInt(expirationSeconds, 10) {
+		return true
+	}
+	next, ok := tokenNextRotation(secret)
+	return !ok || !now.Before(next)
+}
+
+// tokenNextRotation returns the refresh time recorded by the Secret annotations
+func tokenNextRotation(secret *corev1.Secret) (time.Time, bool) {
+	issuedAt, err := time.Parse(time.RFC3339, secret.Annotations[AnnotationTokenIssuedAt])
+	if err != nil {
+		return time.Time{}, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[AnnotationTokenExpiresAt])
+	if err != nil {
+		return time.Time{}, false
+	}
+	lifetime := expiresAt.Sub(issuedAt)
+	return issuedAt.Add(time.Duration(float64(lifetime) * tokenRefreshFraction)), true
+}
+
+// fillTokenRequestStatus copies the rotation times of a TokenRequest Secret into its status entry
+func fillTokenRequestStatus(tokenStatus *permissionv1.ServiceAccountTokenStatus, secret *corev1.Secret) {
+	if issuedAt, err := time.Parse(time.RFC3339, secret.Annotations[AnnotationTokenIssuedAt]); err == nil {
+		t := metav1.NewTime(issuedAt)
+		tokenStatus.LastRotationTime = &t
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[AnnotationTokenExpiresAt]); err == nil {
+		t := metav1.NewTime(expiresAt)
+		tokenStatus.ExpirationTime = &t
+	}
+	if next, ok := tokenNextRotation(secret); ok {
+		t := metav1.NewTime(next)
+		tokenStatus.NextRotationTime = &t
+	}
+}
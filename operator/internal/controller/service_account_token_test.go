@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+// newTokenTestClient returns a fake client with a ServiceAccount and a
+// serviceaccounts/token handler issuing numbered tokens
+func newTokenTestClient(t *testing.T, issued *int) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a-sa-deploy", Namespace: "team-a", UID: "sa-uid"},
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(sa).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceCreate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, subResourceObj client.Object, opts ...client.SubResourceCreateOption) error {
+				tokenRequest, ok := subResourceObj.(*authenticationv1.TokenRequest)
+				if subResource != "token" || !ok {
+					t.Fatalf("unexpected subresource create %q", subResource)
+				}
+				*issued++
+				tokenRequest.Status.Token = fmt.Sprintf("token-%d", *issued)
+				return nil
+			},
+		}).
+		Build()
+}
+
+func TestEnsureServiceAccountToken_TokenRequestRotation(t *testing.T) {
+	issued := 0
+	k8sClient := newTokenTestClient(t, &issued)
+	ctx := context.Background()
+	tokenSpec := &permissionv1.ServiceAccountTokenSpec{
+		Mode:              ServiceAccountTokenModeTokenRequest,
+		Audiences:         []string{"ci.example.com"},
+		ExpirationSeconds: 3600,
+	}
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := testutil.ToFloat64(serviceAccountTokenRotations.WithLabelValues("team-a", ServiceAccountTokenModeTokenRequest, "success"))
+
+	tokenStatus, err := EnsureServiceAccountToken(ctx, k8sClient, "team-a", "team-a-sa-deploy", tokenSpec, "my-binder", "my-namespace", start)
+	if err != nil {
+		t.Fatalf("EnsureServiceAccountToken returned error: %v", err)
+	}
+	if tokenStatus.SecretName != "team-a-sa-deploy-token" || tokenStatus.NextRotationTime == nil {
+		t.Fatalf("Unexpected token status: %+v", tokenStatus)
+	}
+	if want := start.Add(48 * time.Minute); !tokenStatus.NextRotationTime.Time.Equal(want) {
+		t.Errorf("NextRotationTime = %v, want %v (80%% of lifetime)", tokenStatus.NextRotationTime.Time, want)
+	}
+
+	var secret corev1.Secret
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: "team-a-sa-deploy-token", Namespace: "team-a"}, &secret); err != nil {
+		t.Fatalf("Token Secret not created: %v", err)
+	}
+	if string(secret.Data["token"]) != "token-1" {
+		t.Errorf("Expected token-1 in Secret, got %q", secret.Data["token"])
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != "sa-uid" {
+		t.Errorf("Expected Secret to be owned by the ServiceAccount, got %v", secret.OwnerReferences)
+	}
+
+	// Not due yet - no new token
+	if _, err := EnsureServiceAccountToken(ctx, k8sClient, "team-a", "team-a-sa-deploy", tokenSpec, "my-binder", "my-namespace", start.Add(30*time.Minute)); err != nil {
+		t.Fatalf("EnsureServiceAccountToken returned error: %v", err)
+	}
+	if issued != 1 {
+		t.Errorf("Token must not be refreshed before 80%% of its lifetime, issued %d", issued)
+	}
+
+	// Due - RefreshServiceAccountTokens uses the parameters recorded on the Secret
+	refreshed := RefreshServiceAccountTokens(ctx, k8sClient, []permissionv1.ServiceAccountTokenStatus{tokenStatus},
+		"my-binder", "my-namespace", start.Add(50*time.Minute))
+	if issued != 2 {
+		t.Fatalf("Expected token to be refreshed, issued %d", issued)
+	}
+	if len(refreshed) != 1 || !refreshed[0].LastRotationTime.Time.Equal(start.Add(50*time.Minute)) {
+		t.Errorf("Expected LastRotationTime to move, got %+v", refreshed)
+	}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: "team-a-sa-deploy-token", Namespace: "team-a"}, &secret); err != nil {
+		t.Fatalf("Failed to get token Secret: %v", err)
+	}
+	if string(secret.Data["token"]) != "token-2" || secret.Annotations[AnnotationTokenAudiences] != "ci.example.com" {
+		t.Errorf("Expected refreshed token with preserved audiences, got %q / %q",
+			secret.Data["token"], secret.Annotations[AnnotationTokenAudiences])
+	}
+
+	after := testutil.ToFloat64(serviceAccountTokenRotations.WithLabelValues("team-a", ServiceAccountTokenModeTokenRequest, "success"))
+	if after-before != 2 {
+		t.Errorf("Expected 2 successful rotations, got %v", after-before)
+	}
+	if next := nextServiceAccountTokenRefresh(refreshed, start.Add(50*time.Minute)); next != 48*time.Minute {
+		t.Errorf("Expected next refresh in 48m, got %v", next)
+	}
+}
+
+func TestEnsureServiceAccountToken_LongLivedAndForeignSecret(t *testing.T) {
+	issued := 0
+	k8sClient := newTokenTestClient(t, &issued)
+	ctx := context.Background()
+
+	tokenStatus, err := EnsureServiceAccountToken(ctx, k8sClient, "team-a", "team-a-sa-deploy",
+		&permissionv1.ServiceAccountTokenSpec{SecretName: "ci-token"}, "my-binder", "my-namespace", time.Now())
+	if err != nil {
+		t.Fatalf("EnsureServiceAccountToken returned error: %v", err)
+	}
+	if tokenStatus.Mode != ServiceAccountTokenModeLongLived || tokenStatus.NextRotationTime != nil {
+		t.Errorf("Unexpected long-lived token status: %+v", tokenStatus)
+	}
+	var secret corev1.Secret
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: "ci-token", Namespace: "team-a"}, &secret); err != nil {
+		t.Fatalf("Token Secret not created: %v", err)
+	}
+	if secret.Type != corev1.SecretTypeServiceAccountToken || secret.Annotations[corev1.ServiceAccountNameKey] != "team-a-sa-deploy" {
+		t.Errorf("Expected service-account-token Secret for team-a-sa-deploy, got type %q annotations %v", secret.Type, secret.Annotations)
+	}
+	if issued != 0 {
+		t.Errorf("LongLived mode must not use the TokenRequest API")
+	}
+
+	// A token Secret claimed by another PermissionBinder is left alone
+	_, err = EnsureServiceAccountToken(ctx, k8sClient, "team-a", "team-a-sa-deploy",
+		&permissionv1.ServiceAccountTokenSpec{SecretName: "ci-token", Mode: ServiceAccountTokenModeTokenRequest},
+		"other-binder", "other-namespace", time.Now())
+	if err == nil {
+		t.Fatal("Expected an error for a Secret claimed by another PermissionBinder")
+	}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: "ci-token", Namespace: "team-a"}, &secret); err != nil {
+		t.Fatalf("Foreign Secret must not be deleted: %v", err)
+	}
+	if secret.Type != corev1.SecretTypeServiceAccountToken {
+		t.Errorf("Foreign Secret must not be replaced, got type %q", secret.Type)
+	}
+}
+
+func TestEnsureServiceAccountToken_UnmanagedSecret(t *testing.T) {
+	issued := 0
+	k8sClient := newTokenTestClient(t, &issued)
+	ctx := context.Background()
+
+	// An application credential of the tenant that happens to use the configured name
+	tenantSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci-token", Namespace: "team-a"},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+	if err := k8sClient.Create(ctx, tenantSecret); err != nil {
+		t.Fatalf("Failed to create tenant Secret: %v", err)
+	}
+
+	for _, mode := range []string{ServiceAccountTokenModeLongLived, ServiceAccountTokenModeTokenRequest} {
+		_, err := EnsureServiceAccountToken(ctx, k8sClient, "team-a", "team-a-sa-deploy",
+			&permissionv1.ServiceAccountTokenSpec{SecretName: "ci-token", Mode: mode}, "my-binder", "my-namespace", time.Now())
+		var conflict *TokenSecretConflictError
+		if !errors.As(err, &conflict) || conflict.Secret.Name != "ci-token" {
+			t.Fatalf("%s: expected a TokenSecretConflictError, got %v", mode, err)
+		}
+
+		var secret corev1.Secret
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: "ci-token", Namespace: "team-a"}, &secret); err != nil {
+			t.Fatalf("%s: tenant Secret must not be deleted: %v", mode, err)
+		}
+		if secret.Type != corev1.SecretTypeOpaque || string(secret.Data["password"]) != "hunter2" || len(secret.Annotations) != 0 {
+			t.Errorf("%s: tenant Secret must not be modified, got type %q data %v annotations %v",
+				mode, secret.Type, secret.Data, secret.Annotations)
+		}
+	}
+	if issued != 0 {
+		t.Errorf("No token must be requested for a conflicting Secret, issued %d", issued)
+	}
+}
+
+func TestEnsureServiceAccountToken_LongLivedExistingSecret(t *testing.T) {
+	issued := 0
+	k8sClient := newTokenTestClient(t, &issued)
+	ctx := context.Background()
+	tokenSpec := &permissionv1.ServiceAccountTokenSpec{SecretName: "ci-token"}
+
+	// An orphaned Secret of this PermissionBinder is re-stamped
+	orphaned := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci-token", Namespace: "team-a", Annotations: map[string]string{
+			corev1.ServiceAccountNameKey:        "team-a-sa-deploy",
+			AnnotationPermissionBinder:          "my-binder",
+			AnnotationPermissionBinderNamespace: "my-namespace",
+			AnnotationOrphanedAt:                "2025-01-01T00:00:00Z",
+			AnnotationOrphanedBy:                "permission-binder-operator",
+		}},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	if err := k8sClient.Create(ctx, orphaned); err != nil {
+		t.Fatalf("Failed to create Secret: %v", err)
+	}
+	if _, err := EnsureServiceAccountToken(ctx, k8sClient, "team-a", "team-a-sa-deploy", tokenSpec,
+		"my-binder", "my-namespace", time.Now()); err != nil {
+		t.Fatalf("EnsureServiceAccountToken returned error: %v", err)
+	}
+	var secret corev1.Secret
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: "ci-token", Namespace: "team-a"}, &secret); err != nil {
+		t.Fatalf("Failed to get Secret: %v", err)
+	}
+	if _, marked := secret.Annotations[AnnotationOrphanedAt]; marked {
+		t.Errorf("Expected the orphan mark to be lifted, got %v", secret.Annotations)
+	}
+
+	// A Secret of this PermissionBinder holding the token of another ServiceAccount is rejected
+	secret.Annotations[corev1.ServiceAccountNameKey] = "team-a-sa-other"
+	if err := k8sClient.Update(ctx, &secret); err != nil {
+		t.Fatalf("Failed to update Secret: %v", err)
+	}
+	if _, err := EnsureServiceAccountToken(ctx, k8sClient, "team-a", "team-a-sa-deploy", tokenSpec,
+		"my-binder", "my-namespace", time.Now()); err == nil {
+		t.Fatal("Expected an error for a LongLived Secret of another ServiceAccount")
+	}
+}
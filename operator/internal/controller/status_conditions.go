@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// ReadyCondition is True when the last reconciliation applied everything:
+	// nothing failed, no deletions are blocked and the PermissionBinder is not frozen
+	ReadyCondition = "Ready"
+
+	// DegradedCondition is True while one of the component conditions is False
+	DegradedCondition = "Degraded"
+
+	// Component conditions. LdapSyncedCondition is set by the LDAP controller.
+	WhitelistParsedCondition           = "WhitelistParsed"
+	RBACReconciledCondition            = "RBACReconciled"
+	ServiceAccountsReconciledCondition = "ServiceAccountsReconciled"
+	NetworkPoliciesSyncedCondition     = "NetworkPoliciesSynced"
+	WhitelistSnapshotRecordedCondition = "WhitelistSnapshotRecorded"
+)
+
+// componentConditions are the conditions that make a PermissionBinder Degraded when False
+var componentConditions = []string{
+	WhitelistParsedCondition,
+	RBACReconciledCondition,
+	ServiceAccountsReconciledCondition,
+	LdapSyncedCondition,
+	NetworkPoliciesSyncedCondition,
+	WhitelistSnapshotRecordedCondition,
+}
+
+// whitelistParsedCondition returns the WhitelistParsed condition
+func whitelistParsedCondition(generation int64, found bool, entries, invalid int) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               WhitelistParsedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Parsed",
+		Message:            fmt.Sprintf("%d whitelist entries parsed", entries),
+		ObservedGeneration: generation,
+	}
+	switch {
+	case !found:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "WhitelistMissing"
+		condition.Message = "The whitelist ConfigMap has no whitelist.txt key"
+	case invalid > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidEntries"
+		condition.Message = fmt.Sprintf("%d of %d whitelist entries cannot be parsed", invalid, entries)
+	}
+	return condition
+}
+
+// failureCondition returns a condition that is True with reason "Reconciled", or
+// False with failedReason and the first error when errs is not empty
+func failureCondition(conditionType string, generation int64, errs []error, success, failedReason, failedWhat string) metav1.Condition {
+	if len(errs) == 0 {
+		return metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Reconciled",
+			Message:            success,
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             failedReason,
+		Message:            fmt.Sprintf("%d %s failed, first error: %v", len(errs), failedWhat, errs[0]),
+		ObservedGeneration: generation,
+	}
+}
+
+// setSummaryConditions derives Degraded and Ready from the component, Frozen and
+// Blocked conditions. Called by every writer of these conditions - including the
+// LDAP controller - so that the summary never lags behind.
+func setSummaryConditions(pb *permissionv1.PermissionBinder) {
+	degraded := metav1.Condition{
+		Type:               DegradedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NoFailures",
+		Message:            "All components reconciled",
+		ObservedGeneration: pb.Generation,
+	}
+	var failures []string
+	for _, conditionType := range componentConditions {
+		condition := meta.FindStatusCondition(pb.Status.Conditions, conditionType)
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			continue
+		}
+		if len(failures) == 0 {
+			degraded.Reason = condition.Reason
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+	}
+	if len(failures) > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Message = strings.Join(failures, "; ")
+	}
+	meta.SetStatusCondition(&pb.Status.Conditions, degraded)
+
+	ready := metav1.Condition{
+		Type:               ReadyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Reconciled",
+		Message:            "All changes applied",
+		ObservedGeneration: pb.Generation,
+	}
+	switch {
+	case meta.IsStatusConditionTrue(pb.Status.Conditions, FrozenCondition):
+		ready.Status, ready.Reason = metav1.ConditionFalse, "Frozen"
+		ready.Message = "Changes are withheld, see the Frozen condition"
+	case meta.IsStatusConditionTrue(pb.Status.Conditions, BlockedCondition):
+		ready.Status, ready.Reason = metav1.ConditionFalse, "DeletionsBlocked"
+		ready.Message = "Deletions wait for acknowledgement, see the Blocked condition"
+	case len(failures) > 0:
+		ready.Status, ready.Reason = metav1.ConditionFalse, "Degraded"
+		ready.Message = "See the Degraded condition"
+	}
+	meta.SetStatusCondition(&pb.Status.Conditions, ready)
+}
+
+// setComponentCondition sets a component condition, or removes it when the
+// component is not configured
+func setComponentCondition(pb *permissionv1.PermissionBinder, condition metav1.Condition, configured bool) {
+	if !configured {
+		meta.RemoveStatusCondition(&pb.Status.Conditions, condition.Type)
+		return
+	}
+	meta.SetStatusCondition(&pb.Status.Conditions, condition)
+}
+
+// updateStatus applies the computed status with apply and writes it. On a conflict
+// (the LDAP controller or the NetworkPolicy code wrote the status in between) the
+// PermissionBinder is re-read and apply runs again on the latest version.
+func (r *PermissionBinderReconciler) updateStatus(ctx context.Context, pb *permissionv1.PermissionBinder, apply func(*permissionv1.PermissionBinder)) error {
+	first := true
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !first {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(pb), pb); err != nil {
+				return err
+			}
+		}
+		first = false
+		apply(pb)
+		return r.Status().Update(ctx, pb)
+	})
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+	"github.com/permission-binder-operator/operator/internal/controller/reportstore"
+)
+
+func TestReconcileConditions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 3,
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin"},
+			ClusterName:        "prod",
+		},
+	}
+	whitelist := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data: map[string]string{"whitelist.txt": "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com\n" +
+			"not-a-dn"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, whitelist).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(pb)
+
+	reconcile := func() permissionv1.PermissionBinder {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got permissionv1.PermissionBinder
+		if err := k8sClient.Get(ctx, key, &got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	expect := func(pb permissionv1.PermissionBinder, conditionType string, status metav1.ConditionStatus, reason string) {
+		t.Helper()
+		condition := meta.FindStatusCondition(pb.Status.Conditions, conditionType)
+		if condition == nil {
+			t.Errorf("%s condition missing", conditionType)
+			return
+		}
+		if condition.Status != status || condition.Reason != reason || condition.ObservedGeneration != pb.Generation {
+			t.Errorf("%s = %s/%s (generation %d), want %s/%s (generation %d)", conditionType,
+				condition.Status, condition.Reason, condition.ObservedGeneration, status, reason, pb.Generation)
+		}
+	}
+
+	got := reconcile()
+	if got.Status.ObservedGeneration != 3 {
+		t.Errorf("observedGeneration = %d, want 3", got.Status.ObservedGeneration)
+	}
+	expect(got, WhitelistParsedCondition, metav1.ConditionFalse, "InvalidEntries")
+	expect(got, RBACReconciledCondition, metav1.ConditionTrue, "Reconciled")
+	expect(got, DegradedCondition, metav1.ConditionTrue, "InvalidEntries")
+	expect(got, ReadyCondition, metav1.ConditionFalse, "Degraded")
+	if meta.FindStatusCondition(got.Status.Conditions, ServiceAccountsReconciledCondition) != nil ||
+		meta.FindStatusCondition(got.Status.Conditions, NetworkPoliciesSyncedCondition) != nil {
+		t.Error("conditions of features that are not configured must not be set")
+	}
+
+	whitelist.Data["whitelist.txt"] = "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com"
+	if err := k8sClient.Update(ctx, whitelist); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	expect(got, WhitelistParsedCondition, metav1.ConditionTrue, "Parsed")
+	expect(got, DegradedCondition, metav1.ConditionFalse, "NoFailures")
+	expect(got, ReadyCondition, metav1.ConditionTrue, "Reconciled")
+
+	// A new generation is processed even though the whitelist is unchanged
+	got.Spec.ServiceAccountMapping = map[string]string{"deploy": "edit"}
+	got.Generation++
+	if err := k8sClient.Update(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	if got.Status.ObservedGeneration != got.Generation {
+		t.Errorf("observedGeneration = %d, want %d", got.Status.ObservedGeneration, got.Generation)
+	}
+	expect(got, ServiceAccountsReconciledCondition, metav1.ConditionTrue, "Reconciled")
+	report, err := reportstore.Get(ctx, k8sClient, &got, "payments")
+	if err != nil || report == nil || !containsString(report.Status.ServiceAccounts, "payments/payments-sa-deploy") {
+		t.Errorf("report = %+v (%v), want the new ServiceAccount", report, err)
+	}
+}
+
+func TestSetSummaryConditions(t *testing.T) {
+	tests := []struct {
+		name            string
+		conditions      []metav1.Condition
+		wantDegraded    metav1.ConditionStatus
+		wantReady       metav1.ConditionStatus
+		wantReadyReason string
+	}{
+		{
+			name:            "all components reconciled",
+			conditions:      []metav1.Condition{{Type: RBACReconciledCondition, Status: metav1.ConditionTrue, Reason: "Reconciled"}},
+			wantDegraded:    metav1.ConditionFalse,
+			wantReady:       metav1.ConditionTrue,
+			wantReadyReason: "Reconciled",
+		},
+		{
+			name:            "LDAP sync failed",
+			conditions:      []metav1.Condition{{Type: LdapSyncedCondition, Status: metav1.ConditionFalse, Reason: "SyncFailed"}},
+			wantDegraded:    metav1.ConditionTrue,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "Degraded",
+		},
+		{
+			name:            "blocked deletions",
+			conditions:      []metav1.Condition{{Type: BlockedCondition, Status: metav1.ConditionTrue, Reason: "MassDeletion"}},
+			wantDegraded:    metav1.ConditionFalse,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "DeletionsBlocked",
+		},
+		{
+			name: "frozen takes precedence",
+			conditions: []metav1.Condition{
+				{Type: FrozenCondition, Status: metav1.ConditionTrue, Reason: "Paused"},
+				{Type: NetworkPoliciesSyncedCondition, Status: metav1.ConditionFalse, Reason: "NetworkPolicySyncFailed"},
+			},
+			wantDegraded:    metav1.ConditionTrue,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "Frozen",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pb := newPermissionBinder("operators", "team-a")
+			pb.Status.Conditions = tt.conditions
+			setSummaryConditions(pb)
+			degraded := meta.FindStatusCondition(pb.Status.Conditions, DegradedCondition)
+			ready := meta.FindStatusCondition(pb.Status.Conditions, ReadyCondition)
+			if degraded.Status != tt.wantDegraded {
+				t.Errorf("Degraded = %s (%s), want %s", degraded.Status, degraded.Message, tt.wantDegraded)
+			}
+			if ready.Status != tt.wantReady || ready.Reason != tt.wantReadyReason {
+				t.Errorf("Ready = %s/%s, want %s/%s", ready.Status, ready.Reason, tt.wantReady, tt.wantReadyReason)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusRetriesOnConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := newPermissionBinder("operators", "team-a")
+	conflicts := 1
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if conflicts > 0 {
+					conflicts--
+					// Another writer updated the status in between
+					var latest permissionv1.PermissionBinder
+					if err := c.Get(ctx, client.ObjectKeyFromObject(obj), &latest); err != nil {
+						return err
+					}
+					latest.Status.PendingLdapMemberRemovals = 7
+					if err := c.Status().Update(ctx, &latest); err != nil {
+						return err
+					}
+					return apierrors.NewConflict(schema.GroupResource{Resource: "permissionbinders"}, obj.GetName(), nil)
+				}
+				return c.SubResource(subResource).Update(ctx, obj, opts...)
+			},
+		}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+
+	var current permissionv1.PermissionBinder
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pb), &current); err != nil {
+		t.Fatal(err)
+	}
+	applied := 0
+	err := r.updateStatus(ctx, &current, func(pb *permissionv1.PermissionBinder) {
+		applied++
+		pb.Status.LastProcessedConfigMapVersion = "42"
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied != 2 {
+		t.Errorf("status applied %d times, want 2", applied)
+	}
+	var got permissionv1.PermissionBinder
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pb), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.LastProcessedConfigMapVersion != "42" || got.Status.PendingLdapMemberRemovals != 7 {
+		t.Errorf("status = %+v, want both writes kept", got.Status)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+// status.entryResults outcomes
+const (
+	EntryOutcomeBound             = "Bound"
+	EntryOutcomeExcluded          = "Excluded"
+	EntryOutcomeInvalid           = "Invalid"
+	EntryOutcomeLdapGroupMissing  = "LdapGroupMissing"
+	EntryOutcomeTransferred       = "Transferred"
+	EntryOutcomeOwnershipConflict = "OwnershipConflict"
+	EntryOutcomeFailed            = "Failed"
+
+	// maxReportedEntryResults bounds status.entryResults
+	maxReportedEntryResults = 100
+)
+
+// newEntryResult returns the status entry of a whitelist line
+func newEntryResult(entry whitelistEntry, outcome, reason string) permissionv1.WhitelistEntryResult {
+	return permissionv1.WhitelistEntryResult{
+		Line:      entry.Line,
+		CN:        entry.CN,
+		Namespace: entry.Namespace,
+		Role:      entry.Role,
+		Outcome:   outcome,
+		Reason:    reason,
+	}
+}
+
+// summarizeEntryResults counts the entry results per outcome and bounds the list
+// to maxReportedEntryResults. Entries that are not bound come first - they are the
+// ones tenants look for - each group ordered by line.
+func summarizeEntryResults(results []permissionv1.WhitelistEntryResult) (*permissionv1.WhitelistEntriesSummary, []permissionv1.WhitelistEntryResult) {
+	summary := &permissionv1.WhitelistEntriesSummary{Total: len(results)}
+	for _, result := range results {
+		switch result.Outcome {
+		case EntryOutcomeBound:
+			summary.Bound++
+		case EntryOutcomeExcluded:
+			summary.Excluded++
+		case EntryOutcomeInvalid:
+			summary.Invalid++
+		case EntryOutcomeLdapGroupMissing:
+			summary.LdapGroupMissing++
+		case EntryOutcomeTransferred:
+			summary.Transferred++
+		case EntryOutcomeOwnershipConflict:
+			summary.OwnershipConflict++
+		case EntryOutcomeFailed:
+			summary.Failed++
+		}
+	}
+
+	bounded := append([]permissionv1.WhitelistEntryResult(nil), results...)
+	sort.SliceStable(bounded, func(i, j int) bool {
+		iBound, jBound := bounded[i].Outcome == EntryOutcomeBound, bounded[j].Outcome == EntryOutcomeBound
+		if iBound != jBound {
+			return jBound
+		}
+		return bounded[i].Line < bounded[j].Line
+	})
+	if len(bounded) > maxReportedEntryResults {
+		bounded = bounded[:maxReportedEntryResults]
+	}
+	return summary, bounded
+}
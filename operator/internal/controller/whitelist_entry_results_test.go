@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+func TestReconcileEntryResults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 1,
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin"},
+			ExcludeList:        []string{"COMPANY-K8S-legacy-admin"},
+			ClusterName:        "prod",
+		},
+	}
+	whitelist := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data: map[string]string{"whitelist.txt": "# team A\n" +
+			"CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com\n" +
+			"CN=COMPANY-K8S-legacy-admin,OU=K8S,DC=example,DC=com\n" +
+			"CN=COMPANY-K8S-orders-owner,OU=K8S,DC=example,DC=com\n" +
+			"not-a-dn"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, whitelist).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pb)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got permissionv1.PermissionBinder
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pb), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	wantSummary := permissionv1.WhitelistEntriesSummary{Total: 4, Bound: 1, Excluded: 1, Invalid: 2}
+	if got.Status.Entries == nil || *got.Status.Entries != wantSummary {
+		t.Errorf("entries = %+v, want %+v", got.Status.Entries, wantSummary)
+	}
+	// Entries that are not bound first, by line
+	want := []struct {
+		line    int
+		outcome string
+	}{{3, EntryOutcomeExcluded}, {4, EntryOutcomeInvalid}, {5, EntryOutcomeInvalid}, {2, EntryOutcomeBound}}
+	if len(got.Status.EntryResults) != len(want) {
+		t.Fatalf("entryResults = %+v, want %d entries", got.Status.EntryResults, len(want))
+	}
+	for i, w := range want {
+		result := got.Status.EntryResults[i]
+		if result.Line != w.line || result.Outcome != w.outcome || result.Reason == "" {
+			t.Errorf("entryResults[%d] = %+v, want line %d %s with a reason", i, result, w.line, w.outcome)
+		}
+	}
+	bound := got.Status.EntryResults[3]
+	if bound.CN != "COMPANY-K8S-payments-admin" || bound.Namespace != "payments" || bound.Role != "admin" ||
+		bound.Reason != "RoleBinding payments/payments-admin" {
+		t.Errorf("bound entry = %+v", bound)
+	}
+}
+
+func TestSummarizeEntryResultsBounded(t *testing.T) {
+	var results []permissionv1.WhitelistEntryResult
+	for line := 1; line <= maxReportedEntryResults+20; line++ {
+		outcome := EntryOutcomeBound
+		if line%50 == 0 {
+			outcome = EntryOutcomeFailed
+		}
+		results = append(results, permissionv1.WhitelistEntryResult{Line: line, CN: fmt.Sprintf("cn-%d", line), Outcome: outcome})
+	}
+
+	summary, bounded := summarizeEntryResults(results)
+	if summary.Total != maxReportedEntryResults+20 || summary.Failed != 2 || summary.Bound != maxReportedEntryResults+18 {
+		t.Errorf("summary = %+v", summary)
+	}
+	if len(bounded) != maxReportedEntryResults {
+		t.Fatalf("len(entryResults) = %d, want %d", len(bounded), maxReportedEntryResults)
+	}
+	if bounded[0].Line != 50 || bounded[1].Line != 100 || bounded[2].Line != 1 {
+		t.Errorf("entryResults start with lines %d, %d, %d, want the failed lines 50 and 100 first",
+			bounded[0].Line, bounded[1].Line, bounded[2].Line)
+	}
+}
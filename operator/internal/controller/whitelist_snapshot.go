@@ -0,0 +1,289 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+)
+
+const (
+	// AnnotationRollbackTo on a PermissionBinder applies the whitelist snapshot with
+	// this hash instead of the whitelist source until the annotation is removed
+	AnnotationRollbackTo = "permission-binder.io/rollback-to"
+
+	// status.whitelistSnapshot.source values
+	WhitelistFromSource   = "Source"
+	WhitelistFromRollback = "Rollback"
+	WhitelistFromFallback = "Fallback"
+
+	// Reasons of the Events of snapshot fallbacks and failed rollbacks
+	EventReasonWhitelistFallback       = "WhitelistFallback"
+	EventReasonWhitelistRollbackFailed = "WhitelistRollbackFailed"
+
+	defaultWhitelistSnapshotHistory = 5
+
+	// maxWhitelistSnapshotBytes bounds the data of the snapshot ConfigMap below the
+	// 1 MiB object size limit, leaving room for its metadata
+	maxWhitelistSnapshotBytes = 900 * 1024
+
+	// Keys of the snapshot ConfigMap: the history (newest first) and one
+	// whitelist-<hash>.txt per snapshot
+	whitelistSnapshotHistoryKey = "history.json"
+	whitelistSnapshotKeyPrefix  = "whitelist-"
+
+	// whitelistSnapshotVersionPrefix marks lastProcessedConfigMapVersion values of snapshots
+	whitelistSnapshotVersionPrefix = "snapshot-"
+)
+
+// whitelistSnapshot is an entry of the history of the snapshot ConfigMap
+type whitelistSnapshot struct {
+	Hash      string      `json:"hash"`
+	AppliedAt metav1.Time `json:"appliedAt"`
+	// Version is the whitelist source version the snapshot was taken from
+	Version string `json:"version"`
+	Entries int    `json:"entries"`
+}
+
+// whitelistSnapshotsEnabled reports whether last-known-good snapshots are kept
+func whitelistSnapshotsEnabled(pb *permissionv1.PermissionBinder) bool {
+	return pb.Spec.WhitelistSnapshots != nil && pb.Spec.WhitelistSnapshots.Enabled
+}
+
+// whitelistSnapshotHistory returns the number of snapshots kept (default 5)
+func whitelistSnapshotHistory(pb *permissionv1.PermissionBinder) int {
+	if pb.Spec.WhitelistSnapshots == nil || pb.Spec.WhitelistSnapshots.History == nil || *pb.Spec.WhitelistSnapshots.History < 1 {
+		return defaultWhitelistSnapshotHistory
+	}
+	return int(*pb.Spec.WhitelistSnapshots.History)
+}
+
+// whitelistSnapshotConfigMapKey returns the snapshot ConfigMap of a PermissionBinder
+func whitelistSnapshotConfigMapKey(pb *permissionv1.PermissionBinder) types.NamespacedName {
+	return types.NamespacedName{Name: pb.Name + "-whitelist-snapshots", Namespace: pb.Namespace}
+}
+
+// snapshotWhitelistDNs returns the whitelist entries that parse (valid DN and
+// permission string, excluded ones included) as sorted, de-duplicated DNs
+func (r *PermissionBinderReconciler) snapshotWhitelistDNs(pb *permissionv1.PermissionBinder, whitelistContent string) []string {
+	seen := make(map[string]bool)
+	var dns []string
+	for _, entry := range r.parseWhitelist(pb, whitelistContent) {
+		if entry.Err != nil || seen[entry.DN] {
+			continue
+		}
+		seen[entry.DN] = true
+		dns = append(dns, entry.DN)
+	}
+	sort.Strings(dns)
+	return dns
+}
+
+// readWhitelistSnapshots returns the snapshot ConfigMap and its history, nil when it does not exist
+func (r *PermissionBinderReconciler) readWhitelistSnapshots(ctx context.Context, pb *permissionv1.PermissionBinder) (*corev1.ConfigMap, []whitelistSnapshot, error) {
+	key := whitelistSnapshotConfigMapKey(pb)
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, key, &configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get whitelist snapshot ConfigMap %s: %w", key, err)
+	}
+	var history []whitelistSnapshot
+	if raw := configMap.Data[whitelistSnapshotHistoryKey]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &history); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s of whitelist snapshot ConfigMap %s: %w", whitelistSnapshotHistoryKey, key, err)
+		}
+	}
+	return &configMap, history, nil
+}
+
+// recordWhitelistSnapshot stores the applied whitelist as the latest snapshot and
+// drops the snapshots beyond whitelistSnapshots.history, and the oldest ones as long
+// as the ConfigMap would exceed maxWhitelistSnapshotBytes. A whitelist too large to
+// be stored at all is an error; the previous snapshots are kept then.
+func (r *PermissionBinderReconciler) recordWhitelistSnapshot(ctx context.Context, pb *permissionv1.PermissionBinder, whitelistContent, version string, now time.Time) (*permissionv1.WhitelistSnapshotStatus, error) {
+	logger := log.FromContext(ctx)
+	key := whitelistSnapshotConfigMapKey(pb)
+
+	configMap, history, err := r.readWhitelistSnapshots(ctx, pb)
+	if err != nil {
+		return nil, err
+	}
+	exists := configMap != nil
+	if !exists {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					LabelManagedBy:                ManagedByValue,
+					"app.kubernetes.io/component": "whitelist-snapshots",
+				},
+				Annotations: map[string]string{
+					AnnotationManagedBy:                 ManagedByValue,
+					AnnotationPermissionBinder:          pb.Name,
+					AnnotationPermissionBinderNamespace: pb.Namespace,
+				},
+				// Operator state - removed together with the PermissionBinder
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: permissionv1.GroupVersion.String(),
+					Kind:       "PermissionBinder",
+					Name:       pb.Name,
+					UID:        pb.UID,
+				}},
+			},
+		}
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+
+	dns := r.snapshotWhitelistDNs(pb, whitelistContent)
+	hash := hashWhitelistDNs(dns)[:16]
+	status := &permissionv1.WhitelistSnapshotStatus{ConfigMap: key.Name, Current: hash}
+	if len(history) > 0 && history[0].Hash == hash {
+		// Unchanged - also the case for rollbacks to and fallbacks on the latest snapshot
+		status.Versions = len(history)
+		return status, nil
+	}
+
+	// A rollback to an older snapshot makes it the latest again
+	kept := []whitelistSnapshot{{Hash: hash, AppliedAt: metav1.Time{Time: now}, Version: version, Entries: len(dns)}}
+	for _, snapshot := range history {
+		if snapshot.Hash != hash {
+			kept = append(kept, snapshot)
+		}
+	}
+	configMap.Data[whitelistSnapshotKeyPrefix+hash+".txt"] = strings.Join(dns, "\n")
+	limit := whitelistSnapshotHistory(pb)
+	for {
+		raw, err := json.Marshal(kept)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode whitelist snapshot history: %w", err)
+		}
+		configMap.Data[whitelistSnapshotHistoryKey] = string(raw)
+		size := configMapDataSize(configMap)
+		if len(kept) <= limit && size <= maxWhitelistSnapshotBytes {
+			break
+		}
+		if len(kept) == 1 {
+			return nil, fmt.Errorf("whitelist snapshot %s needs %d bytes, more than the %d bytes of ConfigMap %s",
+				hash, size, maxWhitelistSnapshotBytes, key)
+		}
+		dropped := kept[len(kept)-1]
+		delete(configMap.Data, whitelistSnapshotKeyPrefix+dropped.Hash+".txt")
+		kept = kept[:len(kept)-1]
+	}
+
+	if exists {
+		err = r.Update(ctx, configMap)
+	} else {
+		err = r.Create(ctx, configMap)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write whitelist snapshot ConfigMap %s: %w", key, err)
+	}
+	logger.Info("📸 Whitelist snapshot recorded",
+		"configMap", key.String(),
+		"hash", hash,
+		"entries", len(dns),
+		"versions", len(kept))
+
+	status.Versions = len(kept)
+	return status, nil
+}
+
+// configMapDataSize returns the number of bytes of the keys and values of a ConfigMap
+func configMapDataSize(configMap *corev1.ConfigMap) int {
+	size := 0
+	for key, value := range configMap.Data {
+		size += len(key) + len(value)
+	}
+	for key, value := range configMap.BinaryData {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// loadWhitelistSnapshot returns the snapshot with the given hash (the latest for
+// an empty hash) as a whitelist ConfigMap, so that it goes through the same
+// processing as the source. Its ResourceVersion identifies the snapshot. An
+// empty hash without snapshots returns nil.
+func (r *PermissionBinderReconciler) loadWhitelistSnapshot(ctx context.Context, pb *permissionv1.PermissionBinder, hash string) (*corev1.ConfigMap, error) {
+	key := whitelistSnapshotConfigMapKey(pb)
+	configMap, history, err := r.readWhitelistSnapshots(ctx, pb)
+	if err != nil {
+		return nil, err
+	}
+	if hash == "" {
+		if len(history) == 0 {
+			return nil, nil
+		}
+		hash = history[0].Hash
+	}
+	var content string
+	found := false
+	if configMap != nil {
+		content, found = configMap.Data[whitelistSnapshotKeyPrefix+hash+".txt"]
+	}
+	if !found {
+		return nil, fmt.Errorf("whitelist snapshot %s not found in ConfigMap %s", hash, key)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pb.Spec.ConfigMapName,
+			Namespace:       pb.Spec.ConfigMapNamespace,
+			ResourceVersion: whitelistSnapshotVersionPrefix + hash,
+		},
+		Data: map[string]string{"whitelist.txt": content},
+	}, nil
+}
+
+// rolledBackWhitelist returns the snapshot named by permission-binder.io/rollback-to,
+// nil when the PermissionBinder is not rolled back
+func (r *PermissionBinderReconciler) rolledBackWhitelist(ctx context.Context, pb *permissionv1.PermissionBinder) (*corev1.ConfigMap, error) {
+	hash := strings.TrimSpace(pb.Annotations[AnnotationRollbackTo])
+	if hash == "" {
+		return nil, nil
+	}
+	if !whitelistSnapshotsEnabled(pb) {
+		return nil, fmt.Errorf("%s requires spec.whitelistSnapshots.enabled", AnnotationRollbackTo)
+	}
+	return r.loadWhitelistSnapshot(ctx, pb, hash)
+}
+
+// fallbackWhitelist returns the latest snapshot for a missing source ConfigMap,
+// nil when snapshots are disabled or none was taken yet
+func (r *PermissionBinderReconciler) fallbackWhitelist(ctx context.Context, pb *permissionv1.PermissionBinder) (*corev1.ConfigMap, error) {
+	if !whitelistSnapshotsEnabled(pb) {
+		return nil, nil
+	}
+	return r.loadWhitelistSnapshot(ctx, pb, "")
+}
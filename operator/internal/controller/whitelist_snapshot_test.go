@@ -0,0 +1,279 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	permissionv1 "github.com/permission-binder-operator/operator/api/v1"
+	"github.com/permission-binder-operator/operator/internal/controller/reportstore"
+)
+
+func TestWhitelistSnapshots(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	history := int32(2)
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 1,
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin"},
+			ClusterName:        "prod",
+			WhitelistSnapshots: &permissionv1.WhitelistSnapshotSpec{Enabled: true, History: &history},
+		},
+	}
+	source := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data:       map[string]string{"whitelist.txt": "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, source).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(pb)
+
+	reconcile := func() permissionv1.PermissionBinder {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got permissionv1.PermissionBinder
+		if err := k8sClient.Get(ctx, key, &got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	setWhitelist := func(whitelist string) {
+		t.Helper()
+		var configMap corev1.ConfigMap
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(source), &configMap); err != nil {
+			t.Fatal(err)
+		}
+		configMap.Data["whitelist.txt"] = whitelist
+		if err := k8sClient.Update(ctx, &configMap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first := reconcile().Status.WhitelistSnapshot
+	if first == nil || first.Current == "" || first.Versions != 1 || first.Source != WhitelistFromSource {
+		t.Fatalf("whitelistSnapshot = %+v, want the first snapshot", first)
+	}
+
+	// Comments, invalid lines and order do not make a new snapshot
+	setWhitelist("# payments\nnot-a-dn\nCN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com")
+	if got := reconcile().Status.WhitelistSnapshot; got.Current != first.Current || got.Versions != 1 {
+		t.Errorf("whitelistSnapshot = %+v, want the first snapshot unchanged", got)
+	}
+
+	setWhitelist("CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com\nCN=COMPANY-K8S-orders-admin,OU=K8S,DC=example,DC=com")
+	second := reconcile().Status.WhitelistSnapshot
+	setWhitelist("CN=COMPANY-K8S-orders-admin,OU=K8S,DC=example,DC=com")
+	third := reconcile().Status.WhitelistSnapshot
+	if third.Versions != 2 || third.Current == second.Current {
+		t.Fatalf("whitelistSnapshot = %+v, want 2 versions", third)
+	}
+	var snapshots corev1.ConfigMap
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: "team-a-whitelist-snapshots", Namespace: "operators"}, &snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snapshots.Data["whitelist-"+first.Current+".txt"]; ok {
+		t.Error("the snapshot beyond whitelistSnapshots.history must be dropped")
+	}
+	if snapshots.Data["whitelist-"+third.Current+".txt"] != "CN=COMPANY-K8S-orders-admin,OU=K8S,DC=example,DC=com" {
+		t.Errorf("snapshot data = %v", snapshots.Data)
+	}
+
+	// Rolling back applies an older snapshot
+	got := reconcile()
+	got.Annotations = map[string]string{AnnotationRollbackTo: second.Current}
+	if err := k8sClient.Update(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	if got.Status.WhitelistSnapshot.Source != WhitelistFromRollback ||
+		got.Status.LastProcessedConfigMapVersion != whitelistSnapshotVersionPrefix+second.Current {
+		t.Errorf("status = %+v / %s, want the rollback applied", got.Status.WhitelistSnapshot, got.Status.LastProcessedConfigMapVersion)
+	}
+	report, err := reportstore.Get(ctx, k8sClient, &got, "payments")
+	if err != nil || report == nil || !containsString(report.Status.RoleBindings, "payments/payments-admin") {
+		t.Errorf("report = %+v (%v), want the rolled back entries", report, err)
+	}
+
+	// An unknown snapshot is not applied
+	got.Annotations[AnnotationRollbackTo] = "0123456789abcdef"
+	if err := k8sClient.Update(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	if result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil || result.RequeueAfter != time.Minute {
+		t.Errorf("Reconcile() = %+v, %v, want a retry in a minute", result, err)
+	}
+
+	// Without the source ConfigMap the latest snapshot is used
+	if err := k8sClient.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	delete(got.Annotations, AnnotationRollbackTo)
+	if err := k8sClient.Update(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := k8sClient.Delete(ctx, source); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	if got.Status.WhitelistSnapshot.Source != WhitelistFromFallback || got.Status.WhitelistSnapshot.Current != second.Current {
+		t.Errorf("whitelistSnapshot = %+v, want a fallback on the latest snapshot", got.Status.WhitelistSnapshot)
+	}
+	if !strings.HasPrefix(got.Status.LastProcessedConfigMapVersion, whitelistSnapshotVersionPrefix) {
+		t.Errorf("lastProcessedConfigMapVersion = %s", got.Status.LastProcessedConfigMapVersion)
+	}
+}
+
+// TestRecordWhitelistSnapshotSizeLimit verifies that older snapshots are dropped to
+// keep the snapshot ConfigMap below maxWhitelistSnapshotBytes, and that a whitelist
+// too large on its own fails without touching the kept snapshots
+func TestRecordWhitelistSnapshotSizeLimit(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "operators"},
+		Spec: permissionv1.PermissionBinderSpec{
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin"},
+			WhitelistSnapshots: &permissionv1.WhitelistSnapshotSpec{Enabled: true},
+		},
+	}
+	r := &PermissionBinderReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	ctx := context.Background()
+	whitelist := func(variant string, entries int) string {
+		lines := make([]string, entries)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("CN=COMPANY-K8S-%s%05d-admin,OU=K8S,DC=example,DC=com", variant, i)
+		}
+		return strings.Join(lines, "\n")
+	}
+	snapshots := func() *corev1.ConfigMap {
+		t.Helper()
+		var configMap corev1.ConfigMap
+		if err := r.Get(ctx, whitelistSnapshotConfigMapKey(pb), &configMap); err != nil {
+			t.Fatal(err)
+		}
+		return &configMap
+	}
+
+	// About 300 KiB each - three fit, the default history of five does not
+	var status *permissionv1.WhitelistSnapshotStatus
+	for _, variant := range []string{"a", "b", "c", "d"} {
+		var err error
+		if status, err = r.recordWhitelistSnapshot(ctx, pb, whitelist(variant, 6000), variant, time.Now()); err != nil {
+			t.Fatalf("recordWhitelistSnapshot(%s) error: %v", variant, err)
+		}
+	}
+	configMap := snapshots()
+	if size := configMapDataSize(configMap); status.Versions >= 4 || size > maxWhitelistSnapshotBytes {
+		t.Fatalf("versions = %d, size = %d, want older snapshots dropped below %d bytes", status.Versions, size, maxWhitelistSnapshotBytes)
+	}
+	if len(configMap.Data) != status.Versions+1 {
+		t.Errorf("ConfigMap keys = %d, want the history and %d snapshots", len(configMap.Data), status.Versions)
+	}
+
+	if _, err := r.recordWhitelistSnapshot(ctx, pb, whitelist("e", 20000), "e", time.Now()); err == nil {
+		t.Fatal("expected an error for a whitelist larger than the snapshot ConfigMap")
+	}
+	if got := snapshots(); got.Data[whitelistSnapshotHistoryKey] != configMap.Data[whitelistSnapshotHistoryKey] {
+		t.Error("a failed snapshot must keep the previous snapshots")
+	}
+}
+
+// TestWhitelistSnapshotFailureCondition verifies that a failed snapshot write is
+// reported by the WhitelistSnapshotRecorded condition and retried
+func TestWhitelistSnapshotFailureCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = permissionv1.AddToScheme(scheme)
+
+	pb := &permissionv1.PermissionBinder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Namespace: "operators", Generation: 1,
+			Finalizers: []string{PermissionBinderFinalizer},
+		},
+		Spec: permissionv1.PermissionBinderSpec{
+			ConfigMapName:      "permission-config",
+			ConfigMapNamespace: "operators",
+			Prefixes:           []string{"COMPANY-K8S"},
+			RoleMapping:        map[string]string{"admin": "admin"},
+			WhitelistSnapshots: &permissionv1.WhitelistSnapshotSpec{Enabled: true},
+		},
+	}
+	source := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "permission-config", Namespace: "operators"},
+		Data:       map[string]string{"whitelist.txt": "CN=COMPANY-K8S-payments-admin,OU=K8S,DC=example,DC=com"},
+	}
+	failSnapshots := true
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pb, source).
+		WithStatusSubresource(&permissionv1.PermissionBinder{}, &permissionv1.PermissionBinderReport{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if failSnapshots && obj.GetName() == "team-a-whitelist-snapshots" {
+					return errors.New("apiserver unavailable")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	r := &PermissionBinderReconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(pb)
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil || result.RequeueAfter != time.Minute {
+		t.Fatalf("Reconcile() = %+v, %v, want a retry in a minute", result, err)
+	}
+	var got permissionv1.PermissionBinder
+	if err := k8sClient.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	condition := meta.FindStatusCondition(got.Status.Conditions, WhitelistSnapshotRecordedCondition)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "SnapshotFailed" {
+		t.Fatalf("WhitelistSnapshotRecorded = %+v, want False/SnapshotFailed", condition)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, DegradedCondition) {
+		t.Error("a failed snapshot must make the PermissionBinder Degraded")
+	}
+
+	failSnapshots = false
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+	if err := k8sClient.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, WhitelistSnapshotRecordedCondition) ||
+		got.Status.WhitelistSnapshot == nil || got.Status.WhitelistSnapshot.Versions != 1 {
+		t.Errorf("conditions = %+v, whitelistSnapshot = %+v, want the retried snapshot recorded",
+			got.Status.Conditions, got.Status.WhitelistSnapshot)
+	}
+}